@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// input reads records from one or more files of the same format, in order.
+// Files are opened only when the pipeline asks for their records, so a satisfied Take stops reading.
+// Fields named by the operations are checked against the columns of the first header or record.
+type input struct {
+	source   algo.Source[*Record]
+	columns  []string
+	seen     map[string]bool
+	files    []io.Closer
+	required []string
+	checked  bool
+}
+
+// formatFromPath guesses the input format from a file extension.
+func formatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return "jsonl"
+	case ".json":
+		return "json"
+	default:
+		return "csv"
+	}
+}
+
+// openInput returns an input over the given paths, where "-" stands for stdin.
+func openInput(paths []string, format string, stdin io.Reader) (*input, error) {
+	in := &input{seen: make(map[string]bool)}
+	var read func(name string, r io.Reader, index *int, yield func(*Record) bool) (bool, error)
+	switch format {
+	case "csv":
+		read = in.readCSV
+	case "jsonl":
+		read = in.readJSONLines
+	case "json":
+		read = in.readJSON
+	default:
+		return nil, fmt.Errorf("unknown input format %q", format)
+	}
+
+	in.source = func(yield func(*Record) bool) error {
+		index := 0
+		for _, path := range paths {
+			r, name := stdin, "stdin"
+			if path != "-" {
+				file, err := os.Open(path)
+				if err != nil {
+					return err
+				}
+				in.files = append(in.files, file)
+				r, name = file, path
+			}
+			more, err := read(name, r, &index, yield)
+			if err != nil || !more {
+				return err
+			}
+		}
+		return nil
+	}
+	return in, nil
+}
+
+// Close closes every file opened by the input.
+func (in *input) Close() error {
+	var errs []error
+	for _, file := range in.files {
+		errs = append(errs, file.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// addColumn records a column name in first-seen order.
+func (in *input) addColumn(name string) {
+	if !in.seen[name] {
+		in.seen[name] = true
+		in.columns = append(in.columns, name)
+	}
+}
+
+// checkColumns reports the first required field that is not a known column.
+// It checks only once, when the first header or record has been read.
+func (in *input) checkColumns() error {
+	if in.checked {
+		return nil
+	}
+	in.checked = true
+	for _, field := range in.required {
+		if !in.seen[field] {
+			return fmt.Errorf("unknown field %q, expected one of: %s", field, strings.Join(in.columns, ", "))
+		}
+	}
+	return nil
+}
+
+// outputColumns returns the input columns that occur in at least one of the records,
+// or all input columns if there are no records.
+func (in *input) outputColumns(records []*Record) []string {
+	if len(records) == 0 {
+		return in.columns
+	}
+	var columns []string
+	for _, column := range in.columns {
+		for _, r := range records {
+			if _, ok := r.Values[column]; ok {
+				columns = append(columns, column)
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// readCSV yields the rows of a CSV file with a header. It reports whether more records are wanted.
+func (in *input) readCSV(name string, r io.Reader, index *int, yield func(*Record) bool) (bool, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", name, err)
+	}
+	for i, column := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		in.addColumn(header[i])
+	}
+	if err := in.checkColumns(); err != nil {
+		return false, fmt.Errorf("%s: %w", name, err)
+	}
+
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return true, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", name, err)
+		}
+
+		values := make(map[string]any, len(header))
+		for i, column := range header {
+			values[column] = fields[i]
+		}
+		*index++
+		if !yield(&Record{Index: *index, Values: values}) {
+			return false, nil
+		}
+	}
+}
+
+// readJSONLines yields the objects of a JSON Lines file. It reports whether more records are wanted.
+func (in *input) readJSONLines(name string, r io.Reader, index *int, yield func(*Record) bool) (bool, error) {
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return false, fmt.Errorf("%s: %w", name, err)
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			values, decodeErr := in.decodeObject(data)
+			if decodeErr != nil {
+				return false, fmt.Errorf("%s:%d: %w", name, line, decodeErr)
+			}
+			if err := in.checkColumns(); err != nil {
+				return false, fmt.Errorf("%s:%d: %w", name, line, err)
+			}
+			*index++
+			if !yield(&Record{Index: *index, Values: values}) {
+				return false, nil
+			}
+		}
+		if err == io.EOF {
+			return true, nil
+		}
+	}
+}
+
+// readJSON yields the objects of a JSON array, decoding one element at a time.
+// A file that starts with an object instead is read as JSON Lines. It reports whether more records are wanted.
+func (in *input) readJSON(name string, r io.Reader, index *int, yield func(*Record) bool) (bool, error) {
+	reader := bufio.NewReader(r)
+	for {
+		b, err := reader.Peek(1)
+		if err == io.EOF {
+			return true, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", name, err)
+		}
+		if b[0] == '{' {
+			return in.readJSONLines(name, reader, index, yield)
+		}
+		if b[0] != ' ' && b[0] != '\t' && b[0] != '\r' && b[0] != '\n' {
+			break
+		}
+		_, _ = reader.ReadByte()
+	}
+
+	decoder := json.NewDecoder(reader)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return false, fmt.Errorf("%s: expected a JSON array of objects", name)
+	}
+	for element := 1; decoder.More(); element++ {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return false, fmt.Errorf("%s: element %d: %w", name, element, err)
+		}
+		values, err := in.decodeObject(raw)
+		if err == nil {
+			err = in.checkColumns()
+		}
+		if err != nil {
+			return false, fmt.Errorf("%s: element %d: %w", name, element, err)
+		}
+		*index++
+		if !yield(&Record{Index: *index, Values: values}) {
+			return false, nil
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return false, fmt.Errorf("%s: %w", name, err)
+	}
+	return true, nil
+}
+
+// decodeObject decodes a JSON object, recording its keys in the order they appear.
+func (in *input) decodeObject(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, errors.New("expected a JSON object")
+	}
+
+	values := make(map[string]any)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := token.(string)
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		values[key] = value
+		in.addColumn(key)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// writers holds the output formats by name.
+var writers = map[string]func(w io.Writer, columns []string, records []*Record) error{
+	"csv":   writeCSV,
+	"json":  writeJSON,
+	"jsonl": writeJSONLines,
+	"table": writeTable,
+}
+
+// writeCSV writes the records as CSV with a header.
+func writeCSV(w io.Writer, columns []string, records []*Record) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	row := make([]string, len(columns))
+	for _, r := range records {
+		for i, column := range columns {
+			row[i] = text(r.Values[column])
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeJSON writes the records as a JSON array of objects.
+func writeJSON(w io.Writer, columns []string, records []*Record) error {
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for i, r := range records {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n  ")
+		if err := appendObject(&buf, columns, r); err != nil {
+			return err
+		}
+	}
+	if len(records) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// writeJSONLines writes every record as a JSON object on its own line.
+func writeJSONLines(w io.Writer, columns []string, records []*Record) error {
+	var buf bytes.Buffer
+	for _, r := range records {
+		if err := appendObject(&buf, columns, r); err != nil {
+			return err
+		}
+		buf.WriteString("\n")
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// appendObject appends a record as a JSON object with its keys in column order.
+func appendObject(buf *bytes.Buffer, columns []string, r *Record) error {
+	buf.WriteString("{")
+	first := true
+	for _, column := range columns {
+		value, ok := r.Values[column]
+		if !ok {
+			continue
+		}
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if !first {
+			buf.WriteString(",")
+		}
+		first = false
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(encoded)
+	}
+	buf.WriteString("}")
+	return nil
+}
+
+// writeTable writes the records as an aligned text table.
+func writeTable(w io.Writer, columns []string, records []*Record) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	separators := make([]string, len(columns))
+	for i, column := range columns {
+		separators[i] = strings.Repeat("-", len(column))
+	}
+	fmt.Fprintln(table, strings.Join(columns, "\t"))
+	fmt.Fprintln(table, strings.Join(separators, "\t"))
+
+	row := make([]string, len(columns))
+	for _, r := range records {
+		for i, column := range columns {
+			row[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(text(r.Values[column]))
+		}
+		fmt.Fprintln(table, strings.Join(row, "\t"))
+	}
+	return table.Flush()
+}
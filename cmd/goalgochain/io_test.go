@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOpenInput_StopsReadingEarly(t *testing.T) {
+	var lines strings.Builder
+	lines.WriteString("n\n")
+	for i := 0; i < 100000; i++ {
+		lines.WriteString("1\n")
+	}
+	reader := &countingReader{r: strings.NewReader(lines.String())}
+
+	in, err := openInput([]string{"-"}, "csv", reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pipeline, _ := buildPipeline([]step{{name: "take", arg: "3"}})
+	records, err := pipeline.ExecuteSource(in.source)
+	if err != nil || len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d (%v)", len(records), err)
+	}
+	if reader.n > 8192 {
+		t.Errorf("Expected to stop reading early, read %d bytes", reader.n)
+	}
+}
+
+// countingReader counts how many bytes have been read from the underlying reader.
+type countingReader struct {
+	r interface{ Read([]byte) (int, error) }
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestOutputColumns_JSONLines(t *testing.T) {
+	in, _ := openInput([]string{"-"}, "jsonl", strings.NewReader(`{"b":1,"a":2}`+"\n"+`{"c":3,"a":4}`))
+	pipeline, _ := buildPipeline([]step{{name: "filter", arg: "a = 4"}})
+	records, err := pipeline.ExecuteSource(in.source)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	columns := in.outputColumns(records)
+	if strings.Join(columns, ",") != "a,c" {
+		t.Errorf("Expected columns [a c], got %v", columns)
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, columns, records); err != nil || buf.String() != "a,c\n4,3\n" {
+		t.Errorf("Unexpected CSV output %q (%v)", buf.String(), err)
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	cases := map[string]string{
+		"a.csv": "csv", "b.JSONL": "jsonl", "c.ndjson": "jsonl", "e.json": "json", "-": "csv", "d": "csv",
+	}
+	for path, expected := range cases {
+		if got := formatFromPath(path); got != expected {
+			t.Errorf("%s: expected %s, got %s", path, expected, got)
+		}
+	}
+}
+
+func TestWriters_Empty(t *testing.T) {
+	for name, write := range writers {
+		var buf bytes.Buffer
+		if err := write(&buf, []string{"a"}, nil); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+	var buf bytes.Buffer
+	_ = writeJSON(&buf, nil, nil)
+	if buf.String() != "[]\n" {
+		t.Errorf("Expected an empty JSON array, got %q", buf.String())
+	}
+}
+
+func TestReadJSON_EmptyArray(t *testing.T) {
+	for _, data := range []string{"", "  \n", "[]", " [ ]\n"} {
+		in, _ := openInput([]string{"-"}, "json", strings.NewReader(data))
+		in.required = []string{"id"}
+		pipeline, _ := buildPipeline(nil)
+		records, err := pipeline.ExecuteSource(in.source)
+		if err != nil || len(records) != 0 {
+			t.Errorf("%q: expected no records, got %d (%v)", data, len(records), err)
+		}
+	}
+}
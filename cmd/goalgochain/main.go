@@ -0,0 +1,121 @@
+// Command goalgochain runs pkg/algo pipelines over CSV, JSON or JSON Lines files from the shell.
+//
+// Operations are applied in the order they are given on the command line:
+//
+//	goalgochain --filter 'amount > 100' --sort 'amount desc' --take 10 orders.csv
+//	goalgochain --distinct category --output table orders.csv
+//	cat events.jsonl | goalgochain --input jsonl --filter 'level = error' --output json
+//	goalgochain --spec report.spec orders.csv
+//
+// A spec file lists one operation per line, such as "filter status = completed" or "take 10";
+// blank lines and lines starting with # are ignored. Fields named by the operations must be columns
+// of the CSV header or keys of the first JSON object, otherwise the command fails.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command with the given arguments and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var steps []step
+	addStep := func(name string) func(string) error {
+		return func(arg string) error {
+			steps = append(steps, step{name: name, arg: arg})
+			return nil
+		}
+	}
+
+	flags := flag.NewFlagSet("goalgochain", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Func("filter", "keep records matching `condition`, e.g. 'amount > 100' (repeatable)", addStep("filter"))
+	flags.Func("sort", "sort by `keys`, e.g. 'category, amount desc'", addStep("sort"))
+	flags.Func("take", "keep the first `n` records", addStep("take"))
+	flags.Func("skip", "drop the first `n` records", addStep("skip"))
+	flags.Func("distinct", "keep the first record for each combination of `fields`, e.g. 'user,category'",
+		addStep("distinct"))
+	flags.Func("spec", "read operations from `file`, one per line", func(path string) error {
+		spec, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		specSteps, err := parseSpec(bytes.NewReader(spec))
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		steps = append(steps, specSteps...)
+		return nil
+	})
+	inputFormat := flags.String("input", "",
+		"input `format`: csv, json or jsonl (default: from the file extension, else csv)")
+	outputFormat := flags.String("output", "csv", "output `format`: csv, json, jsonl or table")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: goalgochain [operations] [file ...]")
+		fmt.Fprintln(stderr, "Reads standard input when no file is given. Operations run in the order given.")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if err := execute(steps, flags.Args(), *inputFormat, *outputFormat, stdin, stdout); err != nil {
+		fmt.Fprintf(stderr, "goalgochain: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// execute reads the inputs, runs the operations and writes the result.
+func execute(
+	steps []step, paths []string, inputFormat, outputFormat string, stdin io.Reader, stdout io.Writer,
+) (err error) {
+	pipeline, err := buildPipeline(steps)
+	if err != nil {
+		return err
+	}
+	write, ok := writers[outputFormat]
+	if !ok {
+		return fmt.Errorf("unknown output format %q", outputFormat)
+	}
+
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+	if inputFormat == "" {
+		inputFormat = formatFromPath(paths[0])
+	}
+	input, err := openInput(paths, strings.ToLower(inputFormat), stdin)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, input.Close())
+	}()
+	input.required = referencedFields(steps)
+
+	records, err := pipeline.ExecuteSource(input.source)
+	if err != nil {
+		return err
+	}
+	// A streamed filter cannot return its error, so it stops the stream and keeps it.
+	for _, op := range pipeline.GetOperations() {
+		if f, ok := op.(*filterOperation); ok && f.Err() != nil {
+			return fmt.Errorf("filter: %w", f.Err())
+		}
+	}
+	return write(stdout, input.outputColumns(records), records)
+}
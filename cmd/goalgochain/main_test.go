@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const ordersCSV = `id,category,amount
+1,books,120
+2,games,80
+3,books,300
+4,toys,150
+5,games,99.5
+`
+
+func runCommand(t *testing.T, stdin string, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func TestRun_OperationsInOrder(t *testing.T) {
+	stdout, stderr, code := runCommand(t, ordersCSV,
+		"--filter", "amount > 90", "--sort", "amount desc", "--take", "2")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+	}
+	expected := "id,category,amount\n3,books,300\n4,toys,150\n"
+	if stdout != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, stdout)
+	}
+
+	// Taking before sorting sorts only the first two records.
+	stdout, _, _ = runCommand(t, ordersCSV, "--take", "2", "--sort", "amount desc")
+	if stdout != "id,category,amount\n1,books,120\n2,games,80\n" {
+		t.Errorf("Unexpected output: %q", stdout)
+	}
+}
+
+func TestRun_DistinctAndTable(t *testing.T) {
+	stdout, _, code := runCommand(t, ordersCSV, "--distinct", "category", "--output", "table")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	expected := "id  category  amount\n" +
+		"--  --------  ------\n" +
+		"1   books     120\n" +
+		"2   games     80\n" +
+		"4   toys      150\n"
+	if stdout != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, stdout)
+	}
+}
+
+func TestRun_JSONLinesFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "a.jsonl")
+	second := filepath.Join(dir, "b.jsonl")
+	if err := os.WriteFile(first, []byte(`{"id":1,"level":"info","ms":12}
+{"id":2,"level":"error","ms":340,"tags":["db"]}
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte(`{"id":3,"level":"error","ms":25.5}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, code := runCommand(t, "",
+		"--filter", "level = 'error'", "--sort", "ms", "--output", "json", first, second)
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+	}
+	expected := "[\n" +
+		`  {"id":3,"level":"error","ms":25.5},` + "\n" +
+		`  {"id":2,"level":"error","ms":340,"tags":["db"]}` + "\n" +
+		"]\n"
+	if stdout != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, stdout)
+	}
+}
+
+func TestRun_SpecFile(t *testing.T) {
+	spec := filepath.Join(t.TempDir(), "report.spec")
+	content := "# top books\nfilter category = books\n\nsort amount desc\ntake 1\n"
+	if err := os.WriteFile(spec, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, code := runCommand(t, ordersCSV, "--spec", spec, "--output", "jsonl")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+	}
+	if stdout != `{"id":"3","category":"books","amount":"300"}`+"\n" {
+		t.Errorf("Unexpected output: %q", stdout)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	cases := map[string][]string{
+		"bad filter":      {"--filter", "amount"},
+		"bad take":        {"--take", "-1"},
+		"bad output":      {"--output", "xml"},
+		"bad input":       {"--input", "xml"},
+		"missing file":    {"does-not-exist.csv"},
+		"missing spec":    {"--spec", "does-not-exist.spec"},
+		"unknown flag":    {"--limit", "3"},
+		"bad sort order":  {"--sort", "amount sideways"},
+		"trailing tokens": {"--filter", "amount > 100 && category = x"},
+	}
+	for name, args := range cases {
+		_, stderr, code := runCommand(t, ordersCSV, args...)
+		if code == 0 {
+			t.Errorf("%s: expected a non-zero exit code", name)
+		}
+		if stderr == "" {
+			t.Errorf("%s: expected an error message", name)
+		}
+	}
+
+	_, stderr, code := runCommand(t, "{\"id\":1}\nnot json\n", "--input", "jsonl")
+	if code != 1 || !strings.Contains(stderr, "stdin:2") {
+		t.Errorf("Expected an error pointing at stdin:2, got %d %q", code, stderr)
+	}
+
+	_, stderr, code = runCommand(t, ordersCSV+"6,toys,abc\n", "--filter", "amount > 100")
+	if code != 1 || !strings.Contains(stderr, `amount is "abc"`) {
+		t.Errorf("Expected a non-numeric amount error, got %d %q", code, stderr)
+	}
+}
+
+func TestRun_UnknownFields(t *testing.T) {
+	cases := map[string][]string{
+		"amout":  {"--filter", "amout > 100"},
+		"cost":   {"--sort", "category, cost desc"},
+		"seller": {"--distinct", "category,seller"},
+	}
+	for field, args := range cases {
+		stdout, stderr, code := runCommand(t, ordersCSV, args...)
+		if code != 1 || stdout != "" || !strings.Contains(stderr, `unknown field "`+field+`"`) {
+			t.Errorf("%s: expected an unknown field error, got %d %q %q", field, code, stdout, stderr)
+		}
+	}
+
+	_, stderr, code := runCommand(t, `{"id":1,"level":"info"}`+"\n", "--input", "jsonl", "--filter", "lvl = info")
+	if code != 1 || !strings.Contains(stderr, `stdin:1: unknown field "lvl", expected one of: id, level`) {
+		t.Errorf("Expected an unknown field error, got %d %q", code, stderr)
+	}
+}
+
+func TestRun_JSONArrayFile(t *testing.T) {
+	dir := t.TempDir()
+	array := filepath.Join(dir, "orders.json")
+	if err := os.WriteFile(array, []byte(`[
+  {"id": 1, "amount": 120},
+  {"id": 2, "amount": 80}
+]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr, code := runCommand(t, "", "--filter", "amount > 100", "--output", "jsonl", array)
+	if code != 0 || stdout != `{"id":1,"amount":120}`+"\n" {
+		t.Errorf("Unexpected result %d %q %q", code, stdout, stderr)
+	}
+
+	// A .json file holding JSON Lines is still read.
+	lines := filepath.Join(dir, "events.json")
+	if err := os.WriteFile(lines, []byte(`{"id":1}`+"\n"+`{"id":2}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stdout, _, code = runCommand(t, "", "--skip", "1", lines)
+	if code != 0 || stdout != "id\n2\n" {
+		t.Errorf("Unexpected result %d %q", code, stdout)
+	}
+
+	_, stderr, code = runCommand(t, `[{"id":1}, 2]`, "--input", "json")
+	if code != 1 || !strings.Contains(stderr, "element 2: expected a JSON object") {
+		t.Errorf("Expected an error for a non-object element, got %d %q", code, stderr)
+	}
+}
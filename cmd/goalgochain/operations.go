@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// step is one operation requested on the command line or in a spec file.
+type step struct {
+	name string
+	arg  string
+}
+
+// parseSpec reads one operation per line in the form "name argument".
+// Blank lines and lines starting with # are ignored.
+func parseSpec(r io.Reader) ([]step, error) {
+	var steps []step
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, arg, _ := strings.Cut(text, " ")
+		steps = append(steps, step{name: strings.ToLower(name), arg: strings.TrimSpace(arg)})
+		if _, err := buildPipeline(steps[len(steps)-1:]); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return steps, scanner.Err()
+}
+
+// buildPipeline translates the steps into pipeline operations.
+func buildPipeline(steps []step) (*algo.Pipeline[*Record], error) {
+	pipeline := algo.NewPipeline[*Record]()
+	for _, s := range steps {
+		switch s.name {
+		case "filter":
+			c, err := parseCondition(s.arg)
+			if err != nil {
+				return nil, fmt.Errorf("filter %q: %w", s.arg, err)
+			}
+			pipeline.AddOperation(&filterOperation{condition: c})
+		case "sort":
+			keys, err := parseSortKeys(s.arg)
+			if err != nil {
+				return nil, fmt.Errorf("sort %q: %w", s.arg, err)
+			}
+			pipeline.AddOperation(&sortOperation{keys: keys})
+		case "take", "skip":
+			n, err := strconv.Atoi(s.arg)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("%s %q: expected a non-negative count", s.name, s.arg)
+			}
+			if s.name == "take" {
+				pipeline.Take(n)
+			} else {
+				pipeline.Skip(n)
+			}
+		case "distinct":
+			fields := splitFields(s.arg)
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("distinct: expected at least one field")
+			}
+			pipeline.AddOperation(&distinctOperation{fields: fields})
+		default:
+			return nil, fmt.Errorf("unknown operation %q", s.name)
+		}
+	}
+	return pipeline, nil
+}
+
+// referencedFields returns the fields named by the steps, to be checked against the input columns.
+func referencedFields(steps []step) []string {
+	var fields []string
+	for _, s := range steps {
+		switch s.name {
+		case "filter":
+			if match := conditionPattern.FindStringSubmatch(s.arg); match != nil {
+				fields = append(fields, match[1])
+			}
+		case "sort":
+			for _, part := range strings.Split(s.arg, ",") {
+				if words := strings.Fields(part); len(words) > 0 {
+					fields = append(fields, words[0])
+				}
+			}
+		case "distinct":
+			fields = append(fields, splitFields(s.arg)...)
+		}
+	}
+	return fields
+}
+
+// conditionPattern matches "field operator value", where the value is a single word or a quoted string.
+var conditionPattern = regexp.MustCompile(`^\s*([^\s=!<>]+)\s*(==|!=|<=|>=|=|<|>)\s*('[^']*'|"[^"]*"|[^\s'"]*)\s*$`)
+
+// condition is a parsed filter such as "amount > 100" or "status = 'completed'".
+// An unquoted numeric value compares numerically; any other value compares as text.
+type condition struct {
+	field   string
+	accept  func(order int) bool
+	value   string
+	number  float64
+	numeric bool
+}
+
+// parseCondition parses a condition of the form "field operator value".
+func parseCondition(text string) (*condition, error) {
+	match := conditionPattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil, fmt.Errorf("expected 'field operator value' with one of = != < <= > >=, quoting values with spaces")
+	}
+	c := &condition{field: match[1], value: unquote(match[3])}
+	if c.value == match[3] {
+		c.number, c.numeric = number(c.value)
+	}
+
+	switch match[2] {
+	case "=", "==":
+		c.accept = func(order int) bool { return order == 0 }
+	case "!=":
+		c.accept = func(order int) bool { return order != 0 }
+	case "<":
+		c.accept = func(order int) bool { return order < 0 }
+	case "<=":
+		c.accept = func(order int) bool { return order <= 0 }
+	case ">":
+		c.accept = func(order int) bool { return order > 0 }
+	default:
+		c.accept = func(order int) bool { return order >= 0 }
+	}
+	return c, nil
+}
+
+// match reports whether a record satisfies the condition. Against a numeric value, a missing or
+// empty field never matches and any other value that is not a number is an error.
+func (c *condition) match(r *Record) (bool, error) {
+	v := r.Values[c.field]
+	if !c.numeric {
+		return c.accept(strings.Compare(text(v), c.value)), nil
+	}
+	if missing(v) {
+		return false, nil
+	}
+	n, ok := number(v)
+	if !ok {
+		return false, fmt.Errorf("record %d: %s is %q, not a number", r.Index, c.field, text(v))
+	}
+	return c.accept(cmp.Compare(n, c.number)), nil
+}
+
+// filterOperation keeps the records that satisfy a condition. It streams, so a failed match
+// stops the stream and is reported by Err.
+type filterOperation struct {
+	condition *condition
+	err       error
+}
+
+// Apply performs the filter on the records.
+func (f *filterOperation) Apply(records []*Record) ([]*Record, error) {
+	kept := make([]*Record, 0, len(records))
+	for _, r := range records {
+		ok, err := f.condition.match(r)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			kept = append(kept, r)
+		}
+	}
+	return kept, nil
+}
+
+// Stream filters records one at a time.
+func (f *filterOperation) Stream(emit func(*Record) bool) func(*Record) bool {
+	f.err = nil
+	return func(r *Record) bool {
+		ok, err := f.condition.match(r)
+		if err != nil {
+			f.err = err
+			return false
+		}
+		return !ok || emit(r)
+	}
+}
+
+// Err returns the error that stopped the last stream.
+func (f *filterOperation) Err() error {
+	return f.err
+}
+
+// unquote removes matching single or double quotes around s.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// sortKey is one field of a sort specification.
+type sortKey struct {
+	field      string
+	descending bool
+}
+
+// parseSortKeys parses a sort specification such as "category, amount desc".
+func parseSortKeys(spec string) ([]sortKey, error) {
+	var keys []sortKey
+	for _, part := range strings.Split(spec, ",") {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, fmt.Errorf("expected 'field [asc|desc]'")
+		}
+		key := sortKey{field: words[0]}
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				key.descending = true
+			default:
+				return nil, fmt.Errorf("unknown direction %q", words[1])
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sortOperation sorts records by their keys. A field sorts numerically when every present value
+// is a number, with missing and empty values first, and as text otherwise.
+// Records that compare equal keep their input order.
+type sortOperation struct {
+	keys []sortKey
+}
+
+// Apply performs the sort on the records.
+func (s *sortOperation) Apply(records []*Record) ([]*Record, error) {
+	compares := make([]func(a, b *Record) int, len(s.keys))
+	for i, key := range s.keys {
+		field := key.field
+		compares[i] = func(a, b *Record) int { return strings.Compare(text(a.Values[field]), text(b.Values[field])) }
+		if numericField(records, field) {
+			compares[i] = func(a, b *Record) int { return compareNumbers(a.Values[field], b.Values[field]) }
+		}
+		if key.descending {
+			compare := compares[i]
+			compares[i] = func(a, b *Record) int { return -compare(a, b) }
+		}
+	}
+
+	return (&algo.MergeSortOperation[*Record]{Comparator: func(a, b *Record) bool {
+		for _, compare := range compares {
+			if order := compare(a, b); order != 0 {
+				return order < 0
+			}
+		}
+		return a.Index < b.Index
+	}}).Apply(records)
+}
+
+// numericField reports whether every present value of a field is a number.
+func numericField(records []*Record, field string) bool {
+	for _, r := range records {
+		if v := r.Values[field]; !missing(v) {
+			if _, ok := number(v); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// compareNumbers orders two values of a numeric field, with missing values first.
+func compareNumbers(a, b any) int {
+	x, okA := number(a)
+	y, okB := number(b)
+	switch {
+	case !okA || !okB:
+		return cmp.Compare(boolOrder(okA), boolOrder(okB))
+	}
+	return cmp.Compare(x, y)
+}
+
+// boolOrder orders false before true.
+func boolOrder(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// splitFields splits a comma-separated field list.
+func splitFields(list string) []string {
+	var fields []string
+	for _, field := range strings.Split(list, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// distinctOperation keeps the first record for every combination of values of the fields.
+type distinctOperation struct {
+	fields []string
+}
+
+// Apply performs the distinct operation on the records.
+func (d *distinctOperation) Apply(records []*Record) ([]*Record, error) {
+	seen := make(map[string]bool)
+	distinct := make([]*Record, 0, len(records))
+	for _, r := range records {
+		key := make([]string, len(d.fields))
+		for i, field := range d.fields {
+			key[i] = text(r.Values[field])
+		}
+		if joined := strings.Join(key, "\x00"); !seen[joined] {
+			seen[joined] = true
+			distinct = append(distinct, r)
+		}
+	}
+	return distinct, nil
+}
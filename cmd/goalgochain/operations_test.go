@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseCondition(t *testing.T) {
+	record := &Record{Values: map[string]any{"amount": "120", "status": "completed", "score": json.Number("9.5")}}
+	cases := map[string]bool{
+		"amount > 100":          true,
+		"amount>100":            true,
+		"amount <= 119.99":      false,
+		"amount == 120.0":       true,
+		"status = completed":    true,
+		"status != 'completed'": false,
+		`status = "pending"`:    false,
+		"score >= 9":            true,
+		"missing = ''":          true,
+		"amount > 9":            true,  // numeric, not textual, comparison
+		"amount = '120.0'":      false, // quoted values compare as text
+		"missing > 1":           false,
+	}
+	for condition, expected := range cases {
+		c, err := parseCondition(condition)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", condition, err)
+		}
+		if ok, err := c.match(record); err != nil || ok != expected {
+			t.Errorf("%q: expected %v, got %v (%v)", condition, expected, ok, err)
+		}
+	}
+
+	invalid := []string{"", "amount", "amount ~ 3", "> 3", "amount > 100 && category = x", "status = 'a' b"}
+	for _, condition := range invalid {
+		if _, err := parseCondition(condition); err == nil {
+			t.Errorf("%q: expected error", condition)
+		}
+	}
+}
+
+func TestFilterOperation_NonNumericValue(t *testing.T) {
+	records := []*Record{
+		{Index: 1, Values: map[string]any{"amount": "120"}},
+		{Index: 2, Values: map[string]any{"amount": "abc"}},
+	}
+	c, err := parseCondition("amount > 100")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	op := &filterOperation{condition: c}
+	if _, err := op.Apply(records); err == nil || !strings.Contains(err.Error(), `record 2: amount is "abc"`) {
+		t.Errorf("Expected a non-numeric error, got %v", err)
+	}
+
+	var kept []*Record
+	visit := op.Stream(func(r *Record) bool {
+		kept = append(kept, r)
+		return true
+	})
+	for _, r := range records {
+		if !visit(r) {
+			break
+		}
+	}
+	if len(kept) != 1 || op.Err() == nil {
+		t.Errorf("Expected the stream to stop with an error, got %d records and %v", len(kept), op.Err())
+	}
+}
+
+func TestSortOperation_MixedField(t *testing.T) {
+	records := []*Record{
+		{Index: 1, Values: map[string]any{"v": "10"}},
+		{Index: 2, Values: map[string]any{"v": "apple"}},
+		{Index: 3, Values: map[string]any{"v": "9"}},
+		{Index: 4, Values: map[string]any{"v": ""}},
+		{Index: 5, Values: map[string]any{"v": "2"}},
+	}
+	// One text value makes the whole field sort as text, so the order is total.
+	result, err := (&sortOperation{keys: []sortKey{{field: "v"}}}).Apply(slices.Clone(records))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var order []int
+	for _, r := range result {
+		order = append(order, r.Index)
+	}
+	if fmt.Sprint(order) != "[4 1 5 3 2]" {
+		t.Errorf("Expected [4 1 5 3 2], got %v", order)
+	}
+
+	// Missing values sort first in a numeric field.
+	records[1].Values["v"] = nil
+	result, _ = (&sortOperation{keys: []sortKey{{field: "v", descending: true}}}).Apply(records)
+	order = order[:0]
+	for _, r := range result {
+		order = append(order, r.Index)
+	}
+	if fmt.Sprint(order) != "[1 3 5 2 4]" {
+		t.Errorf("Expected [1 3 5 2 4], got %v", order)
+	}
+}
+
+func TestParseSortKeys(t *testing.T) {
+	records := []*Record{
+		{Index: 1, Values: map[string]any{"cat": "b", "n": "2"}},
+		{Index: 2, Values: map[string]any{"cat": "a", "n": "10"}},
+		{Index: 3, Values: map[string]any{"cat": "b", "n": "10"}},
+		{Index: 4, Values: map[string]any{"cat": "a", "n": "10"}},
+	}
+	pipeline, err := buildPipeline([]step{{name: "sort", arg: "cat, n DESC"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result, err := pipeline.WithData(records).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var order []int
+	for _, r := range result {
+		order = append(order, r.Index)
+	}
+	if len(order) != 4 || order[0] != 2 || order[1] != 4 || order[2] != 3 || order[3] != 1 {
+		t.Errorf("Expected [2 4 3 1], got %v", order)
+	}
+
+	for _, spec := range []string{"", "cat,", "n up", "a b c"} {
+		if _, err := parseSortKeys(spec); err == nil {
+			t.Errorf("%q: expected error", spec)
+		}
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	steps, err := parseSpec(strings.NewReader("# comment\nFILTER a > 1\n\n  take 5\ndistinct a, b\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(steps) != 3 || steps[0] != (step{"filter", "a > 1"}) || steps[1] != (step{"take", "5"}) {
+		t.Errorf("Unexpected steps: %v", steps)
+	}
+
+	_, err = parseSpec(strings.NewReader("take 5\nlimit 3\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error on line 2, got %v", err)
+	}
+}
+
+func TestDistinctOperation(t *testing.T) {
+	records := []*Record{
+		{Index: 1, Values: map[string]any{"u": "1", "c": "x"}},
+		{Index: 2, Values: map[string]any{"u": "1", "c": "y"}},
+		{Index: 3, Values: map[string]any{"u": "1", "c": "x"}},
+		{Index: 4, Values: map[string]any{"u": "2"}},
+		{Index: 5, Values: map[string]any{"u": "2", "c": ""}},
+	}
+	result, _ := (&distinctOperation{fields: []string{"u", "c"}}).Apply(records)
+	if len(result) != 3 || result[0].Index != 1 || result[1].Index != 2 || result[2].Index != 4 {
+		t.Errorf("Expected records 1, 2 and 4, got %d records", len(result))
+	}
+}
+
+func TestReferencedFields(t *testing.T) {
+	steps := []step{
+		{name: "filter", arg: "amount >= 100"},
+		{name: "sort", arg: "category, placed desc"},
+		{name: "take", arg: "3"},
+		{name: "distinct", arg: "user, category"},
+	}
+	expected := []string{"amount", "category", "placed", "user", "category"}
+	if got := referencedFields(steps); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
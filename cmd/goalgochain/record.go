@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Record is one input row keyed by column name.
+// CSV values are strings; JSON values are decoded with json.Number for numbers.
+// Pipelines run over *Record so that the element type is comparable.
+type Record struct {
+	Index  int
+	Values map[string]any
+}
+
+// number returns v as a float64 if it is numeric or a string holding a number.
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// text returns the display form of v: strings as-is, missing values as "" and
+// nested JSON values as JSON.
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	}
+}
+
+// missing reports whether v is absent or empty.
+func missing(v any) bool {
+	return v == nil || v == ""
+}
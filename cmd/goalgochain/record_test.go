@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNumber(t *testing.T) {
+	cases := []struct {
+		v        any
+		expected float64
+		ok       bool
+	}{
+		{"10", 10, true},
+		{" 2.5 ", 2.5, true},
+		{json.Number("9.5"), 9.5, true},
+		{float64(3), 3, true},
+		{"abc", 0, false},
+		{nil, 0, false},
+		{true, 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := number(tc.v)
+		if got != tc.expected || ok != tc.ok {
+			t.Errorf("number(%v): expected %v %v, got %v %v", tc.v, tc.expected, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	cases := []struct {
+		v        any
+		expected string
+	}{
+		{"apple", "apple"},
+		{json.Number("2.50"), "2.50"},
+		{nil, ""},
+		{true, "true"},
+		{[]any{"x"}, `["x"]`},
+	}
+	for _, tc := range cases {
+		if got := text(tc.v); got != tc.expected {
+			t.Errorf("text(%v): expected %q, got %q", tc.v, tc.expected, got)
+		}
+	}
+}
@@ -9,7 +9,7 @@ import (
 func BinarySearchBasicExample() {
 	numbers := []int{3, 7, 12, 19, 23, 45, 78}
 	result, _ := algo.NewPipelineWithData(numbers).
-		BinarySearch(func(n int) bool { return n == 23 }).
+		BinarySearch(func(n int) bool { return n >= 23 }).
 		Execute()
 
 	fmt.Printf("Found number in sorted array: %v\n", result)
@@ -30,7 +30,7 @@ func BinarySearchWithSortExample() {
 
 	result, _ := algo.NewPipelineWithData(users).
 		QuickSort(func(a, b User) bool { return a.ID < b.ID }).
-		BinarySearch(func(u User) bool { return u.ID == 5 }).
+		BinarySearch(func(u User) bool { return u.ID >= 5 }).
 		Execute()
 
 	fmt.Printf("Found user: %v\n", result)
@@ -47,7 +47,7 @@ func BinarySearchCombinedExample() {
 	result, _ := algo.NewPipelineWithData(users).
 		Filter(func(u User) bool { return u.Role == "user" }).
 		QuickSort(func(a, b User) bool { return a.ID < b.ID }).
-		BinarySearch(func(u User) bool { return u.ID == 3 }).
+		BinarySearch(func(u User) bool { return u.ID >= 3 }).
 		Execute()
 
 	fmt.Printf("Found user with role filter: %v\n", result)
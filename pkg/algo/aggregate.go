@@ -0,0 +1,407 @@
+package algo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Sum returns the sum of data. An empty slice sums to zero.
+//
+// Example:
+//
+//	total := Sum([]int{1, 2, 3}) // 6
+func Sum[N Number](data []N) N {
+	var sum N
+	for _, x := range data {
+		sum += x
+	}
+	return sum
+}
+
+// SumBy returns the sum of the values extracted from data. An empty slice sums to zero.
+//
+// Example:
+//
+//	revenue := SumBy(orders, func(o Order) float64 { return o.Amount })
+func SumBy[T any, N Number](data []T, value func(T) N) N {
+	var sum N
+	for _, item := range data {
+		sum += value(item)
+	}
+	return sum
+}
+
+// Mean returns the arithmetic mean of data.
+// Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	avg, err := Mean([]float64{1, 2, 3, 4}) // 2.5
+func Mean[N Number](data []N) (float64, error) {
+	return MeanBy(data, identity[N])
+}
+
+// MeanBy returns the arithmetic mean of the values extracted from data.
+// Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	avg, err := MeanBy(orders, func(o Order) float64 { return o.Amount })
+func MeanBy[T any, N Number](data []T, value func(T) N) (float64, error) {
+	stats, err := summarize(data, value)
+	if err != nil {
+		return 0, err
+	}
+	return stats.Mean(), nil
+}
+
+// Variance returns the population variance of data, computed with Welford's algorithm.
+// Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	v, err := Variance([]float64{2, 4, 4, 4, 5, 5, 7, 9}) // 4
+func Variance[N Number](data []N) (float64, error) {
+	return VarianceBy(data, identity[N])
+}
+
+// VarianceBy returns the population variance of the values extracted from data.
+// Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	v, err := VarianceBy(orders, func(o Order) float64 { return o.Amount })
+func VarianceBy[T any, N Number](data []T, value func(T) N) (float64, error) {
+	stats, err := summarize(data, value)
+	if err != nil {
+		return 0, err
+	}
+	return stats.Variance(), nil
+}
+
+// StdDev returns the population standard deviation of data.
+// Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	sd, err := StdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9}) // 2
+func StdDev[N Number](data []N) (float64, error) {
+	return StdDevBy(data, identity[N])
+}
+
+// StdDevBy returns the population standard deviation of the values extracted from data.
+// Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	sd, err := StdDevBy(orders, func(o Order) float64 { return o.Amount })
+func StdDevBy[T any, N Number](data []T, value func(T) N) (float64, error) {
+	stats, err := summarize(data, value)
+	if err != nil {
+		return 0, err
+	}
+	return stats.StdDev(), nil
+}
+
+// Min returns the smallest element of data and the index of its first occurrence.
+// Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	low, index, err := Min([]int{3, 1, 2}) // 1, 1
+func Min[N Number](data []N) (N, int, error) {
+	return MinBy(data, identity[N])
+}
+
+// MinBy returns the element of data with the smallest extracted value and its index.
+// Ties resolve to the first occurrence. Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	cheapest, index, err := MinBy(products, func(p Product) float64 { return p.Price })
+func MinBy[T any, N Number](data []T, value func(T) N) (T, int, error) {
+	return extremeBy(data, value, func(a, b N) bool { return a < b })
+}
+
+// Max returns the largest element of data and the index of its first occurrence.
+// Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	high, index, err := Max([]int{3, 1, 3}) // 3, 0
+func Max[N Number](data []N) (N, int, error) {
+	return MaxBy(data, identity[N])
+}
+
+// MaxBy returns the element of data with the largest extracted value and its index.
+// Ties resolve to the first occurrence. Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	biggest, index, err := MaxBy(orders, func(o Order) float64 { return o.Amount })
+func MaxBy[T any, N Number](data []T, value func(T) N) (T, int, error) {
+	return extremeBy(data, value, func(a, b N) bool { return a > b })
+}
+
+// Mode returns the most frequent element of data and its number of occurrences.
+// Ties resolve to the value that occurs first. Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	value, count, err := Mode([]string{"a", "b", "b"}) // "b", 2
+func Mode[T comparable](data []T) (T, int, error) {
+	return ModeBy(data, func(item T) T { return item })
+}
+
+// ModeBy returns the most frequent key extracted from data and its number of occurrences.
+// Ties resolve to the key that occurs first. Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	category, count, err := ModeBy(orders, func(o Order) string { return o.Category })
+func ModeBy[T any, K comparable](data []T, key func(T) K) (K, int, error) {
+	var mode K
+	if len(data) == 0 {
+		return mode, 0, ErrEmptyInput
+	}
+
+	counts := make(map[K]int)
+	order := make([]K, 0)
+	for _, item := range data {
+		k := key(item)
+		if counts[k] == 0 {
+			order = append(order, k)
+		}
+		counts[k]++
+	}
+
+	best := 0
+	for _, k := range order {
+		if counts[k] > best {
+			mode, best = k, counts[k]
+		}
+	}
+	return mode, best, nil
+}
+
+// HistogramBucket is a bucket of a histogram covering the interval [Low, High).
+// The last bucket of a histogram also includes its High bound.
+type HistogramBucket struct {
+	Low   float64
+	High  float64
+	Count int
+}
+
+// Histogram splits the range between the smallest and largest element of data into
+// equal-width buckets and counts the elements in each.
+// Returns ErrEmptyInput if data is empty, or an error if buckets is not positive or a value is NaN or infinite.
+//
+// Example:
+//
+//	buckets, err := Histogram([]float64{1, 2, 2, 3, 10}, 3)
+//	// [1, 4): 4, [4, 7): 0, [7, 10]: 1
+func Histogram[N Number](data []N, buckets int) ([]HistogramBucket, error) {
+	return HistogramBy(data, identity[N], buckets)
+}
+
+// HistogramBy builds an equal-width histogram of the values extracted from data.
+// Returns ErrEmptyInput if data is empty, or an error if buckets is not positive or a value is NaN or infinite.
+//
+// Example:
+//
+//	buckets, err := HistogramBy(orders, func(o Order) float64 { return o.Amount }, 10)
+func HistogramBy[T any, N Number](data []T, value func(T) N, buckets int) ([]HistogramBucket, error) {
+	if buckets <= 0 {
+		return nil, fmt.Errorf("histogram bucket count must be positive, got %d", buckets)
+	}
+	for i, item := range data {
+		if v := float64(value(item)); math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("histogram value at index %d is not finite: %v", i, v)
+		}
+	}
+	stats, err := summarize(data, value)
+	if err != nil {
+		return nil, err
+	}
+
+	low, _ := stats.Min()
+	high, _ := stats.Max()
+	width := (high - low) / float64(buckets)
+
+	histogram := make([]HistogramBucket, buckets)
+	for i := range histogram {
+		histogram[i].Low = low + float64(i)*width
+		histogram[i].High = low + float64(i+1)*width
+	}
+	histogram[buckets-1].High = high
+
+	for _, item := range data {
+		i := buckets - 1
+		if width > 0 {
+			i = min(int((float64(value(item))-low)/width), buckets-1)
+		}
+		histogram[i].Count++
+	}
+	return histogram, nil
+}
+
+// Covariance returns the population covariance of two equally long slices.
+// Returns ErrEmptyInput if the slices are empty, or an error if their lengths differ.
+//
+// Example:
+//
+//	c, err := Covariance([]float64{1, 2, 3}, []float64{2, 4, 6}) // 1.333...
+func Covariance[N Number](xs, ys []N) (float64, error) {
+	co, err := pairedMoments(xs, ys)
+	if err != nil {
+		return 0, err
+	}
+	return co.covariance(), nil
+}
+
+// CovarianceBy returns the population covariance of two values extracted from each element of data.
+// Returns ErrEmptyInput if data is empty.
+//
+// Example:
+//
+//	c, err := CovarianceBy(days,
+//	    func(d Day) float64 { return d.Temperature },
+//	    func(d Day) float64 { return d.Sales })
+func CovarianceBy[T any](data []T, x, y func(T) float64) (float64, error) {
+	co, err := comomentsBy(data, x, y)
+	if err != nil {
+		return 0, err
+	}
+	return co.covariance(), nil
+}
+
+// Correlation returns the Pearson correlation coefficient of two equally long slices.
+// Returns ErrEmptyInput if the slices are empty, or an error if their lengths differ
+// or either slice has zero variance.
+//
+// Example:
+//
+//	r, err := Correlation([]float64{1, 2, 3}, []float64{2, 4, 7}) // 0.993...
+func Correlation[N Number](xs, ys []N) (float64, error) {
+	co, err := pairedMoments(xs, ys)
+	if err != nil {
+		return 0, err
+	}
+	return co.correlation()
+}
+
+// CorrelationBy returns the Pearson correlation coefficient of two values extracted from each element of data.
+// Returns ErrEmptyInput if data is empty, or an error if either value has zero variance.
+//
+// Example:
+//
+//	r, err := CorrelationBy(days,
+//	    func(d Day) float64 { return d.Temperature },
+//	    func(d Day) float64 { return d.Sales })
+func CorrelationBy[T any](data []T, x, y func(T) float64) (float64, error) {
+	co, err := comomentsBy(data, x, y)
+	if err != nil {
+		return 0, err
+	}
+	return co.correlation()
+}
+
+// comoments holds the running means and second moments of paired values,
+// updated with the bivariate form of Welford's algorithm.
+type comoments struct {
+	count  int
+	meanX  float64
+	meanY  float64
+	m2X    float64
+	m2Y    float64
+	coment float64
+}
+
+// add adds a pair of values.
+func (c *comoments) add(x, y float64) {
+	c.count++
+	n := float64(c.count)
+	dx := x - c.meanX
+	c.meanX += dx / n
+	dy := y - c.meanY
+	c.meanY += dy / n
+	c.m2X += dx * (x - c.meanX)
+	c.m2Y += dy * (y - c.meanY)
+	c.coment += dx * (y - c.meanY)
+}
+
+// covariance returns the population covariance of the pairs added.
+func (c *comoments) covariance() float64 {
+	return c.coment / float64(c.count)
+}
+
+// correlation returns the Pearson correlation coefficient of the pairs added.
+func (c *comoments) correlation() (float64, error) {
+	if c.m2X == 0 || c.m2Y == 0 {
+		return 0, errors.New("correlation is undefined when a variable has zero variance")
+	}
+	return c.coment / math.Sqrt(c.m2X*c.m2Y), nil
+}
+
+// pairedMoments accumulates the comoments of two equally long slices.
+func pairedMoments[N Number](xs, ys []N) (*comoments, error) {
+	if len(xs) != len(ys) {
+		return nil, fmt.Errorf("slices have different lengths %d and %d", len(xs), len(ys))
+	}
+	if len(xs) == 0 {
+		return nil, ErrEmptyInput
+	}
+	co := &comoments{}
+	for i := range xs {
+		co.add(float64(xs[i]), float64(ys[i]))
+	}
+	return co, nil
+}
+
+// comomentsBy accumulates the comoments of two values extracted from each element of data.
+func comomentsBy[T any](data []T, x, y func(T) float64) (*comoments, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyInput
+	}
+	co := &comoments{}
+	for _, item := range data {
+		co.add(x(item), y(item))
+	}
+	return co, nil
+}
+
+// identity returns its argument unchanged.
+func identity[N Number](x N) N {
+	return x
+}
+
+// summarize accumulates the values extracted from data.
+// Returns ErrEmptyInput if data is empty.
+func summarize[T any, N Number](data []T, value func(T) N) (*RunningStats, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyInput
+	}
+	stats := &RunningStats{}
+	for _, item := range data {
+		stats.Add(float64(value(item)))
+	}
+	return stats, nil
+}
+
+// extremeBy returns the first element of data whose value is preferred over every other by better.
+func extremeBy[T any, N Number](data []T, value func(T) N, better func(a, b N) bool) (T, int, error) {
+	var best T
+	if len(data) == 0 {
+		return best, -1, ErrEmptyInput
+	}
+
+	bestIndex := 0
+	bestValue := value(data[0])
+	for i := 1; i < len(data); i++ {
+		if v := value(data[i]); better(v, bestValue) {
+			bestIndex, bestValue = i, v
+		}
+	}
+	return data[bestIndex], bestIndex, nil
+}
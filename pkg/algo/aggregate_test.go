@@ -0,0 +1,139 @@
+package algo
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSumAndMean(t *testing.T) {
+	if sum := Sum([]int{1, 2, 3}); sum != 6 {
+		t.Errorf("Expected sum 6, got %d", sum)
+	}
+	if sum := Sum([]float64(nil)); sum != 0 {
+		t.Errorf("Expected sum of empty slice to be 0, got %v", sum)
+	}
+
+	mean, err := Mean([]int{1, 2, 3, 4})
+	if err != nil || mean != 2.5 {
+		t.Errorf("Expected mean 2.5, got %v (err %v)", mean, err)
+	}
+	if _, err := Mean([]int{}); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Expected ErrEmptyInput, got %v", err)
+	}
+}
+
+func TestByVariants(t *testing.T) {
+	data := []Item{{ID: 2}, {ID: 4}, {ID: 4}, {ID: 4}, {ID: 5}, {ID: 5}, {ID: 7}, {ID: 9}}
+	id := func(a Item) int { return a.ID }
+
+	if sum := SumBy(data, id); sum != 40 {
+		t.Errorf("Expected sum 40, got %d", sum)
+	}
+	if v, err := VarianceBy(data, id); err != nil || !almostEqual(v, 4) {
+		t.Errorf("Expected variance 4, got %v (err %v)", v, err)
+	}
+	if sd, err := StdDevBy(data, id); err != nil || !almostEqual(sd, 2) {
+		t.Errorf("Expected stddev 2, got %v (err %v)", sd, err)
+	}
+	if mean, err := MeanBy(data, id); err != nil || mean != 5 {
+		t.Errorf("Expected mean 5, got %v (err %v)", mean, err)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	low, index, err := Min([]int{3, 1, 2, 1})
+	if err != nil || low != 1 || index != 1 {
+		t.Errorf("Expected min 1 at 1, got %d at %d (err %v)", low, index, err)
+	}
+
+	data := []Item{{ID: 1, Name: "a"}, {ID: 9, Name: "b"}, {ID: 9, Name: "c"}}
+	item, index, err := MaxBy(data, func(a Item) int { return a.ID })
+	if err != nil || item.Name != "b" || index != 1 {
+		t.Errorf("Expected max item b at 1, got %+v at %d (err %v)", item, index, err)
+	}
+
+	if _, index, err := Max([]float64{}); !errors.Is(err, ErrEmptyInput) || index != -1 {
+		t.Errorf("Expected ErrEmptyInput and index -1, got %v and %d", err, index)
+	}
+}
+
+func TestMode(t *testing.T) {
+	value, count, err := Mode([]string{"a", "b", "b", "c", "a"})
+	if err != nil || value != "a" || count != 2 {
+		t.Errorf("Expected mode a with count 2, got %s with %d (err %v)", value, count, err)
+	}
+
+	data := []Order{{UserID: 1}, {UserID: 2}, {UserID: 2}}
+	key, count, err := ModeBy(data, func(o Order) int { return o.UserID })
+	if err != nil || key != 2 || count != 2 {
+		t.Errorf("Expected mode 2 with count 2, got %d with %d (err %v)", key, count, err)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	buckets, err := Histogram([]float64{1, 2, 2, 3, 10}, 3)
+	if err != nil {
+		t.Fatalf("Histogram failed: %v", err)
+	}
+
+	expected := []HistogramBucket{
+		{Low: 1, High: 4, Count: 4},
+		{Low: 4, High: 7, Count: 0},
+		{Low: 7, High: 10, Count: 1},
+	}
+	if !reflect.DeepEqual(buckets, expected) {
+		t.Errorf("Expected %v, got %v", expected, buckets)
+	}
+
+	if _, err := Histogram([]int{1}, 0); err == nil {
+		t.Errorf("Expected error for zero buckets, but got nil")
+	}
+
+	single, err := Histogram([]int{5, 5}, 2)
+	if err != nil || single[1].Count != 2 {
+		t.Errorf("Expected identical values in the last bucket, got %v (err %v)", single, err)
+	}
+}
+
+func TestHistogram_NonFinite(t *testing.T) {
+	for _, v := range []float64{math.Inf(1), math.Inf(-1), math.NaN()} {
+		if _, err := Histogram([]float64{1, 2, v}, 3); err == nil {
+			t.Errorf("Expected error for %v, but got nil", v)
+		}
+	}
+}
+
+func TestCovarianceAndCorrelation(t *testing.T) {
+	xs := []float64{1, 2, 3, 4}
+	ys := []float64{2, 4, 6, 8}
+
+	if c, err := Covariance(xs, ys); err != nil || !almostEqual(c, 2.5) {
+		t.Errorf("Expected covariance 2.5, got %v (err %v)", c, err)
+	}
+	if r, err := Correlation(xs, ys); err != nil || !almostEqual(r, 1) {
+		t.Errorf("Expected correlation 1, got %v (err %v)", r, err)
+	}
+	if r, err := Correlation(xs, []float64{8, 6, 4, 2}); err != nil || !almostEqual(r, -1) {
+		t.Errorf("Expected correlation -1, got %v (err %v)", r, err)
+	}
+
+	if _, err := Covariance(xs, ys[:2]); err == nil {
+		t.Errorf("Expected error for different lengths, but got nil")
+	}
+	if _, err := Correlation(xs, []float64{1, 1, 1, 1}); err == nil {
+		t.Errorf("Expected error for zero variance, but got nil")
+	}
+
+	data := []Item{{ID: 1, Name: "x"}, {ID: 2, Name: "xx"}, {ID: 3, Name: "xxx"}}
+	r, err := CorrelationBy(data,
+		func(a Item) float64 { return float64(a.ID) },
+		func(a Item) float64 { return float64(len(a.Name)) })
+	if err != nil || !almostEqual(r, 1) {
+		t.Errorf("Expected correlation 1, got %v (err %v)", r, err)
+	}
+	if _, err := CovarianceBy([]Item{}, func(Item) float64 { return 0 }, func(Item) float64 { return 0 }); err == nil {
+		t.Errorf("Expected ErrEmptyInput, but got nil")
+	}
+}
@@ -0,0 +1,184 @@
+package algo
+
+// PatternMatch is an occurrence of a pattern in a text.
+// Start and End are byte offsets of the half-open range text[Start:End].
+type PatternMatch struct {
+	Pattern int
+	Start   int
+	End     int
+}
+
+// AhoCorasick is an automaton that finds occurrences of many patterns in a single pass over a text.
+// Matching takes time proportional to the text length plus the number of matches,
+// regardless of how many patterns there are. An AhoCorasick is safe for concurrent use once built.
+type AhoCorasick struct {
+	patterns []string
+	nodes    []acNode
+}
+
+// acNode is a state of the automaton.
+// Outputs lists the patterns that end at this state, including those reached through failure links.
+type acNode struct {
+	next    map[byte]int
+	fail    int
+	outputs []int
+}
+
+// NewAhoCorasick builds an automaton for the patterns.
+// Empty patterns are ignored. Matching is byte-wise and case-sensitive.
+//
+// Example:
+//
+//	matcher := NewAhoCorasick([]string{"password", "secret", "token"})
+//	matcher.Contains("reset token sent") // true
+func NewAhoCorasick(patterns []string) *AhoCorasick {
+	a := &AhoCorasick{patterns: patterns, nodes: []acNode{{}}}
+
+	for i, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		state := 0
+		for j := 0; j < len(pattern); j++ {
+			next, ok := a.nodes[state].next[pattern[j]]
+			if !ok {
+				next = len(a.nodes)
+				a.nodes = append(a.nodes, acNode{})
+				if a.nodes[state].next == nil {
+					a.nodes[state].next = make(map[byte]int)
+				}
+				a.nodes[state].next[pattern[j]] = next
+			}
+			state = next
+		}
+		a.nodes[state].outputs = append(a.nodes[state].outputs, i)
+	}
+
+	queue := make([]int, 0, len(a.nodes))
+	for _, child := range a.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for c, child := range a.nodes[state].next {
+			fail := a.nodes[state].fail
+			for {
+				if next, ok := a.nodes[fail].next[c]; ok {
+					a.nodes[child].fail = next
+					break
+				}
+				if fail == 0 {
+					break
+				}
+				fail = a.nodes[fail].fail
+			}
+			inherited := a.nodes[a.nodes[child].fail].outputs
+			a.nodes[child].outputs = append(a.nodes[child].outputs, inherited...)
+			queue = append(queue, child)
+		}
+	}
+	return a
+}
+
+// FindAll returns every occurrence of every pattern in text, including overlapping ones,
+// ordered by end offset.
+//
+// Example:
+//
+//	matches := NewAhoCorasick([]string{"he", "she", "hers"}).FindAll("ushers")
+//	// she [1:4], he [2:4], hers [2:6]
+func (a *AhoCorasick) FindAll(text string) []PatternMatch {
+	var matches []PatternMatch
+	state := 0
+	for i := 0; i < len(text); i++ {
+		state = a.step(state, text[i])
+		for _, p := range a.nodes[state].outputs {
+			matches = append(matches, PatternMatch{Pattern: p, Start: i + 1 - len(a.patterns[p]), End: i + 1})
+		}
+	}
+	return matches
+}
+
+// Contains reports whether text contains any of the patterns.
+// It stops at the first match.
+func (a *AhoCorasick) Contains(text string) bool {
+	state := 0
+	for i := 0; i < len(text); i++ {
+		state = a.step(state, text[i])
+		if len(a.nodes[state].outputs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Patterns returns the patterns the automaton was built from.
+func (a *AhoCorasick) Patterns() []string {
+	return a.patterns
+}
+
+// step follows the transition for c from state, falling back through failure links.
+func (a *AhoCorasick) step(state int, c byte) int {
+	for {
+		if next, ok := a.nodes[state].next[c]; ok {
+			return next
+		}
+		if state == 0 {
+			return 0
+		}
+		state = a.nodes[state].fail
+	}
+}
+
+// ContainsAnyOperation selects the elements whose key contains at least one of many patterns.
+// With Exclude set it selects the elements whose key contains none of them instead.
+type ContainsAnyOperation[T any] struct {
+	Key     func(T) string
+	Matcher *AhoCorasick
+	Exclude bool
+}
+
+// Apply performs the multi-pattern filter on the data.
+// It returns a new slice of the selected elements in their original order.
+//
+// Example:
+//
+//	pipeline := NewPipeline[string]().
+//	    ContainsAny(func(s string) string { return s }, []string{"error", "fatal"})
+//	result, err := pipeline.Execute()
+func (c *ContainsAnyOperation[T]) Apply(data []T) ([]T, error) {
+	selectedData := make([]T, 0, len(data))
+	for _, item := range data {
+		if c.Matcher.Contains(c.Key(item)) != c.Exclude {
+			selectedData = append(selectedData, item)
+		}
+	}
+	return selectedData, nil
+}
+
+// ContainsAny adds a multi-pattern filter to the pipeline that keeps elements whose key
+// contains any of the patterns. The Aho-Corasick automaton is built once when the stage is added.
+//
+// Example:
+//
+//	pipeline.ContainsAny(func(r LogRecord) string { return r.Message }, alertTerms)
+func (p *Pipeline[T]) ContainsAny(key func(T) string, patterns []string) *Pipeline[T] {
+	p.operations = append(p.operations, &ContainsAnyOperation[T]{Key: key, Matcher: NewAhoCorasick(patterns)})
+	return p
+}
+
+// ContainsNone adds a multi-pattern filter to the pipeline that drops elements whose key
+// contains any of the patterns. The Aho-Corasick automaton is built once when the stage is added.
+//
+// Example:
+//
+//	pipeline.ContainsNone(func(r LogRecord) string { return r.Message }, blockedTerms)
+func (p *Pipeline[T]) ContainsNone(key func(T) string, patterns []string) *Pipeline[T] {
+	p.operations = append(p.operations, &ContainsAnyOperation[T]{
+		Key:     key,
+		Matcher: NewAhoCorasick(patterns),
+		Exclude: true,
+	})
+	return p
+}
@@ -0,0 +1,122 @@
+package algo
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestAhoCorasick_FindAllOverlapping(t *testing.T) {
+	matcher := NewAhoCorasick([]string{"he", "she", "his", "hers"})
+
+	matches := matcher.FindAll("ushers")
+
+	expected := []PatternMatch{
+		{Pattern: 1, Start: 1, End: 4},
+		{Pattern: 0, Start: 2, End: 4},
+		{Pattern: 3, Start: 2, End: 6},
+	}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Errorf("Expected %v, got %v", expected, matches)
+	}
+}
+
+func TestAhoCorasick_MatchesStringsContains(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	randomString := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = "abc"[rng.Intn(3)]
+		}
+		return string(b)
+	}
+
+	patterns := make([]string, 30)
+	for i := range patterns {
+		patterns[i] = randomString(2 + rng.Intn(4))
+	}
+	matcher := NewAhoCorasick(patterns)
+
+	for i := 0; i < 200; i++ {
+		text := randomString(rng.Intn(20))
+
+		expected := 0
+		for _, pattern := range patterns {
+			for j := 0; j+len(pattern) <= len(text); j++ {
+				if text[j:j+len(pattern)] == pattern {
+					expected++
+				}
+			}
+		}
+
+		if got := len(matcher.FindAll(text)); got != expected {
+			t.Fatalf("Text %q: expected %d matches, got %d", text, expected, got)
+		}
+		if matcher.Contains(text) != (expected > 0) {
+			t.Fatalf("Text %q: Contains disagrees with FindAll", text)
+		}
+	}
+}
+
+func TestAhoCorasick_EmptyPatterns(t *testing.T) {
+	matcher := NewAhoCorasick([]string{"", "x"})
+
+	if matcher.Contains("abc") {
+		t.Errorf("Expected empty pattern to be ignored")
+	}
+	if !matcher.Contains("xyz") {
+		t.Errorf("Expected pattern x to match")
+	}
+}
+
+func TestContainsAnyOperation(t *testing.T) {
+	data := []Item{
+		{ID: 1, Name: "disk error on sda"},
+		{ID: 2, Name: "all good"},
+		{ID: 3, Name: "FATAL: out of memory"},
+		{ID: 4, Name: "fatal error"},
+	}
+	key := func(a Item) string { return a.Name }
+
+	matched, err := NewPipelineWithData(data).
+		ContainsAny(key, []string{"error", "FATAL"}).
+		Execute()
+	if err != nil {
+		t.Fatalf("ContainsAnyOperation failed: %v", err)
+	}
+	if len(matched) != 3 || matched[0].ID != 1 || matched[1].ID != 3 || matched[2].ID != 4 {
+		t.Errorf("Expected items [1 3 4], got %v", matched)
+	}
+
+	clean, err := NewPipelineWithData(data).
+		ContainsNone(key, []string{"error", "FATAL"}).
+		Execute()
+	if err != nil {
+		t.Fatalf("ContainsAnyOperation failed: %v", err)
+	}
+	if len(clean) != 1 || clean[0].ID != 2 {
+		t.Errorf("Expected items [2], got %v", clean)
+	}
+}
+
+func BenchmarkContainsNone(b *testing.B) {
+	terms := make([]string, 5000)
+	for i := range terms {
+		terms[i] = fmt.Sprintf("blocked-term-%d", i)
+	}
+	records := make([]string, 10000)
+	for i := range records {
+		records[i] = fmt.Sprintf("request %d served in %dms by node-%d", i, i%300, i%7)
+	}
+	pipeline := NewPipeline[string]().
+		ContainsNone(func(s string) string { return s }, terms)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pipeline.WithData(records)
+		if _, err := pipeline.Execute(); err != nil {
+			b.Fatalf("Pipeline execution failed: %v", err)
+		}
+	}
+}
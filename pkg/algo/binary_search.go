@@ -1,12 +1,13 @@
 package algo
 
 import (
-	"fmt"
 	"sort"
 )
 
 // BinarySearchOperation performs a binary search on sorted data.
-// It requires the data to be sorted according to the predicate function's ordering.
+// It requires the data to be sorted according to the predicate function's ordering:
+// the predicate must be false for a prefix of the data and true for the rest, as in sort.Search.
+// Use LowerBound, UpperBound, EqualRange or Range to search with a three-way comparator instead.
 type BinarySearchOperation[T any] struct {
 	Predicate  func(T) bool
 	FoundIndex int
@@ -20,7 +21,7 @@ type BinarySearchOperation[T any] struct {
 //
 //	pipeline := NewPipeline[int]().
 //	    QuickSort(func(a, b int) bool { return a < b }).
-//	    BinarySearch(func(a int) bool { return a == 42 })
+//	    BinarySearch(func(a int) bool { return a >= 42 })
 //	result, err := pipeline.Execute()
 func (b *BinarySearchOperation[T]) Apply(data []T) ([]T, error) {
 	b.FoundIndex = -1
@@ -33,17 +34,18 @@ func (b *BinarySearchOperation[T]) Apply(data []T) ([]T, error) {
 		return data, nil
 	}
 
-	return data, fmt.Errorf("target not found in data")
+	return data, ErrNotFound
 }
 
 // BinarySearch adds a binary search operation to the pipeline.
-// The predicate function should return true when the target element is found.
+// The predicate function should return true for the target element and every element after it,
+// so the search finds the first element for which it is true.
 //
 // Example:
 //
-//	pipeline.BinarySearch(func(item int) bool { return item == targetValue })
+//	pipeline.BinarySearch(func(item int) bool { return item >= targetValue })
 func (p *Pipeline[T]) BinarySearch(predicate func(T) bool) *Pipeline[T] {
-	p.operations = append(p.operations, &BinarySearchOperation[T]{Predicate: predicate})
+	p.operations = append(p.operations, &BinarySearchOperation[T]{Predicate: predicate, FoundIndex: -1})
 	return p
 }
 
@@ -52,3 +54,11 @@ func (p *Pipeline[T]) BinarySearch(predicate func(T) bool) *Pipeline[T] {
 func (b *BinarySearchOperation[T]) GetFoundIndex() int {
 	return b.FoundIndex
 }
+
+// GetMatchCount returns 1 if the last search found its target and 0 otherwise.
+func (b *BinarySearchOperation[T]) GetMatchCount() int {
+	if b.FoundIndex < 0 {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,146 @@
+package algo
+
+import (
+	"sort"
+	"sync"
+)
+
+// BKTree is a Burkhard-Keller tree for fuzzy lookups over large corpora.
+// It uses the triangle inequality of the distance function to skip most of the corpus
+// when searching for keys within a small edit distance of a query.
+// A BKTree is safe for concurrent use.
+type BKTree[T any] struct {
+	mu       sync.RWMutex
+	key      func(T) string
+	distance func(a, b string) int
+	root     *bkNode[T]
+	size     int
+}
+
+// bkNode is a node of a BK-tree. Children are keyed by their distance from the node's key.
+type bkNode[T any] struct {
+	key      string
+	items    []T
+	children map[int]*bkNode[T]
+}
+
+// NewBKTree creates an empty BK-tree.
+// The distance function must be a metric, such as Levenshtein; Levenshtein is used when nil.
+// DamerauLevenshtein computes the optimal string alignment distance, which is not a metric,
+// so searches with it may miss matches.
+//
+// Example:
+//
+//	tree := NewBKTree(func(c Customer) string { return c.Name }, Levenshtein)
+func NewBKTree[T any](key func(T) string, distance func(a, b string) int) *BKTree[T] {
+	if distance == nil {
+		distance = Levenshtein
+	}
+	return &BKTree[T]{key: key, distance: distance}
+}
+
+// BuildBKTree creates a BK-tree using the Levenshtein distance and inserts every element of data.
+//
+// Example:
+//
+//	tree := BuildBKTree(skus, func(s SKU) string { return s.Code })
+//	matches := tree.Search("AB1C23", 1)
+func BuildBKTree[T any](data []T, key func(T) string) *BKTree[T] {
+	tree := NewBKTree(key, Levenshtein)
+	for _, item := range data {
+		tree.insert(item)
+	}
+	return tree
+}
+
+// Insert adds elements to the tree.
+func (b *BKTree[T]) Insert(items ...T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, item := range items {
+		b.insert(item)
+	}
+}
+
+// insert adds a single element without locking.
+func (b *BKTree[T]) insert(item T) {
+	b.size++
+	key := b.key(item)
+	if b.root == nil {
+		b.root = &bkNode[T]{key: key, items: []T{item}}
+		return
+	}
+
+	node := b.root
+	for {
+		d := b.distance(key, node.key)
+		if d == 0 {
+			node.items = append(node.items, item)
+			return
+		}
+		child, ok := node.children[d]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[int]*bkNode[T])
+			}
+			node.children[d] = &bkNode[T]{key: key, items: []T{item}}
+			return
+		}
+		node = child
+	}
+}
+
+// Search returns the elements whose key is at most maxDistance away from query,
+// ordered by distance, then by key.
+func (b *BKTree[T]) Search(query string, maxDistance int) []FuzzyMatch[T] {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	type keyedMatch struct {
+		key   string
+		match FuzzyMatch[T]
+	}
+
+	var found []keyedMatch
+	if b.root != nil {
+		stack := []*bkNode[T]{b.root}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			d := b.distance(query, node.key)
+			if d <= maxDistance {
+				for _, item := range node.items {
+					found = append(found, keyedMatch{key: node.key, match: FuzzyMatch[T]{Item: item, Distance: d}})
+				}
+			}
+			for childDistance, child := range node.children {
+				if childDistance >= d-maxDistance && childDistance <= d+maxDistance {
+					stack = append(stack, child)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].match.Distance != found[j].match.Distance {
+			return found[i].match.Distance < found[j].match.Distance
+		}
+		return found[i].key < found[j].key
+	})
+
+	matches := make([]FuzzyMatch[T], len(found))
+	for i := range found {
+		matches[i] = found[i].match
+	}
+	return matches
+}
+
+// Len returns the number of elements in the tree.
+func (b *BKTree[T]) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.size
+}
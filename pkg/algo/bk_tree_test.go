@@ -0,0 +1,78 @@
+package algo
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestBKTree_Search(t *testing.T) {
+	tree := BuildBKTree([]string{"book", "books", "cake", "boo", "cape", "cart", "boon", "book"},
+		func(s string) string { return s })
+
+	matches := tree.Search("bo", 2)
+
+	expected := []FuzzyMatch[string]{
+		{Item: "boo", Distance: 1},
+		{Item: "book", Distance: 2},
+		{Item: "book", Distance: 2},
+		{Item: "boon", Distance: 2},
+	}
+	if len(matches) != len(expected) {
+		t.Fatalf("Expected %d matches, got %v", len(expected), matches)
+	}
+	for i := range expected {
+		if matches[i] != expected[i] {
+			t.Errorf("At index %d, expected %+v, got %+v", i, expected[i], matches[i])
+		}
+	}
+	if tree.Len() != 8 {
+		t.Errorf("Expected length 8, got %d", tree.Len())
+	}
+}
+
+func TestBKTree_MatchesFuzzyFind(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	alphabet := []rune("abcde")
+	words := make([]string, 400)
+	for i := range words {
+		runes := make([]rune, 3+rng.Intn(4))
+		for j := range runes {
+			runes[j] = alphabet[rng.Intn(len(alphabet))]
+		}
+		words[i] = string(runes)
+	}
+
+	tree := NewBKTree(func(s string) string { return s }, nil)
+	tree.Insert(words...)
+
+	for _, query := range []string{"abc", "eeee", "abcde", "a"} {
+		for maxDistance := 0; maxDistance <= 2; maxDistance++ {
+			expected, err := NewPipelineWithData(words).
+				FuzzyFind(func(s string) string { return s }, query, maxDistance).
+				Execute()
+			if err != nil {
+				t.Fatalf("FuzzyFind failed: %v", err)
+			}
+
+			matches := tree.Search(query, maxDistance)
+			if len(matches) != len(expected) {
+				t.Fatalf("Query %q within %d: expected %d matches, got %d",
+					query, maxDistance, len(expected), len(matches))
+			}
+		}
+	}
+}
+
+func BenchmarkBKTreeSearch(b *testing.B) {
+	words := make([]string, 100000)
+	for i := range words {
+		words[i] = fmt.Sprintf("SKU-%06d", i)
+	}
+	tree := BuildBKTree(words, func(s string) string { return s })
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Search("SKU-01234x", 1)
+	}
+}
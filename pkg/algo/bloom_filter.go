@@ -0,0 +1,228 @@
+package algo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// bloomFilterVersion is the version byte written by BloomFilter.MarshalBinary.
+const bloomFilterVersion = 1
+
+// BloomFilter is a compact set of keys that answers membership queries with no false negatives
+// and a tunable rate of false positives.
+// Filters with the same dimensions can be merged, and filters can be serialized and reused across jobs.
+type BloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes int
+	count  uint64
+}
+
+// NewBloomFilter creates a filter with the given number of bits and hash functions.
+// Returns an error if bits or hashes is not positive.
+//
+// Example:
+//
+//	bf, err := NewBloomFilter(1<<20, 7)
+//	bf.AddString("sku-1")
+//	bf.ContainsString("sku-1") // true
+func NewBloomFilter(bits, hashes int) (*BloomFilter, error) {
+	if bits <= 0 || hashes <= 0 {
+		return nil, fmt.Errorf("BloomFilter: bits and hashes must be positive, got %d and %d", bits, hashes)
+	}
+	return &BloomFilter{bits: make([]uint64, (bits+63)/64), size: uint64(bits), hashes: hashes}, nil
+}
+
+// NewBloomFilterWithEstimates creates a filter sized to hold n keys with the given false-positive rate.
+// Returns an error if n is negative or falsePositiveRate is not between 0 and 1.
+//
+// Example:
+//
+//	bf, err := NewBloomFilterWithEstimates(1_000_000, 0.01) // about 1.2 MB, 7 hashes
+func NewBloomFilterWithEstimates(n int, falsePositiveRate float64) (*BloomFilter, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("BloomFilter: expected item count must not be negative, got %d", n)
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return nil, fmt.Errorf("BloomFilter: false-positive rate must be between 0 and 1, got %v", falsePositiveRate)
+	}
+
+	items := float64(max(n, 1))
+	bits := math.Ceil(-items * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := math.Round(bits / items * math.Ln2)
+	return NewBloomFilter(int(bits), max(int(hashes), 1))
+}
+
+// Add adds a key to the filter.
+func (b *BloomFilter) Add(key []byte) {
+	b.addHash(hash64(key))
+}
+
+// AddString adds a key to the filter.
+func (b *BloomFilter) AddString(key string) {
+	b.addHash(hashString(key))
+}
+
+// addHash sets the bits of a hashed key.
+func (b *BloomFilter) addHash(h uint64) {
+	step := hashStep(h)
+	for i := 0; i < b.hashes; i++ {
+		bit := (h + uint64(i)*step) % b.size
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+	b.count++
+}
+
+// Contains reports whether a key may have been added.
+// A false result is definite; a true result is wrong with the filter's false-positive rate.
+func (b *BloomFilter) Contains(key []byte) bool {
+	return b.containsHash(hash64(key))
+}
+
+// ContainsString reports whether a key may have been added.
+func (b *BloomFilter) ContainsString(key string) bool {
+	return b.containsHash(hashString(key))
+}
+
+// containsHash reports whether all bits of a hashed key are set.
+func (b *BloomFilter) containsHash(h uint64) bool {
+	step := hashStep(h)
+	for i := 0; i < b.hashes; i++ {
+		bit := (h + uint64(i)*step) % b.size
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of keys added, including duplicates.
+func (b *BloomFilter) Count() uint64 {
+	return b.count
+}
+
+// FalsePositiveRate estimates the current false-positive rate from the number of keys added.
+func (b *BloomFilter) FalsePositiveRate() float64 {
+	k := float64(b.hashes)
+	return math.Pow(1-math.Exp(-k*float64(b.count)/float64(b.size)), k)
+}
+
+// Merge adds the keys of another filter into b.
+// Returns an error if the dimensions differ.
+func (b *BloomFilter) Merge(other *BloomFilter) error {
+	if b.size != other.size || b.hashes != other.hashes {
+		return fmt.Errorf("BloomFilter: cannot merge %d bits/%d hashes into %d bits/%d hashes",
+			other.size, other.hashes, b.size, b.hashes)
+	}
+	for i, word := range other.bits {
+		b.bits[i] |= word
+	}
+	b.count += other.count
+	return nil
+}
+
+// MarshalBinary encodes the filter so that it can be persisted or sent to another process.
+func (b *BloomFilter) MarshalBinary() ([]byte, error) {
+	data := []byte{bloomFilterVersion}
+	data = binary.AppendUvarint(data, b.size)
+	data = binary.AppendUvarint(data, uint64(b.hashes))
+	data = binary.AppendUvarint(data, b.count)
+	for _, word := range b.bits {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary, replacing the contents of b.
+func (b *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != bloomFilterVersion {
+		return errors.New("BloomFilter: unsupported encoding")
+	}
+	r := &uvarintReader{data: data[1:]}
+	size, hashes, count := r.next(), r.next(), r.next()
+	if r.err != nil || size == 0 || hashes == 0 || hashes > 64 || (size+63)/64*8 != uint64(len(r.data)) {
+		return errors.New("BloomFilter: corrupt encoding")
+	}
+
+	decoded, err := NewBloomFilter(int(size), int(hashes))
+	if err != nil {
+		return err
+	}
+	decoded.count = count
+	for i := range decoded.bits {
+		decoded.bits[i] = binary.LittleEndian.Uint64(r.data[i*8:])
+	}
+	*b = *decoded
+	return nil
+}
+
+// FilterByBloomOperation keeps the elements whose key may be contained in a Bloom filter.
+// It is meant as a cheap pre-filter before an exact lookup: elements it drops are definitely absent,
+// while a small fraction of the elements it keeps may be false positives.
+type FilterByBloomOperation[T any] struct {
+	Key    func(T) string
+	Filter *BloomFilter
+}
+
+// Apply performs the Bloom filter operation on the data.
+// It returns a new slice containing the elements whose key may be in the filter.
+// Returns an error if Filter is nil.
+//
+// Example:
+//
+//	pipeline := NewPipeline[Order]().
+//	    FilterByBloom(func(o Order) string { return o.SKU }, knownSKUs)
+//	result, err := pipeline.Execute()
+func (f *FilterByBloomOperation[T]) Apply(data []T) ([]T, error) {
+	if f.Filter == nil {
+		return nil, errors.New("FilterByBloomOperation: filter is nil")
+	}
+
+	filteredData := make([]T, 0, len(data))
+	for _, item := range data {
+		if f.Filter.ContainsString(f.Key(item)) {
+			filteredData = append(filteredData, item)
+		}
+	}
+	return filteredData, nil
+}
+
+// FilterByBloom adds a Bloom filter operation to the pipeline.
+// Follow it with an exact check if false positives must be removed.
+//
+// Example:
+//
+//	pipeline.
+//	    FilterByBloom(func(o Order) string { return o.SKU }, catalogFilter).
+//	    Filter(func(o Order) bool { return catalogIndex.Contains(o.SKU) })
+func (p *Pipeline[T]) FilterByBloom(key func(T) string, filter *BloomFilter) *Pipeline[T] {
+	p.operations = append(p.operations, &FilterByBloomOperation[T]{Key: key, Filter: filter})
+	return p
+}
+
+// BuildBloomFilter executes the pipeline and returns a Bloom filter containing the key of every element
+// in its result, sized so that the false-positive rate does not exceed falsePositiveRate.
+// Returns an error if any operation fails or falsePositiveRate is not between 0 and 1.
+//
+// Example:
+//
+//	catalogFilter, err := NewPipelineWithData(products).
+//	    Filter(func(p Product) bool { return p.Active }).
+//	    BuildBloomFilter(func(p Product) string { return p.SKU }, 0.01)
+func (p *Pipeline[T]) BuildBloomFilter(key func(T) string, falsePositiveRate float64) (*BloomFilter, error) {
+	data, err := p.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := NewBloomFilterWithEstimates(len(data), falsePositiveRate)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range data {
+		filter.AddString(key(item))
+	}
+	return filter, nil
+}
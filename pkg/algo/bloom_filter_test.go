@@ -0,0 +1,146 @@
+package algo
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestNewBloomFilter_Invalid(t *testing.T) {
+	if _, err := NewBloomFilter(0, 3); err == nil {
+		t.Error("Expected error for zero bits")
+	}
+	if _, err := NewBloomFilterWithEstimates(-1, 0.01); err == nil {
+		t.Error("Expected error for negative item count")
+	}
+	if _, err := NewBloomFilterWithEstimates(100, 1.5); err == nil {
+		t.Error("Expected error for false-positive rate above 1")
+	}
+}
+
+func TestNewBloomFilterWithEstimates_Dimensions(t *testing.T) {
+	bf, err := NewBloomFilterWithEstimates(1000, 0.01)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if bf.size != 9586 || bf.hashes != 7 {
+		t.Errorf("Expected 9586 bits and 7 hashes, got %d and %d", bf.size, bf.hashes)
+	}
+}
+
+func TestBloomFilter_NoFalseNegativesAndBoundedFalsePositives(t *testing.T) {
+	bf, _ := NewBloomFilterWithEstimates(10000, 0.01)
+	for i := 0; i < 10000; i++ {
+		bf.AddString("member-" + strconv.Itoa(i))
+	}
+
+	for i := 0; i < 10000; i++ {
+		if !bf.ContainsString("member-" + strconv.Itoa(i)) {
+			t.Fatalf("False negative for member-%d", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < 100000; i++ {
+		if bf.Contains([]byte("other-" + strconv.Itoa(i))) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / 100000; rate > 0.015 {
+		t.Errorf("False-positive rate %.4f exceeds target", rate)
+	}
+	if rate := bf.FalsePositiveRate(); rate < 0.005 || rate > 0.015 {
+		t.Errorf("Expected estimated rate near 0.01, got %.4f", rate)
+	}
+}
+
+func TestBloomFilter_Merge(t *testing.T) {
+	a, _ := NewBloomFilter(1024, 4)
+	b, _ := NewBloomFilter(1024, 4)
+	a.AddString("x")
+	b.Add([]byte("y"))
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !a.ContainsString("x") || !a.ContainsString("y") || a.Count() != 2 {
+		t.Error("Expected merged filter to contain both keys")
+	}
+
+	c, _ := NewBloomFilter(1024, 3)
+	if err := a.Merge(c); err == nil {
+		t.Error("Expected error when merging different dimensions")
+	}
+}
+
+func TestBloomFilter_MarshalRoundTrip(t *testing.T) {
+	bf, _ := NewBloomFilter(1000, 5)
+	for i := 0; i < 100; i++ {
+		bf.AddString(strconv.Itoa(i))
+	}
+
+	data, err := bf.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded BloomFilter
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := 0; i < 200; i++ {
+		key := strconv.Itoa(i)
+		if decoded.ContainsString(key) != bf.ContainsString(key) {
+			t.Errorf("%s: decoded filter disagrees with the original", key)
+		}
+	}
+	if decoded.Count() != 100 {
+		t.Errorf("Expected count 100, got %d", decoded.Count())
+	}
+
+	for _, corrupt := range [][]byte{nil, {3}, data[:len(data)-1], append(data, 0)} {
+		if err := decoded.UnmarshalBinary(corrupt); err == nil {
+			t.Errorf("Expected error for corrupt encoding of length %d", len(corrupt))
+		}
+	}
+}
+
+func TestFilterByBloomOperation(t *testing.T) {
+	products := []Item{{ID: 1, Name: "apple"}, {ID: 2, Name: "banana"}, {ID: 3, Name: "cherry"}}
+	catalog, err := NewPipelineWithData(products).
+		Filter(func(item Item) bool { return item.ID != 2 }).
+		BuildBloomFilter(func(item Item) string { return item.Name }, 0.001)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	orders := []Item{{ID: 10, Name: "cherry"}, {ID: 11, Name: "banana"}, {ID: 12, Name: "apple"}, {ID: 13, Name: "durian"}}
+	result, err := NewPipelineWithData(orders).
+		FilterByBloom(func(item Item) string { return item.Name }, catalog).
+		Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 2 || result[0].ID != 10 || result[1].ID != 12 {
+		t.Errorf("Expected orders 10 and 12, got %v", result)
+	}
+}
+
+func TestFilterByBloomOperation_NilFilter(t *testing.T) {
+	_, err := NewPipelineWithData([]Item{{ID: 1}}).
+		FilterByBloom(func(item Item) string { return item.Name }, nil).
+		Execute()
+	if err == nil {
+		t.Error("Expected error for nil filter")
+	}
+}
+
+func TestBuildBloomFilter_Errors(t *testing.T) {
+	if _, err := NewPipelineWithData([]Item{{ID: 1}}).
+		BuildBloomFilter(func(item Item) string { return item.Name }, 0); err == nil {
+		t.Error("Expected error for zero false-positive rate")
+	}
+	if _, err := NewPipelineWithData([]Item{}).
+		Reduce(func(a, b Item) Item { return a }).
+		BuildBloomFilter(func(item Item) string { return item.Name }, 0.01); err == nil {
+		t.Error("Expected error from failing pipeline")
+	}
+}
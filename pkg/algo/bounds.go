@@ -0,0 +1,248 @@
+package algo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LowerBoundOperation locates the first element that is not less than the target in sorted data.
+// The comparator is three-way: negative when a < b, zero when equal and positive when a > b.
+type LowerBoundOperation[T any] struct {
+	Target     T
+	Compare    func(a, b T) int
+	FoundIndex int
+}
+
+// Apply performs the lower bound search on the data.
+// It returns the elements from the lower bound to the end of the data and records the bound in FoundIndex,
+// which is -1 when every element is less than the target.
+// The operation expects the data to be sorted by Compare.
+//
+// Example:
+//
+//	pipeline := NewPipelineWithData([]int{1, 3, 3, 5}).
+//	    LowerBound(3, cmp.Compare[int])
+//	result, err := pipeline.Execute() // [3 3 5]
+func (l *LowerBoundOperation[T]) Apply(data []T) ([]T, error) {
+	index := lowerBound(data, l.Target, l.Compare)
+	l.FoundIndex = index
+	if index == len(data) {
+		l.FoundIndex = -1
+	}
+	return data[index:], nil
+}
+
+// GetFoundIndex returns the index of the lower bound after the search has been executed,
+// or -1 when every element is less than the target.
+func (l *LowerBoundOperation[T]) GetFoundIndex() int {
+	return l.FoundIndex
+}
+
+// LowerBound adds a lower bound search to the pipeline.
+// The output is every element greater than or equal to target.
+//
+// Example:
+//
+//	pipeline.LowerBound(Order{Amount: 100}, func(a, b Order) int {
+//	    return cmp.Compare(a.Amount, b.Amount)
+//	})
+func (p *Pipeline[T]) LowerBound(target T, compare func(a, b T) int) *Pipeline[T] {
+	p.operations = append(p.operations, &LowerBoundOperation[T]{Target: target, Compare: compare})
+	return p
+}
+
+// UpperBoundOperation locates the first element that is greater than the target in sorted data.
+// The comparator is three-way: negative when a < b, zero when equal and positive when a > b.
+type UpperBoundOperation[T any] struct {
+	Target     T
+	Compare    func(a, b T) int
+	FoundIndex int
+}
+
+// Apply performs the upper bound search on the data.
+// It returns the elements from the upper bound to the end of the data and records the bound in FoundIndex,
+// which is -1 when no element is greater than the target.
+// The operation expects the data to be sorted by Compare.
+//
+// Example:
+//
+//	pipeline := NewPipelineWithData([]int{1, 3, 3, 5}).
+//	    UpperBound(3, cmp.Compare[int])
+//	result, err := pipeline.Execute() // [5]
+func (u *UpperBoundOperation[T]) Apply(data []T) ([]T, error) {
+	index := upperBound(data, u.Target, u.Compare)
+	u.FoundIndex = index
+	if index == len(data) {
+		u.FoundIndex = -1
+	}
+	return data[index:], nil
+}
+
+// GetFoundIndex returns the index of the upper bound after the search has been executed,
+// or -1 when no element is greater than the target.
+func (u *UpperBoundOperation[T]) GetFoundIndex() int {
+	return u.FoundIndex
+}
+
+// UpperBound adds an upper bound search to the pipeline.
+// The output is every element strictly greater than target.
+//
+// Example:
+//
+//	pipeline.UpperBound(cutoff, func(a, b Event) int {
+//	    return a.Timestamp.Compare(b.Timestamp)
+//	})
+func (p *Pipeline[T]) UpperBound(target T, compare func(a, b T) int) *Pipeline[T] {
+	p.operations = append(p.operations, &UpperBoundOperation[T]{Target: target, Compare: compare})
+	return p
+}
+
+// EqualRangeOperation locates every element equal to the target in sorted data.
+// The matches are the half-open index range [Start, End).
+type EqualRangeOperation[T any] struct {
+	Target  T
+	Compare func(a, b T) int
+	Start   int
+	End     int
+}
+
+// Apply performs the equal range search on the data.
+// It returns the elements equal to the target, which is empty when there is no match.
+// The operation expects the data to be sorted by Compare.
+//
+// Example:
+//
+//	pipeline := NewPipelineWithData([]int{1, 3, 3, 5}).
+//	    EqualRange(3, cmp.Compare[int])
+//	result, err := pipeline.Execute() // [3 3]
+func (e *EqualRangeOperation[T]) Apply(data []T) ([]T, error) {
+	e.Start = lowerBound(data, e.Target, e.Compare)
+	e.End = e.Start + upperBound(data[e.Start:], e.Target, e.Compare)
+	return data[e.Start:e.End], nil
+}
+
+// GetFoundIndex returns the index of the first match after the search has been executed.
+// Returns -1 if there was no match.
+func (e *EqualRangeOperation[T]) GetFoundIndex() int {
+	if e.Start == e.End {
+		return -1
+	}
+	return e.Start
+}
+
+// GetMatchCount returns the number of elements equal to the target.
+func (e *EqualRangeOperation[T]) GetMatchCount() int {
+	return e.End - e.Start
+}
+
+// EqualRange adds an equal range search to the pipeline.
+// The output is every element that compares equal to target.
+//
+// Example:
+//
+//	pipeline.EqualRange(Item{ID: 42}, func(a, b Item) int {
+//	    return cmp.Compare(a.ID, b.ID)
+//	})
+func (p *Pipeline[T]) EqualRange(target T, compare func(a, b T) int) *Pipeline[T] {
+	p.operations = append(p.operations, &EqualRangeOperation[T]{Target: target, Compare: compare})
+	return p
+}
+
+// RangeOperation selects the elements of sorted data that fall within the half-open interval [Low, High).
+type RangeOperation[T any] struct {
+	Low     T
+	High    T
+	Compare func(a, b T) int
+	Start   int
+	End     int
+}
+
+// Apply performs the range search on the data.
+// It returns the elements x with Low <= x < High, which is empty when there is no match.
+// The operation expects the data to be sorted by Compare.
+//
+// Example:
+//
+//	pipeline := NewPipelineWithData([]int{1, 3, 5, 7, 9}).
+//	    Range(3, 8, cmp.Compare[int])
+//	result, err := pipeline.Execute() // [3 5 7]
+func (r *RangeOperation[T]) Apply(data []T) ([]T, error) {
+	r.Start = lowerBound(data, r.Low, r.Compare)
+	r.End = r.Start + lowerBound(data[r.Start:], r.High, r.Compare)
+	return data[r.Start:r.End], nil
+}
+
+// GetFoundIndex returns the index of the first element in range after the search has been executed.
+// Returns -1 if no element was in range.
+func (r *RangeOperation[T]) GetFoundIndex() int {
+	if r.Start == r.End {
+		return -1
+	}
+	return r.Start
+}
+
+// GetMatchCount returns the number of elements in range.
+func (r *RangeOperation[T]) GetMatchCount() int {
+	return r.End - r.Start
+}
+
+// Range adds a range search to the pipeline.
+// The output is every element greater than or equal to low and less than high.
+//
+// Example:
+//
+//	pipeline.Range(Order{Amount: 100}, Order{Amount: 500}, func(a, b Order) int {
+//	    return cmp.Compare(a.Amount, b.Amount)
+//	})
+func (p *Pipeline[T]) Range(low, high T, compare func(a, b T) int) *Pipeline[T] {
+	p.operations = append(p.operations, &RangeOperation[T]{Low: low, High: high, Compare: compare})
+	return p
+}
+
+// AssertSortedOperation verifies that the data is sorted before it reaches a search stage.
+type AssertSortedOperation[T any] struct {
+	Compare func(a, b T) int
+}
+
+// Apply checks that every element is not greater than its successor.
+// It returns the data unchanged, or an error naming the first out-of-order position.
+//
+// Example:
+//
+//	pipeline := NewPipeline[int]().
+//	    AssertSorted(cmp.Compare[int]).
+//	    EqualRange(42, cmp.Compare[int])
+//	result, err := pipeline.Execute()
+func (a *AssertSortedOperation[T]) Apply(data []T) ([]T, error) {
+	for i := 1; i < len(data); i++ {
+		if a.Compare(data[i-1], data[i]) > 0 {
+			return nil, fmt.Errorf("AssertSortedOperation: data is not sorted at index %d", i)
+		}
+	}
+	return data, nil
+}
+
+// AssertSorted adds a sortedness check to the pipeline.
+// Place it before LowerBound, UpperBound, EqualRange or Range to validate their input.
+//
+// Example:
+//
+//	pipeline.AssertSorted(func(a, b Item) int { return cmp.Compare(a.ID, b.ID) })
+func (p *Pipeline[T]) AssertSorted(compare func(a, b T) int) *Pipeline[T] {
+	p.operations = append(p.operations, &AssertSortedOperation[T]{Compare: compare})
+	return p
+}
+
+// lowerBound returns the index of the first element of data that is not less than target.
+func lowerBound[T any](data []T, target T, compare func(a, b T) int) int {
+	return sort.Search(len(data), func(i int) bool {
+		return compare(data[i], target) >= 0
+	})
+}
+
+// upperBound returns the index of the first element of data that is greater than target.
+func upperBound[T any](data []T, target T, compare func(a, b T) int) int {
+	return sort.Search(len(data), func(i int) bool {
+		return compare(data[i], target) > 0
+	})
+}
@@ -0,0 +1,217 @@
+package algo
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func compareItemID(a, b Item) int {
+	return cmp.Compare(a.ID, b.ID)
+}
+
+func TestLowerBoundOperation_Duplicates(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		LowerBound(3, cmp.Compare[int])
+
+	pipeline.WithData([]int{1, 3, 3, 5})
+
+	result, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("LowerBoundOperation failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, []int{3, 3, 5}) {
+		t.Errorf("Expected [3 3 5], got %v", result)
+	}
+
+	lbOp := pipeline.GetOperations()[0].(*LowerBoundOperation[int])
+	if lbOp.GetFoundIndex() != 1 {
+		t.Errorf("Expected found index to be 1, but got %d", lbOp.GetFoundIndex())
+	}
+}
+
+func TestUpperBoundOperation_PastEnd(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		UpperBound(5, cmp.Compare[int])
+
+	pipeline.WithData([]int{1, 3, 3, 5})
+
+	result, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("UpperBoundOperation failed: %v", err)
+	}
+
+	if len(result) != 0 {
+		t.Errorf("Expected no items, but got %v", result)
+	}
+
+	ubOp := pipeline.GetOperations()[0].(*UpperBoundOperation[int])
+	if ubOp.FoundIndex != -1 {
+		t.Errorf("Expected FoundIndex to be -1, but got %d", ubOp.FoundIndex)
+	}
+	if ubOp.GetFoundIndex() != -1 {
+		t.Errorf("Expected found index to be -1, but got %d", ubOp.GetFoundIndex())
+	}
+}
+
+func TestBounds_ResultPastEnd(t *testing.T) {
+	data := []int{1, 3, 3, 5}
+	for name, pipeline := range map[string]*Pipeline[int]{
+		"LowerBound": NewPipelineWithData(data).LowerBound(6, cmp.Compare[int]),
+		"UpperBound": NewPipelineWithData(data).UpperBound(5, cmp.Compare[int]),
+	} {
+		result, err := pipeline.ExecuteWithResult()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if result.FoundIndex() != -1 {
+			t.Errorf("%s: expected found index to be -1, but got %d", name, result.FoundIndex())
+		}
+	}
+}
+
+func TestEqualRangeOperation_Found(t *testing.T) {
+	data := []Item{
+		{ID: 1, Name: "Item1"},
+		{ID: 3, Name: "Item3a"},
+		{ID: 3, Name: "Item3b"},
+		{ID: 4, Name: "Item4"},
+	}
+
+	pipeline := NewPipeline[Item]().
+		EqualRange(Item{ID: 3}, compareItemID).
+		WithData(data)
+
+	result, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("EqualRangeOperation failed: %v", err)
+	}
+
+	expected := []Item{
+		{ID: 3, Name: "Item3a"},
+		{ID: 3, Name: "Item3b"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	erOp := pipeline.GetOperations()[0].(*EqualRangeOperation[Item])
+	if erOp.GetFoundIndex() != 1 || erOp.GetMatchCount() != 2 {
+		t.Errorf("Expected range [1, 3), got [%d, %d)", erOp.Start, erOp.End)
+	}
+}
+
+func TestEqualRangeOperation_NotFound(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		EqualRange(2, cmp.Compare[int]).
+		WithData([]int{1, 3, 5})
+
+	result, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("EqualRangeOperation failed: %v", err)
+	}
+
+	if len(result) != 0 {
+		t.Errorf("Expected no items, but got %v", result)
+	}
+
+	erOp := pipeline.GetOperations()[0].(*EqualRangeOperation[int])
+	if erOp.GetFoundIndex() != -1 {
+		t.Errorf("Expected found index to be -1, but got %d", erOp.GetFoundIndex())
+	}
+}
+
+func TestRangeOperation_HalfOpen(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Range(3, 7, cmp.Compare[int]).
+		WithData([]int{1, 3, 5, 7, 9})
+
+	result, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("RangeOperation failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, []int{3, 5}) {
+		t.Errorf("Expected [3 5], got %v", result)
+	}
+}
+
+func TestRangeOperation_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	data := make([]int, 200)
+	for i := range data {
+		data[i] = rng.Intn(50)
+	}
+	sort.Ints(data)
+
+	for i := 0; i < 50; i++ {
+		low, high := rng.Intn(60)-5, rng.Intn(60)-5
+
+		result, err := NewPipelineWithData(data).
+			Range(low, high, cmp.Compare[int]).
+			Execute()
+		if err != nil {
+			t.Fatalf("RangeOperation failed: %v", err)
+		}
+
+		expected, _ := NewPipelineWithData(data).
+			Find(func(x int) bool { return x >= low && x < high }).
+			Execute()
+		if len(result) != len(expected) {
+			t.Fatalf("Range [%d, %d): expected %d items, got %d", low, high, len(expected), len(result))
+		}
+	}
+}
+
+func TestAssertSortedOperation_Unsorted(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		AssertSorted(cmp.Compare[int]).
+		EqualRange(3, cmp.Compare[int]).
+		WithData([]int{1, 3, 2})
+
+	_, err := pipeline.Execute()
+	if err == nil {
+		t.Fatalf("Expected error for unsorted data, but got nil")
+	}
+
+	expectedError := "AssertSortedOperation: data is not sorted at index 2"
+	if err.Error() != expectedError {
+		t.Errorf("Expected error message '%s', got '%s'", expectedError, err.Error())
+	}
+}
+
+func TestAssertSortedOperation_WithQuickSort(t *testing.T) {
+	result, err := NewPipelineWithData([]int{5, 3, 1, 3}).
+		QuickSort(func(a, b int) bool { return a < b }).
+		AssertSorted(cmp.Compare[int]).
+		EqualRange(3, cmp.Compare[int]).
+		Execute()
+	if err != nil {
+		t.Fatalf("Pipeline execution failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, []int{3, 3}) {
+		t.Errorf("Expected [3 3], got %v", result)
+	}
+}
+
+func BenchmarkEqualRange(b *testing.B) {
+	data := make([]int, 1000000)
+	for i := 0; i < len(data); i++ {
+		data[i] = i / 4
+	}
+	pipeline := NewPipeline[int]().
+		EqualRange(125000, cmp.Compare[int]).
+		WithData(data)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := pipeline.Execute()
+		if err != nil {
+			b.Fatalf("Pipeline execution failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package algo
+
+import "fmt"
+
+// ChunkOperation splits the data into consecutive batches of a fixed size
+// and replaces each batch with the value produced by the aggregate function.
+// The last batch may contain fewer than Size elements.
+type ChunkOperation[T any] struct {
+	Size      int
+	Aggregate func(batch []T) T
+}
+
+// Apply performs the chunk operation on the data.
+// It returns a new slice containing one aggregated element per batch.
+// Returns an error if Size is not positive.
+//
+// Example:
+//
+//	pipeline := NewPipeline[int]().
+//	    Chunk(3, func(batch []int) int { return len(batch) })
+//	result, err := pipeline.Execute()
+func (c *ChunkOperation[T]) Apply(data []T) ([]T, error) {
+	chunks, err := Chunk(data, c.Size)
+	if err != nil {
+		return nil, fmt.Errorf("ChunkOperation: %w", err)
+	}
+
+	aggregatedData := make([]T, len(chunks))
+	for i, chunk := range chunks {
+		aggregatedData[i] = c.Aggregate(chunk)
+	}
+	return aggregatedData, nil
+}
+
+// Chunk adds a chunk operation to the pipeline.
+// Every batch of size elements is collapsed into a single element by the aggregate function.
+//
+// Example:
+//
+//	pipeline.Chunk(500, func(batch []Order) Order {
+//	    return mergeOrders(batch)
+//	})
+func (p *Pipeline[T]) Chunk(size int, aggregate func(batch []T) T) *Pipeline[T] {
+	p.operations = append(p.operations, &ChunkOperation[T]{Size: size, Aggregate: aggregate})
+	return p
+}
+
+// Chunk splits data into consecutive batches of the given size.
+// The last batch holds the remaining elements and may be shorter than size.
+// Each batch shares memory with data but has its capacity clipped, so appending to a batch never
+// overwrites the following one.
+// Returns an error if size is not positive.
+//
+// Example:
+//
+//	orders, _ := NewPipelineWithData(orders).
+//	    Filter(func(o Order) bool { return o.Pending }).
+//	    Execute()
+//
+//	batches, err := Chunk(orders, 500)
+//	for _, batch := range batches {
+//	    bulkSubmit(batch)
+//	}
+func Chunk[T any](data []T, size int) ([][]T, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive, got %d", size)
+	}
+
+	chunks := make([][]T, 0, (len(data)+size-1)/size)
+	for start := 0; start < len(data); start += size {
+		end := min(start+size, len(data))
+		chunks = append(chunks, data[start:end:end])
+	}
+	return chunks, nil
+}
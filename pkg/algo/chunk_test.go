@@ -0,0 +1,87 @@
+package algo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChunk_EvenSplit(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6}
+	expected := [][]int{{1, 2}, {3, 4}, {5, 6}}
+
+	chunks, err := Chunk(data, 2)
+	if err != nil {
+		t.Fatalf("Chunk failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(chunks, expected) {
+		t.Errorf("Expected %v, got %v", expected, chunks)
+	}
+}
+
+func TestChunk_PartialLastChunk(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	expected := [][]int{{1, 2, 3}, {4, 5}}
+
+	chunks, err := Chunk(data, 3)
+	if err != nil {
+		t.Fatalf("Chunk failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(chunks, expected) {
+		t.Errorf("Expected %v, got %v", expected, chunks)
+	}
+
+	chunks[0] = append(chunks[0], 99)
+	if data[3] != 4 {
+		t.Errorf("Appending to a chunk overwrote the following element: %v", data)
+	}
+}
+
+func TestChunk_InvalidSize(t *testing.T) {
+	if _, err := Chunk([]int{1, 2, 3}, 0); err == nil {
+		t.Fatalf("Expected error for zero chunk size, but got nil")
+	}
+}
+
+func TestChunkOperation_Aggregate(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Chunk(2, func(batch []int) int {
+			sum := 0
+			for _, x := range batch {
+				sum += x
+			}
+			return sum
+		})
+
+	data := []int{1, 2, 3, 4, 5}
+	expected := []int{3, 7, 5}
+
+	pipeline.WithData(data)
+
+	chunkedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ChunkOperation failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(chunkedData, expected) {
+		t.Errorf("Expected %v, got %v", expected, chunkedData)
+	}
+}
+
+func TestChunkOperation_InvalidSize(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Chunk(-1, func(batch []int) int { return batch[0] })
+
+	pipeline.WithData([]int{1, 2, 3})
+
+	_, err := pipeline.Execute()
+	if err == nil {
+		t.Fatalf("Expected error for negative chunk size, but got nil")
+	}
+
+	expectedError := "ChunkOperation: chunk size must be positive, got -1"
+	if err.Error() != expectedError {
+		t.Errorf("Expected error message '%s', got '%s'", expectedError, err.Error())
+	}
+}
@@ -0,0 +1,41 @@
+package algo
+
+// ConcatOperation appends other slices to the end of the data.
+// The order of the data and of each appended slice is preserved.
+type ConcatOperation[T any] struct {
+	Others [][]T
+}
+
+// Apply performs the concat operation on the data.
+// It returns a new slice containing the data followed by every slice in Others.
+//
+// Example:
+//
+//	pipeline := NewPipelineWithData([]int{1, 2}).
+//	    Concat([]int{3, 4}, []int{5})
+//	result, err := pipeline.Execute() // [1 2 3 4 5]
+func (c *ConcatOperation[T]) Apply(data []T) ([]T, error) {
+	total := len(data)
+	for _, other := range c.Others {
+		total += len(other)
+	}
+
+	concatenatedData := make([]T, 0, total)
+	concatenatedData = append(concatenatedData, data...)
+	for _, other := range c.Others {
+		concatenatedData = append(concatenatedData, other...)
+	}
+	return concatenatedData, nil
+}
+
+// Concat adds a concat operation to the pipeline.
+// To append the output of another pipeline, pass the result of its Execute.
+//
+// Example:
+//
+//	archived, _ := archivePipeline.Execute()
+//	pipeline.Concat(archived)
+func (p *Pipeline[T]) Concat(others ...[]T) *Pipeline[T] {
+	p.operations = append(p.operations, &ConcatOperation[T]{Others: others})
+	return p
+}
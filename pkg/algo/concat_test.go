@@ -0,0 +1,51 @@
+package algo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConcatOperation_MultipleSlices(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Concat([]int{3, 4}, nil, []int{5})
+
+	pipeline.WithData([]int{1, 2})
+
+	concatenatedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ConcatOperation failed: %v", err)
+	}
+
+	expected := []int{1, 2, 3, 4, 5}
+	if !reflect.DeepEqual(concatenatedData, expected) {
+		t.Errorf("Expected %v, got %v", expected, concatenatedData)
+	}
+}
+
+func TestConcatOperation_PipelineOutput(t *testing.T) {
+	archived, err := NewPipelineWithData([]Item{
+		{ID: 3, Name: "Item3", Active: false},
+		{ID: 4, Name: "Item4", Active: true},
+	}).
+		Filter(func(a Item) bool { return a.Active }).
+		Execute()
+	if err != nil {
+		t.Fatalf("Pipeline execution failed: %v", err)
+	}
+
+	pipeline := NewPipelineWithData([]Item{{ID: 1, Name: "Item1", Active: true}}).
+		Concat(archived)
+
+	concatenatedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ConcatOperation failed: %v", err)
+	}
+
+	expected := []Item{
+		{ID: 1, Name: "Item1", Active: true},
+		{ID: 4, Name: "Item4", Active: true},
+	}
+	if !reflect.DeepEqual(concatenatedData, expected) {
+		t.Errorf("Expected %v, got %v", expected, concatenatedData)
+	}
+}
@@ -0,0 +1,171 @@
+package algo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// countMinSketchVersion is the version byte written by CountMinSketch.MarshalBinary.
+const countMinSketchVersion = 1
+
+// CountMinSketch estimates how often each key occurs in a stream using a fixed amount of memory.
+// Estimates never undercount; they overcount by at most epsilon*N with probability 1-delta,
+// where N is the total count added and width = ceil(e/epsilon), depth = ceil(ln(1/delta)).
+// Sketches with the same dimensions can be merged.
+type CountMinSketch struct {
+	width    int
+	depth    int
+	counters []uint64
+	total    uint64
+}
+
+// NewCountMinSketch creates a sketch with depth rows of width counters.
+// Returns an error if width or depth is not positive or the sketch would have more counters than an int can count.
+//
+// Example:
+//
+//	cms, err := NewCountMinSketch(2048, 5)
+//	cms.AddString("/checkout")
+//	hits := cms.Estimate("/checkout")
+func NewCountMinSketch(width, depth int) (*CountMinSketch, error) {
+	if width <= 0 || depth <= 0 {
+		return nil, fmt.Errorf("CountMinSketch: width and depth must be positive, got %d and %d", width, depth)
+	}
+	if width > math.MaxInt/depth {
+		return nil, fmt.Errorf("CountMinSketch: %dx%d counters are too many", depth, width)
+	}
+	return &CountMinSketch{width: width, depth: depth, counters: make([]uint64, width*depth)}, nil
+}
+
+// NewCountMinSketchWithEstimates creates a sketch whose estimates exceed the true count by at most
+// epsilon times the total count, with probability at least 1-delta.
+// Returns an error if epsilon or delta is not between 0 and 1.
+//
+// Example:
+//
+//	cms, err := NewCountMinSketchWithEstimates(0.001, 0.01)
+func NewCountMinSketchWithEstimates(epsilon, delta float64) (*CountMinSketch, error) {
+	if epsilon <= 0 || epsilon >= 1 || delta <= 0 || delta >= 1 {
+		return nil, fmt.Errorf("CountMinSketch: epsilon and delta must be between 0 and 1, got %v and %v", epsilon, delta)
+	}
+	return NewCountMinSketch(int(math.Ceil(math.E/epsilon)), int(math.Ceil(math.Log(1/delta))))
+}
+
+// Add adds count occurrences of a key.
+func (c *CountMinSketch) Add(key []byte, count uint64) {
+	c.addHash(hash64(key), count)
+}
+
+// AddString adds a single occurrence of a key.
+func (c *CountMinSketch) AddString(key string) {
+	c.addHash(hashString(key), 1)
+}
+
+// AddStringCount adds count occurrences of a key.
+func (c *CountMinSketch) AddStringCount(key string, count uint64) {
+	c.addHash(hashString(key), count)
+}
+
+// addHash increments one counter per row for a hashed key.
+func (c *CountMinSketch) addHash(h uint64, count uint64) {
+	for row := 0; row < c.depth; row++ {
+		c.counters[c.index(h, row)] += count
+	}
+	c.total += count
+}
+
+// Estimate returns the estimated number of occurrences of a key.
+func (c *CountMinSketch) Estimate(key string) uint64 {
+	h := hashString(key)
+	estimate := uint64(math.MaxUint64)
+	for row := 0; row < c.depth; row++ {
+		estimate = min(estimate, c.counters[c.index(h, row)])
+	}
+	return estimate
+}
+
+// Total returns the sum of all counts added.
+func (c *CountMinSketch) Total() uint64 {
+	return c.total
+}
+
+// Merge adds the counts of another sketch into c.
+// Returns an error if the dimensions differ.
+func (c *CountMinSketch) Merge(other *CountMinSketch) error {
+	if c.width != other.width || c.depth != other.depth {
+		return fmt.Errorf("CountMinSketch: cannot merge %dx%d into %dx%d", other.depth, other.width, c.depth, c.width)
+	}
+	for i, v := range other.counters {
+		c.counters[i] += v
+	}
+	c.total += other.total
+	return nil
+}
+
+// MarshalBinary encodes the sketch so that it can be persisted or sent to another process.
+func (c *CountMinSketch) MarshalBinary() ([]byte, error) {
+	data := []byte{countMinSketchVersion}
+	data = binary.AppendUvarint(data, uint64(c.width))
+	data = binary.AppendUvarint(data, uint64(c.depth))
+	data = binary.AppendUvarint(data, c.total)
+	for _, v := range c.counters {
+		data = binary.AppendUvarint(data, v)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a sketch encoded by MarshalBinary, replacing the contents of c.
+func (c *CountMinSketch) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != countMinSketchVersion {
+		return errors.New("CountMinSketch: unsupported encoding")
+	}
+	r := &uvarintReader{data: data[1:]}
+	width, depth, total := r.next(), r.next(), r.next()
+	// Every counter takes at least one byte, so each dimension is checked before multiplying them.
+	if r.err != nil || width == 0 || depth == 0 || width > uint64(len(data)) || depth > uint64(len(data)) ||
+		width*depth > uint64(len(data)) {
+		return errors.New("CountMinSketch: corrupt header")
+	}
+
+	decoded, err := NewCountMinSketch(int(width), int(depth))
+	if err != nil {
+		return err
+	}
+	decoded.total = total
+	for i := range decoded.counters {
+		decoded.counters[i] = r.next()
+	}
+	if r.err != nil || len(r.data) != 0 {
+		return errors.New("CountMinSketch: corrupt counters")
+	}
+	*c = *decoded
+	return nil
+}
+
+// index returns the position of the counter for a hashed key in the given row,
+// deriving one hash per row by double hashing.
+func (c *CountMinSketch) index(h uint64, row int) int {
+	return row*c.width + int((h+uint64(row)*hashStep(h))%uint64(c.width))
+}
+
+// uvarintReader decodes a sequence of uvarints, remembering the first error.
+type uvarintReader struct {
+	data []byte
+	err  error
+}
+
+// next decodes the next uvarint, or returns 0 once an error has occurred.
+func (r *uvarintReader) next() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errors.New("invalid uvarint")
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
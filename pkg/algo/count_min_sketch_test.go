@@ -0,0 +1,148 @@
+package algo
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestNewCountMinSketch_Invalid(t *testing.T) {
+	if _, err := NewCountMinSketch(0, 3); err == nil {
+		t.Error("Expected error for zero width")
+	}
+	if _, err := NewCountMinSketch(10, -1); err == nil {
+		t.Error("Expected error for negative depth")
+	}
+	if _, err := NewCountMinSketchWithEstimates(0, 0.01); err == nil {
+		t.Error("Expected error for zero epsilon")
+	}
+	if _, err := NewCountMinSketchWithEstimates(0.01, 1); err == nil {
+		t.Error("Expected error for delta of 1")
+	}
+}
+
+func TestNewCountMinSketchWithEstimates_Dimensions(t *testing.T) {
+	cms, err := NewCountMinSketchWithEstimates(0.01, 0.01)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cms.width != 272 || cms.depth != 5 {
+		t.Errorf("Expected 5x272, got %dx%d", cms.depth, cms.width)
+	}
+}
+
+func TestCountMinSketch_NeverUndercounts(t *testing.T) {
+	cms, _ := NewCountMinSketchWithEstimates(0.001, 0.01)
+	rng := rand.New(rand.NewSource(1))
+	exact := make(map[string]uint64)
+	for i := 0; i < 100000; i++ {
+		key := "page-" + strconv.Itoa(int(rng.ExpFloat64()*50))
+		exact[key]++
+		cms.AddString(key)
+	}
+	cms.AddStringCount("bulk", 500)
+	cms.Add([]byte("bulk"), 250)
+	exact["bulk"] = 750
+
+	bound := uint64(0.001 * float64(cms.Total()))
+	for key, count := range exact {
+		estimate := cms.Estimate(key)
+		if estimate < count {
+			t.Errorf("%s: estimate %d is below the true count %d", key, estimate, count)
+		}
+		if estimate > count+bound {
+			t.Errorf("%s: estimate %d exceeds %d by more than %d", key, estimate, count, bound)
+		}
+	}
+	if cms.Estimate("never-seen") > bound {
+		t.Errorf("Unexpected estimate for unseen key: %d", cms.Estimate("never-seen"))
+	}
+}
+
+func TestCountMinSketch_Merge(t *testing.T) {
+	a, _ := NewCountMinSketch(100, 4)
+	b, _ := NewCountMinSketch(100, 4)
+	a.AddStringCount("x", 3)
+	b.AddStringCount("x", 4)
+	b.AddString("y")
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if a.Estimate("x") < 7 || a.Estimate("y") < 1 || a.Total() != 8 {
+		t.Errorf("Unexpected merged estimates: x=%d y=%d total=%d", a.Estimate("x"), a.Estimate("y"), a.Total())
+	}
+
+	c, _ := NewCountMinSketch(50, 4)
+	if err := a.Merge(c); err == nil {
+		t.Error("Expected error when merging different dimensions")
+	}
+}
+
+func TestCountMinSketch_MarshalRoundTrip(t *testing.T) {
+	cms, _ := NewCountMinSketch(64, 3)
+	for i := 0; i < 1000; i++ {
+		cms.AddString(strconv.Itoa(i % 37))
+	}
+
+	data, err := cms.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded CountMinSketch
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := 0; i < 37; i++ {
+		key := strconv.Itoa(i)
+		if decoded.Estimate(key) != cms.Estimate(key) {
+			t.Errorf("%s: expected %d, got %d", key, cms.Estimate(key), decoded.Estimate(key))
+		}
+	}
+	if decoded.Total() != 1000 {
+		t.Errorf("Expected total 1000, got %d", decoded.Total())
+	}
+
+	for _, corrupt := range [][]byte{nil, {9}, data[:len(data)-1], append(data, 0)} {
+		if err := decoded.UnmarshalBinary(corrupt); err == nil {
+			t.Errorf("Expected error for corrupt encoding of length %d", len(corrupt))
+		}
+	}
+
+	// Dimensions whose product overflows must not decode into a sketch without counters.
+	overflow := []byte{countMinSketchVersion}
+	overflow = binary.AppendUvarint(overflow, 1<<32)
+	overflow = binary.AppendUvarint(overflow, 1<<32)
+	overflow = binary.AppendUvarint(overflow, 0)
+	if err := decoded.UnmarshalBinary(overflow); err == nil {
+		t.Error("Expected error for overflowing dimensions")
+	}
+}
+
+func TestNewCountMinSketch_TooLarge(t *testing.T) {
+	if _, err := NewCountMinSketch(math.MaxInt/2+1, 2); err == nil {
+		t.Error("Expected error for overflowing dimensions")
+	}
+}
+
+func FuzzCountMinSketch_UnmarshalBinary(f *testing.F) {
+	cms, _ := NewCountMinSketch(8, 3)
+	cms.AddString("a")
+	data, _ := cms.MarshalBinary()
+	f.Add(data)
+	f.Add([]byte{countMinSketchVersion, 0x80, 0x80, 0x80, 0x80, 0x10, 0x80, 0x80, 0x80, 0x80, 0x10, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var decoded CountMinSketch
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			return
+		}
+		if len(decoded.counters) != decoded.width*decoded.depth {
+			t.Fatalf("Decoded %dx%d sketch with %d counters", decoded.depth, decoded.width, len(decoded.counters))
+		}
+		decoded.AddString("key")
+		decoded.Estimate("key")
+	})
+}
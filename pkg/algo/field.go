@@ -0,0 +1,108 @@
+package algo
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/fieldpath"
+)
+
+// ErrUnknownField is returned by the field operations when a field name does not exist in the element type.
+var ErrUnknownField = fieldpath.ErrUnknownField
+
+// Field is a resolved, possibly nested field of an element type. See ResolveField.
+type Field = fieldpath.Field
+
+// ResolveField looks up a dot-separated field path of T, such as "Customer.Country".
+// Each name matches an exported field by Go name or `algo` tag, ignoring case, and pointers
+// to structs along the path are followed. Resolved paths are cached per type.
+// Returns an error wrapping ErrUnknownField if the path does not name a field.
+//
+// Example:
+//
+//	field, err := ResolveField[Order]("customer.country")
+//	country := field.Value(reflect.ValueOf(order)).String()
+func ResolveField[T any](path string) (*Field, error) {
+	return fieldpath.Resolve(reflect.TypeOf((*T)(nil)).Elem(), path)
+}
+
+// timeType is the type of time.Time fields, whose keys are normalized to UTC.
+var timeType = reflect.TypeOf(time.Time{})
+
+// fieldValue returns the value of the field in item.
+func fieldValue[T any](f *Field, item T) reflect.Value {
+	return f.Value(reflect.ValueOf(&item).Elem())
+}
+
+// requireOrdered returns an error unless the field can be sorted.
+func requireOrdered(f *Field) error {
+	if !f.Ordered() {
+		return fmt.Errorf("field %s of type %s cannot be ordered", f.Name, f.Type)
+	}
+	return nil
+}
+
+// requireComparable returns an error unless values of the field can be compared with == and used as map keys.
+// Interface types are rejected because the values they hold may not be comparable.
+func requireComparable(f *Field) error {
+	if !hashable(f.Type) {
+		return fmt.Errorf("field %s of type %s cannot be compared", f.Name, f.Type)
+	}
+	return nil
+}
+
+// hashable reports whether every value of t can be compared with == without panicking.
+func hashable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Array:
+		return hashable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !hashable(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return t.Comparable()
+}
+
+// convertFieldValue converts a value to the type of the field. Numbers convert between numeric
+// types when no precision is lost, and named string and bool types convert to their underlying kind.
+func convertFieldValue(f *Field, value any) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(f.Type), nil
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Type() == f.Type {
+		return v, nil
+	}
+	from, to := kindClass(v.Type()), kindClass(f.Type)
+	if from != "" && from == to && v.CanConvert(f.Type) {
+		converted := v.Convert(f.Type)
+		if to != "number" || converted.Convert(v.Type()).Equal(v) {
+			return converted, nil
+		}
+		return reflect.Value{}, fmt.Errorf("value %v does not fit field %s of type %s", value, f.Name, f.Type)
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %v (%T) as field %s of type %s", value, value, f.Name, f.Type)
+}
+
+// kindClass groups the kinds that convert into each other without changing meaning.
+func kindClass(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	}
+	return ""
+}
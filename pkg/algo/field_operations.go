@@ -0,0 +1,370 @@
+package algo
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// SortOrder is the direction of SortByField.
+type SortOrder int
+
+const (
+	// Asc sorts from the smallest value to the largest.
+	Asc SortOrder = iota
+	// Desc sorts from the largest value to the smallest.
+	Desc
+)
+
+// SortByFieldOperation sorts data by the value of a named field using a stable merge sort.
+// Field is a Go field name or `algo` tag, with dots for nested structs. Booleans, numbers,
+// strings and time.Time values can be sorted; a nil pointer along the path reads as the zero value.
+type SortByFieldOperation[T any] struct {
+	Field string
+	Order SortOrder
+	Err   error
+}
+
+// Apply performs the sort on the data.
+// Elements with equal field values keep their original order.
+//
+// Example:
+//
+//	pipeline := NewPipeline[Sale]().
+//	    SortByField("Amount", Desc)
+//	result, err := pipeline.Execute()
+func (s *SortByFieldOperation[T]) Apply(data []T) ([]T, error) {
+	if s.Err != nil {
+		return nil, fmt.Errorf("SortByFieldOperation: %w", s.Err)
+	}
+	comparator, err := s.comparator()
+	if err != nil {
+		return nil, fmt.Errorf("SortByFieldOperation: %w", err)
+	}
+	return (&MergeSortOperation[T]{Comparator: comparator}).Apply(data)
+}
+
+// comparator resolves the field and returns a comparator that is true on ties, which keeps MergeSort stable.
+func (s *SortByFieldOperation[T]) comparator() (func(a, b T) bool, error) {
+	accessor, err := ResolveField[T](s.Field)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOrdered(accessor); err != nil {
+		return nil, err
+	}
+	sign := 1
+	if s.Order == Desc {
+		sign = -1
+	}
+	return func(a, b T) bool {
+		return sign*accessor.Compare(fieldValue(accessor, a), fieldValue(accessor, b)) <= 0
+	}, nil
+}
+
+// SortByField adds a stable sort by the value of a named field to the pipeline.
+// The field is validated when the stage is added; an unknown or unsortable field is
+// returned as an error when the pipeline executes.
+//
+// Example:
+//
+//	pipeline.SortByField("Customer.Region", Asc).SortByField("Amount", Desc)
+func (p *Pipeline[T]) SortByField(field string, order SortOrder) *Pipeline[T] {
+	op := &SortByFieldOperation[T]{Field: field, Order: order}
+	_, op.Err = op.comparator()
+	p.operations = append(p.operations, op)
+	return p
+}
+
+// FilterByFieldOperation selects the elements whose named field compares to Value with Op,
+// one of ==, !=, <, <=, > and >=. Value is converted to the type of the field: numbers convert
+// between numeric types when no precision is lost, and strings and bools convert to named types.
+type FilterByFieldOperation[T any] struct {
+	Field string
+	Op    string
+	Value any
+	Err   error
+}
+
+// Apply performs the comparison on the data.
+// It returns the matching elements in their original order.
+//
+// Example:
+//
+//	pipeline := NewPipeline[Sale]().
+//	    FilterByField("Amount", ">=", 100)
+//	result, err := pipeline.Execute()
+func (f *FilterByFieldOperation[T]) Apply(data []T) ([]T, error) {
+	if f.Err != nil {
+		return nil, fmt.Errorf("FilterByFieldOperation: %w", f.Err)
+	}
+	predicate, err := f.predicate()
+	if err != nil {
+		return nil, fmt.Errorf("FilterByFieldOperation: %w", err)
+	}
+	return (&FilterOperation[T]{Predicate: predicate}).Apply(data)
+}
+
+// predicate resolves the field, checks it supports the operator, converts the value and
+// returns the comparison as a predicate.
+func (f *FilterByFieldOperation[T]) predicate() (func(T) bool, error) {
+	accessor, err := ResolveField[T](f.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches func(c int) bool
+	require := requireOrdered
+	switch f.Op {
+	case "==", "!=":
+		require = requireComparable
+	case "<":
+		matches = func(c int) bool { return c < 0 }
+	case "<=":
+		matches = func(c int) bool { return c <= 0 }
+	case ">":
+		matches = func(c int) bool { return c > 0 }
+	case ">=":
+		matches = func(c int) bool { return c >= 0 }
+	default:
+		return nil, fmt.Errorf("unknown comparison operator %q", f.Op)
+	}
+	if err := require(accessor); err != nil {
+		return nil, err
+	}
+	value, err := convertFieldValue(accessor, f.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	if matches != nil {
+		return func(item T) bool {
+			return matches(accessor.Compare(fieldValue(accessor, item), value))
+		}, nil
+	}
+	want := f.Op == "=="
+	if accessor.Ordered() {
+		// Compare rather than ==, so that equal times in different locations match.
+		return func(item T) bool {
+			return (accessor.Compare(fieldValue(accessor, item), value) == 0) == want
+		}, nil
+	}
+	return func(item T) bool {
+		return fieldValue(accessor, item).Equal(value) == want
+	}, nil
+}
+
+// FilterByField adds a filter comparing a named field to a value to the pipeline.
+// The field, operator and value are validated when the stage is added; an error is
+// returned when the pipeline executes.
+//
+// Example:
+//
+//	pipeline.FilterByField("Amount", ">", 100).FilterByField("Customer.Country", "!=", "US")
+func (p *Pipeline[T]) FilterByField(field, op string, value any) *Pipeline[T] {
+	filter := &FilterByFieldOperation[T]{Field: field, Op: op, Value: value}
+	_, filter.Err = filter.predicate()
+	p.operations = append(p.operations, filter)
+	return p
+}
+
+// FilterEq adds a filter keeping the elements whose named field equals value. See FilterByField.
+//
+// Example:
+//
+//	pipeline.FilterEq("Status", "completed")
+func (p *Pipeline[T]) FilterEq(field string, value any) *Pipeline[T] {
+	return p.FilterByField(field, "==", value)
+}
+
+// GroupByFieldOperation reorders data so that elements with the same value of a named field are adjacent.
+// Groups appear in the order of their first element and keep the original order of their elements,
+// so a preceding sort decides the order within each group.
+type GroupByFieldOperation[T any] struct {
+	Field string
+	Err   error
+}
+
+// Apply performs the grouping on the data.
+//
+// Example:
+//
+//	pipeline := NewPipeline[Sale]().
+//	    SortByField("Amount", Desc).
+//	    GroupByField("Category")
+//	result, err := pipeline.Execute()
+func (g *GroupByFieldOperation[T]) Apply(data []T) ([]T, error) {
+	if g.Err != nil {
+		return nil, fmt.Errorf("GroupByFieldOperation: %w", g.Err)
+	}
+	groupedData, err := groupByFields(data, []string{g.Field}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("GroupByFieldOperation: %w", err)
+	}
+	return groupedData, nil
+}
+
+// GroupByField adds a grouping by the value of a named field to the pipeline.
+// The field must be comparable with == and not hold an interface; it is validated when the stage is added.
+//
+// Example:
+//
+//	pipeline.GroupByField("Customer.Region")
+func (p *Pipeline[T]) GroupByField(field string) *Pipeline[T] {
+	_, err := fieldsKey[T]([]string{field})
+	p.operations = append(p.operations, &GroupByFieldOperation[T]{Field: field, Err: err})
+	return p
+}
+
+// GroupByFieldsOperation reorders data so that elements with the same values of the named fields are
+// adjacent, as GroupByFieldOperation does for one field. A positive Limit keeps only the first Limit
+// elements of each group.
+type GroupByFieldsOperation[T any] struct {
+	Fields []string
+	Limit  int
+	Err    error
+}
+
+// Apply performs the grouping on the data.
+//
+// Example:
+//
+//	top3 := &GroupByFieldsOperation[Sale]{Fields: []string{"Region", "Category"}, Limit: 3}
+//	result, err := NewPipeline[Sale]().SortByField("Amount", Desc).AddOperation(top3).Execute()
+func (g *GroupByFieldsOperation[T]) Apply(data []T) ([]T, error) {
+	if g.Err != nil {
+		return nil, fmt.Errorf("GroupByFieldsOperation: %w", g.Err)
+	}
+	groupedData, err := groupByFields(data, g.Fields, g.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("GroupByFieldsOperation: %w", err)
+	}
+	return groupedData, nil
+}
+
+// GroupByFields adds a grouping by the values of the named fields to the pipeline.
+// The fields must be comparable with == and not hold interfaces; they are validated when the stage is added.
+//
+// Example:
+//
+//	pipeline.GroupByFields("Region", "Category")
+func (p *Pipeline[T]) GroupByFields(fields ...string) *Pipeline[T] {
+	_, err := fieldsKey[T](fields)
+	p.operations = append(p.operations, &GroupByFieldsOperation[T]{Fields: fields, Err: err})
+	return p
+}
+
+// groupByFields groups data by the values of the named fields, keeping at most limit elements
+// per group when limit is positive.
+func groupByFields[T any](data []T, fields []string, limit int) ([]T, error) {
+	key, err := fieldsKey[T](fields)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[any][]T)
+	var order []any
+	for _, item := range data {
+		k := key(item)
+		group, ok := groups[k]
+		if !ok {
+			order = append(order, k)
+		}
+		if limit <= 0 || len(group) < limit {
+			groups[k] = append(group, item)
+		}
+	}
+
+	groupedData := make([]T, 0, len(data))
+	for _, k := range order {
+		groupedData = append(groupedData, groups[k]...)
+	}
+	return groupedData, nil
+}
+
+// DistinctByFieldsOperation keeps the first element for each combination of values of the named fields.
+// Unlike DistinctOperation it compares against every element seen so far, so duplicates need not be adjacent.
+type DistinctByFieldsOperation[T any] struct {
+	Fields []string
+	Err    error
+}
+
+// Apply removes the duplicates from the data.
+// It returns the remaining elements in their original order.
+//
+// Example:
+//
+//	pipeline := NewPipeline[Sale]().
+//	    DistinctByFields("UserID", "Category")
+//	result, err := pipeline.Execute()
+func (d *DistinctByFieldsOperation[T]) Apply(data []T) ([]T, error) {
+	if d.Err != nil {
+		return nil, fmt.Errorf("DistinctByFieldsOperation: %w", d.Err)
+	}
+	key, err := fieldsKey[T](d.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("DistinctByFieldsOperation: %w", err)
+	}
+
+	seen := make(map[any]struct{}, len(data))
+	distinctData := make([]T, 0, len(data))
+	for _, item := range data {
+		k := key(item)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		distinctData = append(distinctData, item)
+	}
+	return distinctData, nil
+}
+
+// DistinctByFields adds a removal of elements with duplicate values of the named fields to the pipeline.
+// The fields must be comparable with == and not hold interfaces; they are validated when the stage is added.
+//
+// Example:
+//
+//	pipeline.DistinctByFields("UserID", "Category")
+func (p *Pipeline[T]) DistinctByFields(fields ...string) *Pipeline[T] {
+	_, err := fieldsKey[T](fields)
+	p.operations = append(p.operations, &DistinctByFieldsOperation[T]{Fields: fields, Err: err})
+	return p
+}
+
+// fieldsKey returns a function building a map key from the values of the named fields.
+// Each value is nested into a pair with the key of the previous fields, which keeps keys
+// exact and comparable without formatting the values. Times are keyed by their instant.
+func fieldsKey[T any](fields []string) (func(T) any, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields given")
+	}
+	accessors := make([]*Field, len(fields))
+	for i, field := range fields {
+		accessor, err := ResolveField[T](field)
+		if err != nil {
+			return nil, err
+		}
+		if err := requireComparable(accessor); err != nil {
+			return nil, err
+		}
+		accessors[i] = accessor
+	}
+
+	return func(item T) any {
+		v := reflect.ValueOf(&item).Elem()
+		var key any
+		for i, accessor := range accessors {
+			value := accessor.Value(v).Interface()
+			if t, ok := value.(time.Time); ok && accessor.Type == timeType {
+				// Equal instants in different locations share a key, as they do in FilterByField.
+				value = t.UTC()
+			}
+			if i == 0 {
+				key = value
+				continue
+			}
+			key = [2]any{key, value}
+		}
+		return key
+	}, nil
+}
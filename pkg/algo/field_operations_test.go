@@ -0,0 +1,251 @@
+package algo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSortByField(t *testing.T) {
+	result, err := NewPipelineWithData(generateSales()).SortByField("Amount", Desc).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []int{3, 4, 1, 5, 7, 2, 6}; !reflect.DeepEqual(saleIDs(result), expected) {
+		t.Errorf("Expected %v, got %v", expected, saleIDs(result))
+	}
+
+	result, _ = NewPipelineWithData(generateSales()).
+		SortByField("Amount", Desc).
+		SortByField("cat", Asc).
+		Execute()
+	if expected := []int{4, 1, 5, 3, 7, 2, 6}; !reflect.DeepEqual(saleIDs(result), expected) {
+		t.Errorf("Expected a stable sort %v, got %v", expected, saleIDs(result))
+	}
+}
+
+func TestSortByField_NestedPointer(t *testing.T) {
+	parcels := []Parcel{
+		{ID: 1, Carrier: &Carrier{Name: "ups"}},
+		{ID: 2},
+		{ID: 3, Carrier: &Carrier{Name: "dhl"}},
+	}
+	result, err := NewPipelineWithData(parcels).SortByField("Carrier.Name", Asc).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var ids []int
+	for _, p := range result {
+		ids = append(ids, p.ID)
+	}
+	if expected := []int{2, 3, 1}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected a nil carrier to sort as an empty name %v, got %v", expected, ids)
+	}
+}
+
+func TestSortByField_Errors(t *testing.T) {
+	op := &SortByFieldOperation[Manifest]{Field: "Parcels"}
+	if _, err := op.Apply([]Manifest{{}}); err == nil || !strings.Contains(err.Error(), "cannot be ordered") {
+		t.Errorf("Expected an unsortable field error, got %v", err)
+	}
+
+	_, err := NewPipelineWithData([]Parcel{{ID: 1}}).SortByField("Carrier", Asc).Execute()
+	if err == nil || !strings.Contains(err.Error(), "cannot be ordered") {
+		t.Errorf("Expected an unsortable field error, got %v", err)
+	}
+
+	_, err = NewPipelineWithData(generateSales()).SortByField("Amout", Asc).Execute()
+	if !errors.Is(err, ErrUnknownField) || !strings.HasPrefix(err.Error(), "SortByFieldOperation: ") {
+		t.Errorf("Expected a wrapped ErrUnknownField, got %v", err)
+	}
+}
+
+func TestFilterByField(t *testing.T) {
+	cases := []struct {
+		field, op string
+		value     any
+		expected  []int
+	}{
+		{"Amount", ">", 120, []int{3, 4}},
+		{"Amount", ">=", 120, []int{1, 3, 4, 5}},
+		{"Amount", "<", 99.5, []int{2, 6}},
+		{"Units", "<=", 1, []int{2, 5, 6}},
+		{"region", "!=", "eu", []int{2, 4, 6, 7}},
+		{"cat", "==", "toys", []int{6}},
+		{"Placed", ">", generateSales()[5].Placed, []int{7}},
+	}
+	for _, c := range cases {
+		result, err := NewPipelineWithData(generateSales()).FilterByField(c.field, c.op, c.value).Execute()
+		if err != nil {
+			t.Fatalf("%s %s %v: unexpected error: %v", c.field, c.op, c.value, err)
+		}
+		if !reflect.DeepEqual(saleIDs(result), c.expected) {
+			t.Errorf("%s %s %v: expected %v, got %v", c.field, c.op, c.value, c.expected, saleIDs(result))
+		}
+	}
+
+	result, _ := NewPipelineWithData(generateSales()).
+		FilterEq("Status", "completed").
+		FilterEq("Region", "us").
+		Execute()
+	if expected := []int{4, 7}; !reflect.DeepEqual(saleIDs(result), expected) {
+		t.Errorf("Expected %v, got %v", expected, saleIDs(result))
+	}
+}
+
+func TestFilterByField_NestedPointer(t *testing.T) {
+	parcels := []Parcel{
+		{ID: 1, Carrier: &Carrier{Express: true}},
+		{ID: 2},
+		{ID: 3, Carrier: &Carrier{}},
+	}
+	result, err := NewPipelineWithData(parcels).FilterEq("carrier.express", false).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 2 || result[0].ID != 2 || result[1].ID != 3 {
+		t.Errorf("Expected parcels 2 and 3, got %+v", result)
+	}
+}
+
+func TestFilterByField_Errors(t *testing.T) {
+	cases := map[string]*Pipeline[Sale]{
+		"unknown field":      NewPipeline[Sale]().FilterEq("Stat", "completed"),
+		"cannot use":         NewPipeline[Sale]().FilterEq("Amount", "high"),
+		"does not fit":       NewPipeline[Sale]().FilterByField("Units", ">", 1.5),
+		"unknown comparison": NewPipeline[Sale]().FilterByField("Units", "=~", 1),
+	}
+	for message, pipeline := range cases {
+		_, err := pipeline.WithData(generateSales()).Execute()
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected an error containing %q, got %v", message, err)
+		}
+	}
+
+	filter := &FilterByFieldOperation[Manifest]{Field: "Parcels", Op: "==", Value: nil}
+	if _, err := filter.Apply([]Manifest{{}}); err == nil || !strings.Contains(err.Error(), "cannot be compared") {
+		t.Errorf("Expected an incomparable field error, got %v", err)
+	}
+	_, err := NewPipelineWithData([]Parcel{{ID: 1}}).FilterByField("Carrier", "<", nil).Execute()
+	if err == nil || !strings.Contains(err.Error(), "cannot be ordered") {
+		t.Errorf("Expected an unordered field error, got %v", err)
+	}
+}
+
+func TestGroupByField(t *testing.T) {
+	result, err := NewPipelineWithData(generateSales()).GroupByField("Region").Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []int{1, 3, 5, 2, 4, 7, 6}; !reflect.DeepEqual(saleIDs(result), expected) {
+		t.Errorf("Expected %v, got %v", expected, saleIDs(result))
+	}
+
+	result, _ = NewPipelineWithData(generateSales()).
+		SortByField("Amount", Desc).
+		GroupByField("cat").
+		Execute()
+	if expected := []int{3, 7, 2, 4, 1, 5, 6}; !reflect.DeepEqual(saleIDs(result), expected) {
+		t.Errorf("Expected groups ordered by their largest sale %v, got %v", expected, saleIDs(result))
+	}
+
+	group := &GroupByFieldOperation[Manifest]{Field: "Parcels"}
+	if _, err := group.Apply([]Manifest{{}}); err == nil || !strings.HasPrefix(err.Error(), "GroupByFieldOperation: ") {
+		t.Errorf("Expected an incomparable field error, got %v", err)
+	}
+}
+
+func TestGroupByFields(t *testing.T) {
+	result, err := NewPipelineWithData(generateSales()).GroupByFields("Region", "Status").Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []int{1, 3, 5, 2, 4, 7, 6}; !reflect.DeepEqual(saleIDs(result), expected) {
+		t.Errorf("Expected %v, got %v", expected, saleIDs(result))
+	}
+
+	top := &GroupByFieldsOperation[Sale]{Fields: []string{"Region"}, Limit: 2}
+	result, err = NewPipelineWithData(generateSales()).SortByField("Amount", Desc).AddOperation(top).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []int{3, 1, 4, 7, 6}; !reflect.DeepEqual(saleIDs(result), expected) {
+		t.Errorf("Expected the two largest sales per region %v, got %v", expected, saleIDs(result))
+	}
+
+	if _, err := NewPipeline[Sale]().GroupByFields().WithData(generateSales()).Execute(); err == nil {
+		t.Errorf("Expected an error for no fields, but got nil")
+	}
+}
+
+func TestDistinctByFields(t *testing.T) {
+	result, err := NewPipelineWithData(generateSales()).DistinctByFields("Region", "Category").Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []int{1, 2, 3, 4, 6}; !reflect.DeepEqual(saleIDs(result), expected) {
+		t.Errorf("Expected %v, got %v", expected, saleIDs(result))
+	}
+
+	result, _ = NewPipelineWithData(generateSales()).DistinctByFields("Status").Execute()
+	if expected := []int{1, 2, 6}; !reflect.DeepEqual(saleIDs(result), expected) {
+		t.Errorf("Expected %v, got %v", expected, saleIDs(result))
+	}
+
+	_, err = NewPipeline[Sale]().DistinctByFields().WithData(generateSales()).Execute()
+	if err == nil || !strings.Contains(err.Error(), "no fields") {
+		t.Errorf("Expected a missing fields error, got %v", err)
+	}
+}
+
+func TestDistinctByFields_NonAdjacentDuplicates(t *testing.T) {
+	var sales []Sale
+	for i := 0; i < 300; i++ {
+		sales = append(sales, Sale{ID: i, Region: fmt.Sprint(i % 100)})
+	}
+	result, err := NewPipelineWithData(sales).DistinctByFields("Region").Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 100 || result[99].ID != 99 {
+		t.Errorf("Expected the first 100 sales, got %d ending with %d", len(result), result[len(result)-1].ID)
+	}
+}
+
+func TestFieldKeys_InterfaceFieldsRejected(t *testing.T) {
+	parcels := []Parcel{{ID: 1, Label: []int{1}}, {ID: 2, Label: []int{1}}}
+	for name, pipeline := range map[string]*Pipeline[Parcel]{
+		"DistinctByFieldsOperation": NewPipelineWithData(parcels).DistinctByFields("Label"),
+		"GroupByFieldOperation":     NewPipelineWithData(parcels).GroupByField("Label"),
+		"FilterByFieldOperation":    NewPipelineWithData(parcels).FilterEq("Label", "x"),
+	} {
+		_, err := pipeline.Execute()
+		if err == nil || !strings.HasPrefix(err.Error(), name+": ") || !strings.Contains(err.Error(), "cannot be compared") {
+			t.Errorf("%s: expected an incomparable field error, got %v", name, err)
+		}
+	}
+}
+
+func TestFieldKeys_TimesInDifferentLocations(t *testing.T) {
+	shipped := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	parcels := []Parcel{
+		{ID: 1, Shipped: shipped},
+		{ID: 2, Shipped: shipped.In(time.FixedZone("CEST", 2*3600))},
+		{ID: 3, Shipped: shipped.Add(time.Hour)},
+	}
+	result, err := NewPipelineWithData(parcels).DistinctByFields("Shipped").Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 2 || result[0].ID != 1 || result[1].ID != 3 {
+		t.Errorf("Expected parcels 1 and 3, got %+v", result)
+	}
+
+	result, _ = NewPipelineWithData(parcels).FilterEq("Shipped", shipped).Execute()
+	if len(result) != 2 {
+		t.Errorf("Expected parcels 1 and 2, got %+v", result)
+	}
+}
@@ -0,0 +1,81 @@
+package algo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type Carrier struct {
+	Name    string `algo:"carrier_name"`
+	Express bool
+}
+
+type Parcel struct {
+	ID      int
+	Weight  float32
+	Carrier *Carrier
+	Label   any
+	Shipped time.Time
+}
+
+type Manifest struct {
+	Parcels []int
+}
+
+func TestResolveField(t *testing.T) {
+	field, err := ResolveField[Parcel]("carrier.carrier_name")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if field.Name != "Carrier.Name" || field.Type.Kind() != reflect.String {
+		t.Errorf("Expected Carrier.Name of type string, got %s of type %s", field.Name, field.Type)
+	}
+	if got := fieldValue(field, Parcel{Carrier: &Carrier{Name: "ups"}}).String(); got != "ups" {
+		t.Errorf("Expected ups, got %q", got)
+	}
+	if got := fieldValue(field, Parcel{}).String(); got != "" {
+		t.Errorf("Expected the zero value through a nil pointer, got %q", got)
+	}
+
+	if _, err := ResolveField[Parcel]("Carrier.Missing"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField, got %v", err)
+	}
+}
+
+func TestConvertFieldValue(t *testing.T) {
+	weight, _ := ResolveField[Parcel]("Weight")
+	if v, err := convertFieldValue(weight, 2); err != nil || v.Float() != 2 {
+		t.Errorf("Expected 2 converted to float32, got %v, %v", v, err)
+	}
+	if _, err := convertFieldValue(weight, 0.1); err == nil {
+		t.Error("Expected an error for a value that loses precision")
+	}
+	if _, err := convertFieldValue(weight, "2"); err == nil {
+		t.Error("Expected an error for a string value")
+	}
+
+	id, _ := ResolveField[Parcel]("ID")
+	if _, err := convertFieldValue(id, 2.5); err == nil {
+		t.Error("Expected an error for a fractional value")
+	}
+	if v, err := convertFieldValue(id, nil); err != nil || v.Int() != 0 {
+		t.Errorf("Expected nil to convert to the zero value, got %v, %v", v, err)
+	}
+}
+
+func TestRequireComparable(t *testing.T) {
+	for path, expected := range map[string]bool{"ID": true, "Carrier": true, "Shipped": true, "Label": false} {
+		field, _ := ResolveField[Parcel](path)
+		if got := requireComparable(field) == nil; got != expected {
+			t.Errorf("%s: expected comparable %v, got %v", path, expected, got)
+		}
+	}
+
+	type Wrapped struct{ Inner struct{ Value any } }
+	field, _ := ResolveField[Wrapped]("Inner")
+	if requireComparable(field) == nil {
+		t.Error("Expected a struct holding an interface to be rejected")
+	}
+}
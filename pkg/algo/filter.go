@@ -32,6 +32,16 @@ func (f *FilterOperation[T]) Apply(data []T) ([]T, error) {
 	return filteredData, nil
 }
 
+// Stream implements Streamer, passing on only the elements that satisfy the predicate.
+func (f *FilterOperation[T]) Stream(emit func(T) bool) func(T) bool {
+	return func(item T) bool {
+		if f.Predicate(item) {
+			return emit(item)
+		}
+		return true
+	}
+}
+
 // Filter adds a filter operation to the pipeline.
 // The predicate function should return true for items to keep in the result.
 //
@@ -0,0 +1,48 @@
+package algo
+
+// FlatMapOperation expands each element in the data into zero or more elements.
+// The expanded elements are concatenated in the order of their source elements.
+type FlatMapOperation[T any] struct {
+	Mapper func(T) []T
+}
+
+// Apply performs the flat map operation on the data.
+// It returns a new slice containing the concatenation of all mapped slices.
+//
+// Example:
+//
+//	pipeline := NewPipeline[int]().
+//	    FlatMap(func(x int) []int { return []int{x, x * 10} })
+//	result, err := pipeline.Execute() // [1 10 2 20 ...]
+func (f *FlatMapOperation[T]) Apply(data []T) ([]T, error) {
+	flattenedData := make([]T, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		flattenedData = append(flattenedData, f.Mapper(data[i])...)
+	}
+	return flattenedData, nil
+}
+
+// Stream implements Streamer, passing on the expanded elements of every element.
+func (f *FlatMapOperation[T]) Stream(emit func(T) bool) func(T) bool {
+	return func(item T) bool {
+		for _, mapped := range f.Mapper(item) {
+			if !emit(mapped) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// FlatMap adds a flat map operation to the pipeline.
+// The mapper function returns the elements that replace each item; an empty slice drops the item.
+//
+// Example:
+//
+//	pipeline.FlatMap(func(order Order) []Order {
+//	    return splitByWarehouse(order)
+//	})
+func (p *Pipeline[T]) FlatMap(mapper func(T) []T) *Pipeline[T] {
+	p.operations = append(p.operations, &FlatMapOperation[T]{Mapper: mapper})
+	return p
+}
@@ -0,0 +1,86 @@
+package algo
+
+import (
+	"testing"
+)
+
+func TestFlatMapOperation_Expand(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		FlatMap(func(x int) []int { return []int{x, x * 10} })
+
+	data := []int{1, 2, 3}
+	expected := []int{1, 10, 2, 20, 3, 30}
+
+	pipeline.WithData(data)
+
+	flattenedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("FlatMapOperation failed: %v", err)
+	}
+
+	if len(flattenedData) != len(expected) {
+		t.Fatalf("Expected %d items, got %d", len(expected), len(flattenedData))
+	}
+
+	for i, item := range flattenedData {
+		if item != expected[i] {
+			t.Errorf("At index %d, expected %d, got %d", i, expected[i], item)
+		}
+	}
+}
+
+func TestFlatMapOperation_DropItems(t *testing.T) {
+	pipeline := NewPipeline[Item]().
+		FlatMap(func(a Item) []Item {
+			if !a.Active {
+				return nil
+			}
+			return []Item{a}
+		})
+
+	data := []Item{
+		{ID: 1, Name: "Item1", Active: true},
+		{ID: 2, Name: "Item2", Active: false},
+		{ID: 3, Name: "Item3", Active: true},
+	}
+
+	expected := []Item{
+		{ID: 1, Name: "Item1", Active: true},
+		{ID: 3, Name: "Item3", Active: true},
+	}
+
+	pipeline.WithData(data)
+
+	flattenedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("FlatMapOperation failed: %v", err)
+	}
+
+	if len(flattenedData) != len(expected) {
+		t.Fatalf("Expected %d items, got %d", len(expected), len(flattenedData))
+	}
+
+	for i, item := range flattenedData {
+		if item != expected[i] {
+			t.Errorf("At index %d, expected %+v, got %+v", i, expected[i], item)
+		}
+	}
+}
+
+func TestFlatMapOperation_EmptySlice(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		FlatMap(func(x int) []int { return []int{x, x} })
+
+	var data []int
+
+	pipeline.WithData(data)
+
+	flattenedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("FlatMapOperation failed on empty slice: %v", err)
+	}
+
+	if len(flattenedData) != 0 {
+		t.Errorf("Expected no items, but got %+v", flattenedData)
+	}
+}
@@ -0,0 +1,164 @@
+package algo
+
+import (
+	"reflect"
+	"sort"
+	"unicode/utf8"
+)
+
+// FuzzyMatch is an element matched by a fuzzy search together with its edit distance from the query.
+type FuzzyMatch[T any] struct {
+	Item     T
+	Distance int
+}
+
+// FuzzyFindOperation selects the elements whose key is within an edit distance of a query.
+// Matches are ranked from the closest to the farthest.
+type FuzzyFindOperation[T any] struct {
+	Key         func(T) string
+	Query       string
+	MaxDistance int
+	// Distance is the edit distance function. Levenshtein is used when nil.
+	Distance func(a, b string) int
+	// LengthBounded declares that Distance is never less than the difference in rune count of its
+	// arguments, as for Levenshtein and DamerauLevenshtein, so keys of a too different length are
+	// skipped without computing the distance.
+	LengthBounded bool
+}
+
+// Apply performs the fuzzy find operation on the data.
+// It returns the elements whose key is at most MaxDistance edits away from Query,
+// ordered by distance. Elements at the same distance keep their original order.
+//
+// Example:
+//
+//	pipeline := NewPipeline[string]().
+//	    FuzzyFind(func(s string) string { return s }, "jonh", 2)
+//	result, err := pipeline.Execute() // ["john", "joan", ...]
+func (f *FuzzyFindOperation[T]) Apply(data []T) ([]T, error) {
+	distance, bounded := f.Distance, f.LengthBounded
+	if distance == nil {
+		distance, bounded = Levenshtein, true
+	}
+
+	queryLength := utf8.RuneCountInString(f.Query)
+	matches := make([]FuzzyMatch[T], 0)
+	for _, item := range data {
+		key := f.Key(item)
+		if bounded && abs(utf8.RuneCountInString(key)-queryLength) > f.MaxDistance {
+			continue
+		}
+		if d := distance(key, f.Query); d <= f.MaxDistance {
+			matches = append(matches, FuzzyMatch[T]{Item: item, Distance: d})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Distance < matches[j].Distance
+	})
+
+	result := make([]T, len(matches))
+	for i, match := range matches {
+		result[i] = match.Item
+	}
+	return result, nil
+}
+
+// FuzzyFind adds a typo-tolerant search to the pipeline using the Levenshtein distance.
+// The key function selects the string to compare against the query.
+//
+// Example:
+//
+//	pipeline.FuzzyFind(func(c Customer) string {
+//	    return strings.ToLower(c.Name)
+//	}, "jonh smith", 2)
+func (p *Pipeline[T]) FuzzyFind(key func(T) string, query string, maxDistance int) *Pipeline[T] {
+	return p.FuzzyFindWith(key, query, maxDistance, Levenshtein)
+}
+
+// FuzzyFindWith adds a typo-tolerant search to the pipeline using a custom distance function,
+// such as DamerauLevenshtein to treat swapped adjacent characters as a single typo.
+// Other distances are computed for every key; set LengthBounded on a FuzzyFindOperation to skip
+// keys by length for a custom edit distance.
+//
+// Example:
+//
+//	pipeline.FuzzyFindWith(func(p Product) string { return p.SKU }, "AB1C23", 1, DamerauLevenshtein)
+func (p *Pipeline[T]) FuzzyFindWith(
+	key func(T) string, query string, maxDistance int, distance func(a, b string) int,
+) *Pipeline[T] {
+	p.operations = append(p.operations, &FuzzyFindOperation[T]{
+		Key:         key,
+		Query:       query,
+		MaxDistance: maxDistance,
+		Distance:    distance,
+		// The built-in edit distances count an insertion or deletion for every character of length difference.
+		LengthBounded: sameFunc(distance, Levenshtein) || sameFunc(distance, DamerauLevenshtein),
+	})
+	return p
+}
+
+// sameFunc reports whether two functions are the same top-level function.
+func sameFunc(a, b func(a, b string) int) bool {
+	return a != nil && reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+// SimilarityFindOperation selects the elements whose key is similar enough to a query
+// according to the Jaro-Winkler similarity. Matches are ranked from the most to the least similar.
+type SimilarityFindOperation[T any] struct {
+	Key      func(T) string
+	Query    string
+	MinScore float64
+}
+
+// Apply performs the similarity find operation on the data.
+// It returns the elements whose Jaro-Winkler similarity to Query is at least MinScore,
+// ordered by similarity. Elements with the same score keep their original order.
+//
+// Example:
+//
+//	pipeline := NewPipeline[string]().
+//	    FindSimilar(func(s string) string { return s }, "MARTHA", 0.9)
+//	result, err := pipeline.Execute()
+func (s *SimilarityFindOperation[T]) Apply(data []T) ([]T, error) {
+	type scored struct {
+		item  T
+		score float64
+	}
+
+	matches := make([]scored, 0)
+	for _, item := range data {
+		if score := JaroWinkler(s.Key(item), s.Query); score >= s.MinScore {
+			matches = append(matches, scored{item: item, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]T, len(matches))
+	for i, match := range matches {
+		result[i] = match.item
+	}
+	return result, nil
+}
+
+// FindSimilar adds a Jaro-Winkler similarity search to the pipeline.
+// The minScore is between 0 and 1; values around 0.85 to 0.9 suit personal names.
+//
+// Example:
+//
+//	pipeline.FindSimilar(func(c Customer) string { return c.LastName }, "Smyth", 0.88)
+func (p *Pipeline[T]) FindSimilar(key func(T) string, query string, minScore float64) *Pipeline[T] {
+	p.operations = append(p.operations, &SimilarityFindOperation[T]{Key: key, Query: query, MinScore: minScore})
+	return p
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
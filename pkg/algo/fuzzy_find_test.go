@@ -0,0 +1,96 @@
+package algo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFuzzyFindOperation_RankedByDistance(t *testing.T) {
+	pipeline := NewPipeline[string]().
+		FuzzyFind(func(s string) string { return s }, "jonh", 2)
+
+	pipeline.WithData([]string{"joan", "john", "jon", "jane", "johnny", "bob"})
+
+	result, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("FuzzyFindOperation failed: %v", err)
+	}
+
+	expected := []string{"jon", "joan", "john", "jane"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestFuzzyFindWith_Transpositions(t *testing.T) {
+	data := []Item{
+		{ID: 1, Name: "AB1C23"},
+		{ID: 2, Name: "A1BC23"},
+		{ID: 3, Name: "ZZZZZZ"},
+	}
+
+	levenshtein, _ := NewPipelineWithData(data).
+		FuzzyFind(func(a Item) string { return a.Name }, "AB1C23", 1).
+		Execute()
+	damerau, _ := NewPipelineWithData(data).
+		FuzzyFindWith(func(a Item) string { return a.Name }, "AB1C23", 1, DamerauLevenshtein).
+		Execute()
+
+	if len(levenshtein) != 1 {
+		t.Errorf("Expected Levenshtein to match 1 item, got %v", levenshtein)
+	}
+	if len(damerau) != 2 || damerau[1].ID != 2 {
+		t.Errorf("Expected DamerauLevenshtein to match the transposed SKU, got %v", damerau)
+	}
+}
+
+func TestFuzzyFindWith_CustomDistance(t *testing.T) {
+	// Hamming-like distance over the shared prefix, ignoring any extra characters.
+	prefixMismatches := func(a, b string) int {
+		d := 0
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if a[i] != b[i] {
+				d++
+			}
+		}
+		return d
+	}
+
+	result, err := NewPipelineWithData([]string{"abc", "abcdefgh", "xbc"}).
+		FuzzyFindWith(func(s string) string { return s }, "abc", 0, prefixMismatches).
+		Execute()
+	if err != nil {
+		t.Fatalf("FuzzyFindOperation failed: %v", err)
+	}
+
+	expected := []string{"abc", "abcdefgh"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected keys of any length to be compared, got %v", result)
+	}
+
+	op := NewPipeline[string]().FuzzyFindWith(func(s string) string { return s }, "abc", 0, Levenshtein).
+		GetOperations()[0].(*FuzzyFindOperation[string])
+	if !op.LengthBounded {
+		t.Errorf("Expected Levenshtein to be length bounded")
+	}
+}
+
+func TestSimilarityFindOperation(t *testing.T) {
+	data := []User{
+		{ID: 1, Name: "Smith"},
+		{ID: 2, Name: "Smyth"},
+		{ID: 3, Name: "Jones"},
+	}
+
+	result, err := NewPipelineWithData(data).
+		FindSimilar(func(u User) string { return strings.ToLower(u.Name) }, "smith", 0.85).
+		Execute()
+	if err != nil {
+		t.Fatalf("SimilarityFindOperation failed: %v", err)
+	}
+
+	if len(result) != 2 || result[0].ID != 1 || result[1].ID != 2 {
+		t.Errorf("Expected [Smith Smyth], got %v", result)
+	}
+}
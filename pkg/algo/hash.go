@@ -0,0 +1,34 @@
+package algo
+
+import "hash/fnv"
+
+// hash64 returns a well-mixed 64-bit hash of data.
+// It is stable across processes and platforms, so sketches and filters built from it can be
+// serialized in one job and merged or queried in another.
+func hash64(data []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return mix64(h.Sum64())
+}
+
+// hashString returns hash64 of the bytes of s.
+func hashString(s string) uint64 {
+	return hash64([]byte(s))
+}
+
+// mix64 is the 64-bit finalizer of MurmurHash3. It spreads every input bit over the whole word,
+// which FNV alone does not do well for the high bits used by HyperLogLog.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// hashStep derives a second, odd hash from h for double hashing, so that h+i*hashStep(h)
+// simulates i independent hash functions.
+func hashStep(h uint64) uint64 {
+	return mix64(h^0x9e3779b97f4a7c15) | 1
+}
@@ -0,0 +1,94 @@
+package algo
+
+import "sync"
+
+// HashIndex is a prebuilt lookup table that maps a key to every element with that key.
+// It answers exact-key lookups in O(1) instead of scanning the data with LinearSearch or Find.
+// A HashIndex is safe for concurrent use.
+type HashIndex[T comparable, K comparable] struct {
+	mu      sync.RWMutex
+	key     func(T) K
+	buckets map[K][]T
+	size    int
+}
+
+// BuildHashIndex builds a hash index over data using the key function.
+// Elements with the same key are kept in their original order.
+//
+// Example:
+//
+//	index := BuildHashIndex(products, func(p Product) string { return p.SKU })
+//	result, err := NewPipelineWithData(index.Lookup("ABC123")).
+//	    Filter(func(p Product) bool { return p.InStock }).
+//	    Execute()
+func BuildHashIndex[T comparable, K comparable](data []T, key func(T) K) *HashIndex[T, K] {
+	h := &HashIndex[T, K]{key: key, buckets: make(map[K][]T)}
+	for _, item := range data {
+		k := key(item)
+		h.buckets[k] = append(h.buckets[k], item)
+	}
+	h.size = len(data)
+	return h
+}
+
+// Lookup returns the elements whose key equals key, in insertion order.
+// The returned slice is a copy, so it can be passed to a pipeline that sorts in place.
+func (h *HashIndex[T, K]) Lookup(key K) []T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	bucket := h.buckets[key]
+	result := make([]T, len(bucket))
+	copy(result, bucket)
+	return result
+}
+
+// Contains reports whether any element has the given key.
+func (h *HashIndex[T, K]) Contains(key K) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.buckets[key]) > 0
+}
+
+// Insert adds elements to the index.
+func (h *HashIndex[T, K]) Insert(items ...T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, item := range items {
+		k := h.key(item)
+		h.buckets[k] = append(h.buckets[k], item)
+	}
+	h.size += len(items)
+}
+
+// Delete removes the first element equal to item from the index.
+// Returns false if the index does not contain item.
+func (h *HashIndex[T, K]) Delete(item T) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	k := h.key(item)
+	bucket := h.buckets[k]
+	for i := range bucket {
+		if bucket[i] == item {
+			if len(bucket) == 1 {
+				delete(h.buckets, k)
+			} else {
+				h.buckets[k] = append(bucket[:i:i], bucket[i+1:]...)
+			}
+			h.size--
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of elements in the index.
+func (h *HashIndex[T, K]) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.size
+}
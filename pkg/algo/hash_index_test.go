@@ -0,0 +1,101 @@
+package algo
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func randomOrders(n int, seed int64) []Order {
+	rng := rand.New(rand.NewSource(seed))
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{OrderID: i, UserID: rng.Intn(20), Item: "Item" + strconv.Itoa(rng.Intn(50))}
+	}
+	return orders
+}
+
+func findOrders(data []Order, predicate func(Order) bool) []Order {
+	op := &FindOperation[Order]{Predicate: predicate}
+	result, _ := op.Apply(data)
+	return result
+}
+
+func TestHashIndex_MatchesFind(t *testing.T) {
+	data := randomOrders(500, 1)
+	index := BuildHashIndex(data, func(o Order) int { return o.UserID })
+
+	for userID := -1; userID <= 20; userID++ {
+		expected := findOrders(data, func(o Order) bool { return o.UserID == userID })
+		result := index.Lookup(userID)
+
+		if len(result) != len(expected) {
+			t.Fatalf("UserID %d: expected %d items, got %d", userID, len(expected), len(result))
+		}
+		for i := range expected {
+			if result[i] != expected[i] {
+				t.Errorf("UserID %d: at index %d, expected %+v, got %+v", userID, i, expected[i], result[i])
+			}
+		}
+	}
+
+	if index.Len() != len(data) {
+		t.Errorf("Expected length %d, got %d", len(data), index.Len())
+	}
+}
+
+func TestHashIndex_InsertDelete(t *testing.T) {
+	index := BuildHashIndex([]Item{
+		{ID: 1, Name: "Item1", Active: true},
+		{ID: 2, Name: "Item2", Active: false},
+	}, func(a Item) bool { return a.Active })
+
+	index.Insert(Item{ID: 3, Name: "Item3", Active: true})
+
+	expected := []Item{
+		{ID: 1, Name: "Item1", Active: true},
+		{ID: 3, Name: "Item3", Active: true},
+	}
+	if result := index.Lookup(true); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if !index.Delete(Item{ID: 1, Name: "Item1", Active: true}) {
+		t.Fatalf("Expected Delete to find the item")
+	}
+	if index.Delete(Item{ID: 1, Name: "Item1", Active: true}) {
+		t.Errorf("Expected second Delete to report false")
+	}
+	if !index.Delete(Item{ID: 2, Name: "Item2", Active: false}) || index.Contains(false) {
+		t.Errorf("Expected the false bucket to be removed")
+	}
+	if index.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", index.Len())
+	}
+}
+
+func TestHashIndex_LookupReturnsCopy(t *testing.T) {
+	index := BuildHashIndex([]int{3, 1, 2}, func(x int) int { return 0 })
+
+	_, err := NewPipelineWithData(index.Lookup(0)).
+		QuickSort(func(a, b int) bool { return a < b }).
+		Execute()
+	if err != nil {
+		t.Fatalf("Pipeline execution failed: %v", err)
+	}
+
+	if result := index.Lookup(0); !reflect.DeepEqual(result, []int{3, 1, 2}) {
+		t.Errorf("Sorting a lookup result modified the index: %v", result)
+	}
+}
+
+func BenchmarkHashIndexLookup(b *testing.B) {
+	data := randomOrders(1000000, 1)
+	index := BuildHashIndex(data, func(o Order) int { return o.OrderID })
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.Lookup(i % len(data))
+	}
+}
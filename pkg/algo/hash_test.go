@@ -0,0 +1,31 @@
+package algo
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestHash64_Stable(t *testing.T) {
+	// The encoded sketches depend on these values staying the same across releases.
+	if got := hashString(""); got != mix64(0xcbf29ce484222325) {
+		t.Errorf("Unexpected hash of empty string: %#x", got)
+	}
+	if hashString("abc") != hash64([]byte("abc")) {
+		t.Error("Expected hashString and hash64 to agree")
+	}
+	if hashString("abc") == hashString("abd") {
+		t.Error("Expected different keys to hash differently")
+	}
+}
+
+func TestHash64_HighBitsSpread(t *testing.T) {
+	buckets := make([]int, 16)
+	for i := 0; i < 16000; i++ {
+		buckets[hashString("key-"+strconv.Itoa(i))>>60]++
+	}
+	for i, n := range buckets {
+		if n < 800 || n > 1200 {
+			t.Errorf("Bucket %d holds %d keys, expected about 1000", i, n)
+		}
+	}
+}
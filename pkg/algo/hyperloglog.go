@@ -0,0 +1,126 @@
+package algo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// hyperLogLogVersion is the version byte written by HyperLogLog.MarshalBinary.
+const hyperLogLogVersion = 1
+
+// HyperLogLog estimates the number of distinct keys in a stream using a fixed amount of memory.
+// With precision p it uses 2^p one-byte registers and has a standard error of about 1.04/sqrt(2^p),
+// for example 0.81% at the default precision of 14 (16 KiB).
+// Sketches with the same precision can be merged, so shards can be counted independently.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog creates a sketch with 2^precision registers.
+// Returns an error if precision is outside the range 4 to 18.
+//
+// Example:
+//
+//	hll, err := NewHyperLogLog(14)
+//	hll.AddString("user-42")
+//	estimate := hll.Count()
+func NewHyperLogLog(precision uint8) (*HyperLogLog, error) {
+	if precision < 4 || precision > 18 {
+		return nil, fmt.Errorf("HyperLogLog: precision must be between 4 and 18, got %d", precision)
+	}
+	return &HyperLogLog{precision: precision, registers: make([]uint8, 1<<precision)}, nil
+}
+
+// Add adds a key to the sketch.
+func (h *HyperLogLog) Add(key []byte) {
+	h.addHash(hash64(key))
+}
+
+// AddString adds a key to the sketch.
+func (h *HyperLogLog) AddString(key string) {
+	h.addHash(hashString(key))
+}
+
+// addHash records a hashed key: the top bits select a register, which keeps the longest
+// run of leading zeros seen in the remaining bits.
+func (h *HyperLogLog) addHash(x uint64) {
+	index := x >> (64 - h.precision)
+	rank := uint8(bits.LeadingZeros64(x<<h.precision|1<<(h.precision-1)) + 1)
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+// Count returns the estimated number of distinct keys added.
+func (h *HyperLogLog) Count() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := hyperLogLogAlpha(len(h.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Merge combines another sketch into h, so that h estimates the distinct keys of both.
+// Returns an error if the precisions differ.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision {
+		return fmt.Errorf("HyperLogLog: cannot merge precision %d into %d", other.precision, h.precision)
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// MarshalBinary encodes the sketch so that it can be persisted or sent to another process.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+len(h.registers))
+	data = append(data, hyperLogLogVersion, h.precision)
+	return append(data, h.registers...), nil
+}
+
+// UnmarshalBinary decodes a sketch encoded by MarshalBinary, replacing the contents of h.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != hyperLogLogVersion {
+		return errors.New("HyperLogLog: unsupported encoding")
+	}
+	decoded, err := NewHyperLogLog(data[1])
+	if err != nil {
+		return err
+	}
+	if len(data)-2 != len(decoded.registers) {
+		return fmt.Errorf("HyperLogLog: expected %d registers, got %d", len(decoded.registers), len(data)-2)
+	}
+	copy(decoded.registers, data[2:])
+	*h = *decoded
+	return nil
+}
+
+// hyperLogLogAlpha returns the bias correction constant for m registers.
+func hyperLogLogAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
@@ -0,0 +1,102 @@
+package algo
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestNewHyperLogLog_InvalidPrecision(t *testing.T) {
+	for _, precision := range []uint8{0, 3, 19} {
+		if _, err := NewHyperLogLog(precision); err == nil {
+			t.Errorf("Expected error for precision %d", precision)
+		}
+	}
+}
+
+func TestHyperLogLog_Empty(t *testing.T) {
+	hll, _ := NewHyperLogLog(10)
+	if hll.Count() != 0 {
+		t.Errorf("Expected 0, got %d", hll.Count())
+	}
+}
+
+func TestHyperLogLog_SmallCardinalityIsExact(t *testing.T) {
+	hll, _ := NewHyperLogLog(14)
+	for i := 0; i < 3; i++ {
+		for _, key := range []string{"a", "b", "c", "d", "e"} {
+			hll.AddString(key)
+		}
+	}
+	if hll.Count() != 5 {
+		t.Errorf("Expected 5, got %d", hll.Count())
+	}
+}
+
+func TestHyperLogLog_Accuracy(t *testing.T) {
+	for _, n := range []int{1000, 50000, 300000} {
+		hll, _ := NewHyperLogLog(14)
+		for i := 0; i < n; i++ {
+			hll.AddString("user-" + strconv.Itoa(i))
+			hll.AddString("user-" + strconv.Itoa(i/2)) // duplicates must not be counted again
+		}
+
+		relativeError := math.Abs(float64(hll.Count())-float64(n)) / float64(n)
+		if relativeError > 0.03 {
+			t.Errorf("n=%d: estimate %d has relative error %.4f", n, hll.Count(), relativeError)
+		}
+	}
+}
+
+func TestHyperLogLog_MergeMatchesSingleSketch(t *testing.T) {
+	whole, _ := NewHyperLogLog(12)
+	shardA, _ := NewHyperLogLog(12)
+	shardB, _ := NewHyperLogLog(12)
+	for i := 0; i < 20000; i++ {
+		key := strconv.Itoa(i)
+		whole.AddString(key)
+		if i < 12000 {
+			shardA.AddString(key)
+		}
+		if i >= 8000 {
+			shardB.Add([]byte(key))
+		}
+	}
+
+	if err := shardA.Merge(shardB); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if shardA.Count() != whole.Count() {
+		t.Errorf("Expected merged count %d, got %d", whole.Count(), shardA.Count())
+	}
+
+	other, _ := NewHyperLogLog(10)
+	if err := shardA.Merge(other); err == nil {
+		t.Error("Expected error when merging different precisions")
+	}
+}
+
+func TestHyperLogLog_MarshalRoundTrip(t *testing.T) {
+	hll, _ := NewHyperLogLog(8)
+	for i := 0; i < 1000; i++ {
+		hll.AddString(strconv.Itoa(i))
+	}
+
+	data, err := hll.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded HyperLogLog
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Count() != hll.Count() {
+		t.Errorf("Expected %d, got %d", hll.Count(), decoded.Count())
+	}
+
+	for _, corrupt := range [][]byte{nil, {2, 8}, {1, 30}, data[:len(data)-1]} {
+		if err := decoded.UnmarshalBinary(corrupt); err == nil {
+			t.Errorf("Expected error for encoding %v", corrupt[:min(len(corrupt), 2)])
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package algo
+
+// InterleaveOperation merges the data with other slices in round-robin order.
+// Once a slice is exhausted the remaining slices continue to take turns.
+type InterleaveOperation[T any] struct {
+	Others [][]T
+}
+
+// Apply performs the interleave operation on the data.
+// It returns a new slice taking one element from the data and from each other slice in turn.
+//
+// Example:
+//
+//	pipeline := NewPipelineWithData([]int{1, 4, 7}).
+//	    Interleave([]int{2, 5}, []int{3})
+//	result, err := pipeline.Execute() // [1 2 3 4 5 7]
+func (i *InterleaveOperation[T]) Apply(data []T) ([]T, error) {
+	return Interleave(append([][]T{data}, i.Others...)...), nil
+}
+
+// Interleave adds an interleave operation to the pipeline.
+// Elements are taken alternately from the pipeline data and each of the other slices.
+//
+// Example:
+//
+//	pipeline.Interleave(promotedProducts, sponsoredProducts)
+func (p *Pipeline[T]) Interleave(others ...[]T) *Pipeline[T] {
+	p.operations = append(p.operations, &InterleaveOperation[T]{Others: others})
+	return p
+}
+
+// Interleave merges the inputs in round-robin order.
+// It takes the first element of every input, then the second, and so on, skipping exhausted inputs.
+//
+// Example:
+//
+//	mixed := Interleave([]string{"a1", "a2"}, []string{"b1", "b2", "b3"})
+//	// [a1 b1 a2 b2 b3]
+func Interleave[T any](inputs ...[]T) []T {
+	total, longest := 0, 0
+	for _, input := range inputs {
+		total += len(input)
+		longest = max(longest, len(input))
+	}
+
+	interleaved := make([]T, 0, total)
+	for i := 0; i < longest; i++ {
+		for _, input := range inputs {
+			if i < len(input) {
+				interleaved = append(interleaved, input[i])
+			}
+		}
+	}
+	return interleaved
+}
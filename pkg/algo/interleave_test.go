@@ -0,0 +1,32 @@
+package algo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInterleaveOperation_RoundRobin(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Interleave([]int{2, 5}, []int{3})
+
+	pipeline.WithData([]int{1, 4, 7})
+
+	interleavedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("InterleaveOperation failed: %v", err)
+	}
+
+	expected := []int{1, 2, 3, 4, 5, 7}
+	if !reflect.DeepEqual(interleavedData, expected) {
+		t.Errorf("Expected %v, got %v", expected, interleavedData)
+	}
+}
+
+func TestInterleave_EmptyInputs(t *testing.T) {
+	interleaved := Interleave([]string{}, []string{"a", "b"}, nil)
+
+	expected := []string{"a", "b"}
+	if !reflect.DeepEqual(interleaved, expected) {
+		t.Errorf("Expected %v, got %v", expected, interleaved)
+	}
+}
@@ -1,15 +1,15 @@
 package algo
 
-import "fmt"
-
 // LinearSearchOperation performs a sequential search through the data.
 // It searches for elements that match the given predicate function.
 type LinearSearchOperation[T any] struct {
-	Predicate func(T) bool
+	Predicate  func(T) bool
+	FoundIndex int
 }
 
 // Apply performs the linear search operation on the data.
 // It returns the data and an error if no matching element is found.
+// The position of the first match is recorded in FoundIndex.
 //
 // Example:
 //
@@ -17,12 +17,28 @@ type LinearSearchOperation[T any] struct {
 //	    LinearSearch(func(p Product) bool { return p.SKU == "ABC123" })
 //	result, err := pipeline.Execute()
 func (l *LinearSearchOperation[T]) Apply(data []T) ([]T, error) {
-	for _, item := range data {
+	l.FoundIndex = -1
+	for i, item := range data {
 		if l.Predicate(item) {
+			l.FoundIndex = i
 			return data, nil
 		}
 	}
-	return data, fmt.Errorf("target not found in data")
+	return data, ErrNotFound
+}
+
+// GetFoundIndex returns the index of the first match after a successful linear search.
+// Returns -1 if the element was not found or if the search has not been executed.
+func (l *LinearSearchOperation[T]) GetFoundIndex() int {
+	return l.FoundIndex
+}
+
+// GetMatchCount returns 1 if the last search found its target and 0 otherwise.
+func (l *LinearSearchOperation[T]) GetMatchCount() int {
+	if l.FoundIndex < 0 {
+		return 0
+	}
+	return 1
 }
 
 // LinearSearch adds a linear search operation to the pipeline.
@@ -34,7 +50,7 @@ func (l *LinearSearchOperation[T]) Apply(data []T) ([]T, error) {
 //	    return user.Email == "example@email.com"
 //	})
 func (p *Pipeline[T]) LinearSearch(predicate func(T) bool) *Pipeline[T] {
-	p.operations = append(p.operations, &LinearSearchOperation[T]{Predicate: predicate})
+	p.operations = append(p.operations, &LinearSearchOperation[T]{Predicate: predicate, FoundIndex: -1})
 	return p
 }
 
@@ -46,6 +62,6 @@ func (p *Pipeline[T]) LinearSearch(predicate func(T) bool) *Pipeline[T] {
 func (p *Pipeline[T]) LinearSearchExact(target T) *Pipeline[T] {
 	p.operations = append(p.operations, &LinearSearchOperation[T]{Predicate: func(item T) bool {
 		return item == target
-	}})
+	}, FoundIndex: -1})
 	return p
 }
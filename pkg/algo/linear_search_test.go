@@ -97,3 +97,26 @@ func BenchmarkLinearSearch(b *testing.B) {
 		}
 	}
 }
+
+func TestLinearSearchOperation_FoundIndex(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		LinearSearch(func(item int) bool {
+			return item > 2
+		})
+
+	pipeline.WithData([]int{1, 2, 3, 4})
+
+	_, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("Expected target to be found, but got error: %v", err)
+	}
+
+	lsOp, ok := pipeline.GetOperations()[0].(*LinearSearchOperation[int])
+	if !ok {
+		t.Fatalf("Expected operation to be LinearSearchOperation, but got %T", pipeline.GetOperations()[0])
+	}
+
+	if lsOp.GetFoundIndex() != 2 {
+		t.Errorf("Expected found index to be 2, but got %d", lsOp.GetFoundIndex())
+	}
+}
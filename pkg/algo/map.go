@@ -22,6 +22,13 @@ func (m *MapOperation[T]) Apply(data []T) ([]T, error) {
 	return mappedData, nil
 }
 
+// Stream implements Streamer, passing on the mapped value of every element.
+func (m *MapOperation[T]) Stream(emit func(T) bool) func(T) bool {
+	return func(item T) bool {
+		return emit(m.Mapper(item))
+	}
+}
+
 // Map adds a map operation to the pipeline.
 // The mapper function defines how each element should be transformed.
 //
@@ -0,0 +1,113 @@
+package algo
+
+// MergeSortedOperation merges the data with other already-sorted slices.
+// It performs a k-way merge with a min-heap in O(n log k) time, where k is the number of inputs.
+type MergeSortedOperation[T any] struct {
+	Comparator func(a, b T) bool
+	Others     [][]T
+}
+
+// Apply performs the k-way merge on the data.
+// It returns a new sorted slice containing every element of the data and of Others.
+// The data and every slice in Others must already be sorted by Comparator.
+//
+// Example:
+//
+//	pipeline := NewPipelineWithData([]int{1, 4, 7}).
+//	    MergeSorted(func(a, b int) bool { return a < b }, []int{2, 5}, []int{3, 6})
+//	result, err := pipeline.Execute() // [1 2 3 4 5 6 7]
+func (m *MergeSortedOperation[T]) Apply(data []T) ([]T, error) {
+	return MergeSorted(m.Comparator, append([][]T{data}, m.Others...)...), nil
+}
+
+// MergeSorted adds a k-way merge operation to the pipeline.
+// The comparator function should return true when a should come before b, matching
+// the order the inputs are already sorted in.
+//
+// Example:
+//
+//	pipeline.MergeSorted(func(a, b Event) bool {
+//	    return a.Timestamp.Before(b.Timestamp)
+//	}, shardB, shardC)
+func (p *Pipeline[T]) MergeSorted(comparator func(a, b T) bool, others ...[]T) *Pipeline[T] {
+	p.operations = append(p.operations, &MergeSortedOperation[T]{Comparator: comparator, Others: others})
+	return p
+}
+
+// MergeSorted merges inputs that are each sorted by the comparator into a single sorted slice.
+// The merge is stable: equal elements keep their input order, and elements from earlier
+// inputs come before equal elements from later inputs.
+//
+// Example:
+//
+//	merged := MergeSorted(func(a, b int) bool { return a < b }, shardA, shardB, shardC)
+func MergeSorted[T any](comparator func(a, b T) bool, inputs ...[]T) []T {
+	total := 0
+	heap := make([]mergeCursor, 0, len(inputs))
+	for i, input := range inputs {
+		total += len(input)
+		if len(input) > 0 {
+			heap = append(heap, mergeCursor{input: i})
+		}
+	}
+
+	less := func(a, b mergeCursor) bool {
+		x, y := inputs[a.input][a.pos], inputs[b.input][b.pos]
+		if comparator(x, y) {
+			return true
+		}
+		if comparator(y, x) {
+			return false
+		}
+		return a.input < b.input
+	}
+
+	for i := len(heap)/2 - 1; i >= 0; i-- {
+		minHeapify(heap, i, less)
+	}
+
+	merged := make([]T, 0, total)
+	for len(heap) > 0 {
+		top := heap[0]
+		merged = append(merged, inputs[top.input][top.pos])
+		if top.pos+1 < len(inputs[top.input]) {
+			heap[0].pos++
+		} else {
+			heap[0] = heap[len(heap)-1]
+			heap = heap[:len(heap)-1]
+		}
+		minHeapify(heap, 0, less)
+	}
+	return merged
+}
+
+// mergeCursor tracks the next unread position of one input during a k-way merge.
+type mergeCursor struct {
+	input int
+	pos   int
+}
+
+// minHeapify is a helper function that maintains the min heap property.
+func minHeapify[T any](heap []T, i int, less func(a, b T) bool) {
+	n := len(heap)
+	for {
+		smallest := i
+		left := 2*i + 1
+		right := 2*i + 2
+
+		if left < n && less(heap[left], heap[smallest]) {
+			smallest = left
+		}
+
+		if right < n && less(heap[right], heap[smallest]) {
+			smallest = right
+		}
+
+		if smallest == i {
+			break
+		}
+
+		heap[i], heap[smallest] = heap[smallest], heap[i]
+		i = smallest
+	}
+}
@@ -0,0 +1,83 @@
+package algo
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestMergeSortedOperation_ThreeShards(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		MergeSorted(func(a, b int) bool { return a < b }, []int{2, 5, 8}, []int{3, 6})
+
+	pipeline.WithData([]int{1, 4, 7, 9})
+
+	mergedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("MergeSortedOperation failed: %v", err)
+	}
+
+	expected := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(mergedData, expected) {
+		t.Errorf("Expected %v, got %v", expected, mergedData)
+	}
+}
+
+func TestMergeSorted_Stable(t *testing.T) {
+	first := []Item{
+		{ID: 1, Name: "first-1"},
+		{ID: 2, Name: "first-2"},
+	}
+	second := []Item{
+		{ID: 1, Name: "second-1"},
+		{ID: 2, Name: "second-2"},
+	}
+
+	merged := MergeSorted(func(a, b Item) bool { return a.ID < b.ID }, first, second)
+
+	expected := []Item{
+		{ID: 1, Name: "first-1"},
+		{ID: 1, Name: "second-1"},
+		{ID: 2, Name: "first-2"},
+		{ID: 2, Name: "second-2"},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Expected %v, got %v", expected, merged)
+	}
+}
+
+func TestMergeSorted_MatchesSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var shards [][]int
+	var all []int
+	for i := 0; i < 8; i++ {
+		shard := make([]int, rng.Intn(50))
+		for j := range shard {
+			shard[j] = rng.Intn(100)
+		}
+		sort.Ints(shard)
+		shards = append(shards, shard)
+		all = append(all, shard...)
+	}
+	sort.Ints(all)
+
+	merged := MergeSorted(func(a, b int) bool { return a < b }, shards...)
+
+	if len(merged) != len(all) {
+		t.Fatalf("Expected %d items, got %d", len(all), len(merged))
+	}
+	for i := range all {
+		if merged[i] != all[i] {
+			t.Fatalf("At index %d, expected %d, got %d", i, all[i], merged[i])
+		}
+	}
+}
+
+func TestMergeSorted_NoInputs(t *testing.T) {
+	merged := MergeSorted(func(a, b int) bool { return a < b })
+
+	if len(merged) != 0 {
+		t.Errorf("Expected no items, but got %v", merged)
+	}
+}
@@ -0,0 +1,37 @@
+package algo
+
+// Partition splits data into the elements that satisfy the predicate and those that do not.
+// Both results preserve the original order and are produced in a single pass.
+//
+// Example:
+//
+//	adults, minors := Partition(users, func(u User) bool { return u.Age >= 18 })
+func Partition[T any](data []T, predicate func(T) bool) (matched, unmatched []T) {
+	matched = make([]T, 0, len(data))
+	unmatched = make([]T, 0, len(data))
+	for _, item := range data {
+		if predicate(item) {
+			matched = append(matched, item)
+		} else {
+			unmatched = append(unmatched, item)
+		}
+	}
+	return matched, unmatched
+}
+
+// Partition executes the pipeline and splits its result by the predicate.
+// It returns the matching and non-matching elements, or an error if any operation fails.
+//
+// Example:
+//
+//	valid, invalid, err := NewPipelineWithData(orders).
+//	    Map(normalizeOrder).
+//	    Partition(func(o Order) bool { return o.Validate() == nil })
+func (p *Pipeline[T]) Partition(predicate func(T) bool) (matched, unmatched []T, err error) {
+	data, err := p.Execute()
+	if err != nil {
+		return nil, nil, err
+	}
+	matched, unmatched = Partition(data, predicate)
+	return matched, unmatched, nil
+}
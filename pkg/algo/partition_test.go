@@ -0,0 +1,63 @@
+package algo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPartition_SplitsInOnePass(t *testing.T) {
+	data := []Item{
+		{ID: 1, Name: "Item1", Active: true},
+		{ID: 2, Name: "Item2", Active: false},
+		{ID: 3, Name: "Item3", Active: true},
+	}
+
+	calls := 0
+	matched, unmatched := Partition(data, func(a Item) bool {
+		calls++
+		return a.Active
+	})
+
+	expectedMatched := []Item{
+		{ID: 1, Name: "Item1", Active: true},
+		{ID: 3, Name: "Item3", Active: true},
+	}
+	expectedUnmatched := []Item{
+		{ID: 2, Name: "Item2", Active: false},
+	}
+
+	if !reflect.DeepEqual(matched, expectedMatched) {
+		t.Errorf("Expected matched %v, got %v", expectedMatched, matched)
+	}
+	if !reflect.DeepEqual(unmatched, expectedUnmatched) {
+		t.Errorf("Expected unmatched %v, got %v", expectedUnmatched, unmatched)
+	}
+	if calls != len(data) {
+		t.Errorf("Expected predicate to be called %d times, got %d", len(data), calls)
+	}
+}
+
+func TestPipeline_Partition(t *testing.T) {
+	matched, unmatched, err := NewPipelineWithData([]int{5, 1, 4, 2, 3}).
+		QuickSort(func(a, b int) bool { return a < b }).
+		Partition(func(x int) bool { return x%2 == 0 })
+	if err != nil {
+		t.Fatalf("Partition failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(matched, []int{2, 4}) {
+		t.Errorf("Expected matched [2 4], got %v", matched)
+	}
+	if !reflect.DeepEqual(unmatched, []int{1, 3, 5}) {
+		t.Errorf("Expected unmatched [1 3 5], got %v", unmatched)
+	}
+}
+
+func TestPipeline_PartitionError(t *testing.T) {
+	_, _, err := NewPipelineWithData([]int{}).
+		Reduce(func(acc, item int) int { return acc + item }).
+		Partition(func(x int) bool { return x > 0 })
+	if err == nil {
+		t.Fatalf("Expected error from failing pipeline, but got nil")
+	}
+}
@@ -11,6 +11,11 @@
 //	result, err := pipeline.Execute()
 package algo
 
+import "errors"
+
+// ErrNotFound is returned by search operations and terminals when no element matches.
+var ErrNotFound = errors.New("target not found in data")
+
 // Operation defines the interface for all pipeline operations.
 // Each operation implements Apply to transform or process the data.
 type Operation[T comparable] interface {
@@ -85,6 +90,19 @@ func (p *Pipeline[T]) Execute() ([]T, error) {
 	return p.data, nil
 }
 
+// run applies the pipeline's operations to data without replacing the pipeline's own data.
+// It lets a pipeline be reused as a template for several inputs.
+func (p *Pipeline[T]) run(data []T) ([]T, error) {
+	var err error
+	for _, op := range p.operations {
+		data, err = op.Apply(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
 // GetOperations returns the slice of operations in the pipeline.
 func (p *Pipeline[T]) GetOperations() []Operation[T] {
 	return p.operations
@@ -0,0 +1,67 @@
+package algo
+
+import "strings"
+
+// PrefixSearchOperation selects the elements whose key starts with a prefix, ranked as Trie.Search
+// ranks them so that the shortest completions come first.
+// When Trie is set the operation searches it instead of the data, so a dataset that is queried
+// repeatedly is indexed only once.
+type PrefixSearchOperation[T any] struct {
+	Key    func(T) string
+	Prefix string
+	Limit  int
+	Trie   *Trie[T]
+}
+
+// Apply performs the prefix search on the data.
+// It returns at most Limit matching elements (all of them if Limit is zero or less), ordered by key
+// length, then alphabetically by key. Elements with equal keys keep their original order.
+//
+// Example:
+//
+//	pipeline := NewPipeline[string]().
+//	    PrefixSearch(func(s string) string { return s }, "ap", 0)
+//	result, err := pipeline.Execute() // ["ape" "apple" "apricot"]
+func (ps *PrefixSearchOperation[T]) Apply(data []T) ([]T, error) {
+	if ps.Trie != nil {
+		return ps.Trie.Values(ps.Prefix, ps.Limit), nil
+	}
+
+	// Only the matches are indexed, so a one-off search costs a single scan of the data.
+	trie := NewTrie[T]()
+	for _, item := range data {
+		if key := ps.Key(item); strings.HasPrefix(key, ps.Prefix) {
+			trie.insert(key, item, 0)
+		}
+	}
+	return trie.Values(ps.Prefix, ps.Limit), nil
+}
+
+// PrefixSearch adds a prefix search operation to the pipeline that keeps at most limit matches.
+// A limit of zero or less keeps every match. For repeated queries over the same dataset, build a
+// Trie once and use PrefixSearchIn.
+//
+// Example:
+//
+//	pipeline.PrefixSearch(func(p Product) string {
+//	    return strings.ToLower(p.Name)
+//	}, "lap", 10)
+func (p *Pipeline[T]) PrefixSearch(key func(T) string, prefix string, limit int) *Pipeline[T] {
+	p.operations = append(p.operations, &PrefixSearchOperation[T]{Key: key, Prefix: prefix, Limit: limit})
+	return p
+}
+
+// PrefixSearchIn adds a prefix search of a prebuilt trie to the pipeline. The stage replaces the
+// data with at most limit ranked matches from the trie, so it usually starts the pipeline.
+//
+// Example:
+//
+//	trie := BuildTrie(products, func(p Product) string { return strings.ToLower(p.Name) })
+//	result, err := NewPipeline[Product]().
+//	    PrefixSearchIn(trie, "lap", 20).
+//	    Filter(func(p Product) bool { return p.InStock }).
+//	    Execute()
+func (p *Pipeline[T]) PrefixSearchIn(trie *Trie[T], prefix string, limit int) *Pipeline[T] {
+	p.operations = append(p.operations, &PrefixSearchOperation[T]{Prefix: prefix, Limit: limit, Trie: trie})
+	return p
+}
@@ -0,0 +1,93 @@
+package algo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrefixSearchOperation_Ranked(t *testing.T) {
+	pipeline := NewPipeline[string]().
+		PrefixSearch(func(s string) string { return s }, "ap", 0)
+
+	pipeline.WithData([]string{"apricot", "banana", "apple", "ape"})
+
+	result, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("PrefixSearchOperation failed: %v", err)
+	}
+
+	expected := []string{"ape", "apple", "apricot"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestPrefixSearchOperation_StructKeyWithTake(t *testing.T) {
+	data := []User{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "Al"},
+		{ID: 4, Name: "Alfred"},
+	}
+
+	result, err := NewPipelineWithData(data).
+		PrefixSearch(func(u User) string { return u.Name }, "Al", 0).
+		Take(2).
+		Execute()
+	if err != nil {
+		t.Fatalf("PrefixSearchOperation failed: %v", err)
+	}
+
+	expected := []User{
+		{ID: 3, Name: "Al"},
+		{ID: 1, Name: "Alice"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestPrefixSearchOperation_NoMatch(t *testing.T) {
+	result, err := NewPipelineWithData([]string{"apple"}).
+		PrefixSearch(func(s string) string { return s }, "b", 0).
+		Execute()
+	if err != nil {
+		t.Fatalf("PrefixSearchOperation failed: %v", err)
+	}
+
+	if len(result) != 0 {
+		t.Errorf("Expected no items, but got %v", result)
+	}
+}
+
+func TestPrefixSearchOperation_Limit(t *testing.T) {
+	result, err := NewPipelineWithData([]string{"apricot", "banana", "apple", "ape"}).
+		PrefixSearch(func(s string) string { return s }, "ap", 2).
+		Execute()
+	if err != nil {
+		t.Fatalf("PrefixSearchOperation failed: %v", err)
+	}
+
+	expected := []string{"ape", "apple"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestPrefixSearchIn(t *testing.T) {
+	trie := BuildTrie([]string{"apricot", "banana", "apple", "ape"}, func(s string) string { return s })
+
+	for _, prefix := range []string{"ap", "b"} {
+		result, err := NewPipeline[string]().
+			PrefixSearchIn(trie, prefix, 0).
+			Execute()
+		if err != nil {
+			t.Fatalf("PrefixSearchOperation failed: %v", err)
+		}
+
+		expected := trie.Values(prefix, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Prefix %q: expected %v, got %v", prefix, expected, result)
+		}
+	}
+}
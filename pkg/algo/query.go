@@ -0,0 +1,666 @@
+package algo
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/expr"
+)
+
+// QueryResult is the outcome of Query.
+// A SELECT * query returns the selected elements in Items; a query that selects columns or
+// aggregates returns Columns and one slice of values per row in Rows. Values are bool,
+// int64, float64, string, time.Time, or nil for the aggregates of an empty group.
+// An EXPLAIN query only sets Plan.
+type QueryResult[T any] struct {
+	Items   []T
+	Columns []string
+	Rows    [][]any
+	Plan    string
+}
+
+// PreparedQuery is a query compiled for elements of type T. It can be run on any number of inputs.
+type PreparedQuery[T comparable] struct {
+	explain bool
+	columns []string
+	// before runs on the input; grouping, if set, turns its output into one row per group,
+	// and after runs on those rows.
+	before   *queryPlan[T]
+	grouping *queryGrouping[T]
+	after    *queryPlan[T]
+}
+
+// queryRow is an element passing through a query. Values holds the selected columns once projected.
+type queryRow[T any] struct {
+	item   T
+	values []any
+}
+
+// queryPlan is a pipeline over query rows together with the clause each operation comes from.
+type queryPlan[T comparable] struct {
+	pipeline *Pipeline[*queryRow[T]]
+	clauses  []string
+}
+
+// add appends an operation compiled from a clause.
+func (p *queryPlan[T]) add(op Operation[*queryRow[T]], clause string) {
+	p.pipeline.AddOperation(op)
+	p.clauses = append(p.clauses, clause)
+}
+
+// queryGrouping groups rows by keys and computes the selected columns of each group.
+type queryGrouping[T comparable] struct {
+	keys    []*expr.Expr[T]
+	columns []func(group []*queryRow[T]) any
+	clause  string
+}
+
+// Query runs a SQL-like query over data. It supports
+//
+//	[EXPLAIN] SELECT [DISTINCT] * | expr [AS name], ... [FROM name]
+//	    [WHERE expr] [GROUP BY expr, ...] [ORDER BY expr [ASC|DESC], ...] [LIMIT n] [OFFSET n]
+//
+// with expressions of the expr package and the aggregates COUNT(*), COUNT, SUM, AVG, MIN and MAX.
+// The query is compiled onto Filter, MergeSort, Distinct, Skip, Take, Map and GroupBy;
+// EXPLAIN returns that plan instead of running it. Sorting is stable, and groups without
+// ORDER BY are returned in the order of their keys. Errors are *expr.Error values that
+// give the column in the query.
+//
+// Example:
+//
+//	result, err := Query(orders, "SELECT * WHERE status = 'completed' ORDER BY amount DESC LIMIT 10 OFFSET 20")
+//	top := result.Items
+//
+//	result, err = Query(orders, "SELECT region, COUNT(*) AS orders, SUM(amount) AS revenue GROUP BY region")
+//	for _, row := range result.Rows {
+//	    fmt.Println(row[0], row[1], row[2])
+//	}
+func Query[T comparable](data []T, query string) (*QueryResult[T], error) {
+	q, err := PrepareQuery[T](query)
+	if err != nil {
+		return nil, err
+	}
+	if q.explain {
+		return &QueryResult[T]{Plan: q.Explain()}, nil
+	}
+	return q.Run(data)
+}
+
+// Explain returns the plan of a query without running it: one line per operation with the
+// clause it was compiled from.
+//
+// Example:
+//
+//	plan, err := Explain[Order]("SELECT * WHERE amount > 100 ORDER BY amount DESC LIMIT 5")
+//	fmt.Print(plan)
+//	// FilterOperation     WHERE amount > 100
+//	// MergeSortOperation  ORDER BY amount DESC
+//	// TakeOperation       LIMIT 5
+func Explain[T comparable](query string) (string, error) {
+	q, err := PrepareQuery[T](query)
+	if err != nil {
+		return "", err
+	}
+	return q.Explain(), nil
+}
+
+// PrepareQuery parses a query and compiles it for elements of type T.
+// See Query for the supported syntax.
+//
+// Example:
+//
+//	q, err := PrepareQuery[Order]("SELECT * WHERE customer.vip ORDER BY placed DESC")
+//	today, err := q.Run(todaysOrders)
+func PrepareQuery[T comparable](query string) (*PreparedQuery[T], error) {
+	parsed, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	c := &queryCompiler[T]{query: query, parsed: parsed}
+	return c.compile()
+}
+
+// Explain returns the plan of the query: one line per operation with the clause it was compiled from.
+func (q *PreparedQuery[T]) Explain() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	write := func(plan *queryPlan[T]) {
+		for i, op := range plan.pipeline.operations {
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", operationName(op), plan.clauses[i])
+		}
+	}
+	write(q.before)
+	if q.grouping != nil {
+		_, _ = fmt.Fprintf(w, "GroupBy\t%s\n", q.grouping.clause)
+		write(q.after)
+	}
+	_ = w.Flush()
+	return b.String()
+}
+
+// Run runs the query over data.
+func (q *PreparedQuery[T]) Run(data []T) (*QueryResult[T], error) {
+	rows := make([]*queryRow[T], len(data))
+	backing := make([]queryRow[T], len(data))
+	for i := range data {
+		backing[i].item = data[i]
+		rows[i] = &backing[i]
+	}
+
+	rows, err := q.before.pipeline.run(rows)
+	if err != nil {
+		return nil, err
+	}
+	if q.grouping != nil {
+		if rows, err = q.after.pipeline.run(q.grouping.apply(rows)); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &QueryResult[T]{Columns: q.columns}
+	if q.columns == nil {
+		result.Items = make([]T, len(rows))
+		for i, row := range rows {
+			result.Items[i] = row.item
+		}
+		return result, nil
+	}
+	result.Rows = make([][]any, len(rows))
+	for i, row := range rows {
+		result.Rows[i] = row.values
+	}
+	return result, nil
+}
+
+// apply groups rows with GroupBy and returns one row per group, ordered by the group keys.
+func (g *queryGrouping[T]) apply(rows []*queryRow[T]) []*queryRow[T] {
+	if len(g.keys) == 0 {
+		return []*queryRow[T]{g.row(rows)}
+	}
+
+	groups := GroupBy(rows, func(row *queryRow[T]) any {
+		var k any
+		for i, key := range g.keys {
+			value := key.Eval(row.item)
+			switch v := value.(type) {
+			case time.Time:
+				// Equal instants in different locations share a group, as they do in GroupByField.
+				value = v.UTC()
+			case float64:
+				if math.IsNaN(v) {
+					// NaN never equals itself as a map key, so NaN values share a placeholder.
+					value = nanKey{}
+				}
+			}
+			if i == 0 {
+				k = value
+				continue
+			}
+			k = [2]any{k, value}
+		}
+		return k
+	})
+
+	type keyedGroup struct {
+		keys  []any
+		items []*queryRow[T]
+	}
+	keyed := make([]keyedGroup, len(groups))
+	for i, group := range groups {
+		keyed[i].items = group.Items
+		for _, key := range g.keys {
+			keyed[i].keys = append(keyed[i].keys, key.Eval(group.Items[0].item))
+		}
+	}
+	sort.Slice(keyed, func(a, b int) bool { return compareRowValues(keyed[a].keys, keyed[b].keys) < 0 })
+
+	grouped := make([]*queryRow[T], len(keyed))
+	for i, group := range keyed {
+		grouped[i] = g.row(group.items)
+	}
+	return grouped
+}
+
+// nanKey stands in for NaN in group keys.
+type nanKey struct{}
+
+// row computes the selected columns of a group.
+func (g *queryGrouping[T]) row(group []*queryRow[T]) *queryRow[T] {
+	values := make([]any, len(g.columns))
+	for i, column := range g.columns {
+		values[i] = column(group)
+	}
+	return &queryRow[T]{values: values}
+}
+
+// compareQueryValues orders two column values. Nil values come first.
+func compareQueryValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	switch x := a.(type) {
+	case bool:
+		y := b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case y:
+			return -1
+		}
+		return 1
+	case int64:
+		if y, ok := b.(float64); ok {
+			return cmp.Compare(float64(x), y)
+		}
+		return cmp.Compare(x, b.(int64))
+	case float64:
+		if y, ok := b.(int64); ok {
+			return cmp.Compare(x, float64(y))
+		}
+		return cmp.Compare(x, b.(float64))
+	case string:
+		return strings.Compare(x, b.(string))
+	case time.Time:
+		return x.Compare(b.(time.Time))
+	}
+	return 0
+}
+
+// compareRowValues orders two rows of values column by column.
+func compareRowValues(a, b []any) int {
+	for i := range a {
+		if c := compareQueryValues(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// queryCompiler compiles a parsed query.
+type queryCompiler[T comparable] struct {
+	query  string
+	parsed *parsedQuery
+}
+
+// compile builds the plan of the query:
+//
+//	plain:     Filter, [MergeSort, Distinct], MergeSort, Skip, Take, Map
+//	distinct:  Filter, Map, MergeSort, Distinct, MergeSort, Skip, Take
+//	grouped:   Filter, GroupBy, [MergeSort, Distinct], MergeSort, Skip, Take
+//
+// DISTINCT over columns first sorts by the selected columns so that duplicates are adjacent for Distinct;
+// SELECT DISTINCT * compares whole elements with == instead.
+func (c *queryCompiler[T]) compile() (*PreparedQuery[T], error) {
+	parsed := c.parsed
+	q := &PreparedQuery[T]{explain: parsed.explain, before: newQueryPlan[T]()}
+
+	if parsed.where != nil {
+		filter, err := expr.CompileFilter[T](parsed.where.text)
+		if err != nil {
+			return nil, inQuery(c.query, *parsed.where, err)
+		}
+		q.before.add(&FilterOperation[*queryRow[T]]{Predicate: func(r *queryRow[T]) bool { return filter(r.item) }},
+			"WHERE "+parsed.where.text)
+	}
+
+	grouped := len(parsed.groupBy) > 0
+	for _, item := range parsed.items {
+		grouped = grouped || item.aggregate != ""
+	}
+	if grouped {
+		if err := c.compileGrouped(q); err != nil {
+			return nil, err
+		}
+		return q, nil
+	}
+
+	plan := q.before
+	if parsed.items != nil {
+		project, err := c.projection()
+		if err != nil {
+			return nil, err
+		}
+		q.columns = c.columnNames()
+		if !parsed.distinct {
+			// Without DISTINCT, project last so that only the returned rows are projected.
+			if err := c.addOrder(plan, nil); err != nil {
+				return nil, err
+			}
+			c.addPaging(plan)
+			plan.add(&MapOperation[*queryRow[T]]{Mapper: project}, "SELECT "+c.selectText())
+			return q, nil
+		}
+		plan.add(&MapOperation[*queryRow[T]]{Mapper: project}, "SELECT "+c.selectText())
+		c.addDistinctColumns(plan)
+		if err := c.addOrder(plan, c.resolveColumn); err != nil {
+			return nil, err
+		}
+		c.addPaging(plan)
+		return q, nil
+	}
+
+	if parsed.distinct {
+		c.addDistinctItems(plan)
+	}
+	if err := c.addOrder(plan, nil); err != nil {
+		return nil, err
+	}
+	c.addPaging(plan)
+	return q, nil
+}
+
+// newQueryPlan creates an empty plan.
+func newQueryPlan[T comparable]() *queryPlan[T] {
+	return &queryPlan[T]{pipeline: NewPipeline[*queryRow[T]]()}
+}
+
+// compileGrouped compiles GROUP BY and aggregates, and the clauses that apply to the groups.
+func (c *queryCompiler[T]) compileGrouped(q *PreparedQuery[T]) error {
+	parsed := c.parsed
+	if parsed.items == nil {
+		return queryError(c.query, 0, "SELECT * cannot be used with GROUP BY or aggregates")
+	}
+
+	grouping := &queryGrouping[T]{}
+	keyTexts := make([]string, 0, len(parsed.groupBy))
+	for _, segment := range parsed.groupBy {
+		// GROUP BY may name a column by its alias.
+		source := segment
+		for _, item := range parsed.items {
+			if item.alias != "" && item.aggregate == "" && strings.EqualFold(item.alias, segment.text) {
+				source = item.querySegment
+			}
+		}
+		key, err := expr.Compile[T](source.text)
+		if err != nil {
+			return inQuery(c.query, source, err)
+		}
+		grouping.keys = append(grouping.keys, key)
+		keyTexts = append(keyTexts, normalizeQueryText(source.text))
+	}
+
+	for _, item := range parsed.items {
+		column, err := c.groupColumn(item, grouping.keys, keyTexts)
+		if err != nil {
+			return err
+		}
+		grouping.columns = append(grouping.columns, column)
+	}
+
+	grouping.clause = "SELECT " + c.selectText()
+	if len(parsed.groupBy) > 0 {
+		texts := make([]string, len(parsed.groupBy))
+		for i, segment := range parsed.groupBy {
+			texts[i] = segment.text
+		}
+		grouping.clause = "GROUP BY " + strings.Join(texts, ", ") + "; " + grouping.clause
+	}
+
+	q.columns = c.columnNames()
+	q.grouping = grouping
+	q.after = newQueryPlan[T]()
+	if parsed.distinct {
+		c.addDistinctColumns(q.after)
+	}
+	if err := c.addOrder(q.after, c.resolveColumn); err != nil {
+		return err
+	}
+	c.addPaging(q.after)
+	return nil
+}
+
+// groupColumn compiles a selected column of a grouped query: either a GROUP BY key or an aggregate.
+func (c *queryCompiler[T]) groupColumn(
+	item selectItem, keys []*expr.Expr[T], keyTexts []string,
+) (func([]*queryRow[T]) any, error) {
+	if item.aggregate == "" {
+		for i, text := range keyTexts {
+			if text == normalizeQueryText(item.text) {
+				key := keys[i]
+				return func(group []*queryRow[T]) any {
+					if len(group) == 0 {
+						return nil
+					}
+					return key.Eval(group[0].item)
+				}, nil
+			}
+		}
+		return nil, queryError(c.query, item.pos, "%s must appear in GROUP BY or be used in an aggregate", item.text)
+	}
+
+	if item.arg.text == "*" {
+		return func(group []*queryRow[T]) any { return int64(len(group)) }, nil
+	}
+	value, err := expr.Compile[T](item.arg.text)
+	if err != nil {
+		return nil, inQuery(c.query, item.arg, err)
+	}
+
+	switch item.aggregate {
+	case "COUNT":
+		return func(group []*queryRow[T]) any { return int64(len(group)) }, nil
+	case "SUM", "AVG":
+		if value.Type() != expr.Int && value.Type() != expr.Float {
+			return nil, queryError(c.query, item.arg.pos, "%s expects a number, got %s", item.aggregate, value.Type())
+		}
+		number := func(row *queryRow[T]) float64 {
+			if n, ok := value.Eval(row.item).(int64); ok {
+				return float64(n)
+			}
+			return value.Eval(row.item).(float64)
+		}
+		if item.aggregate == "AVG" {
+			return func(group []*queryRow[T]) any {
+				mean, err := MeanBy(group, number)
+				if errors.Is(err, ErrEmptyInput) {
+					return nil
+				}
+				return mean
+			}, nil
+		}
+		if value.Type() == expr.Int {
+			return func(group []*queryRow[T]) any {
+				return SumBy(group, func(row *queryRow[T]) int64 { return value.Eval(row.item).(int64) })
+			}, nil
+		}
+		return func(group []*queryRow[T]) any { return SumBy(group, number) }, nil
+	}
+
+	if value.Type() == expr.Bool {
+		return nil, queryError(c.query, item.arg.pos, "%s expects an ordered value, got bool", item.aggregate)
+	}
+	better := -1
+	if item.aggregate == "MAX" {
+		better = 1
+	}
+	return func(group []*queryRow[T]) any {
+		var best any
+		for _, row := range group {
+			v := value.Eval(row.item)
+			if best == nil || compareQueryValues(v, best) == better {
+				best = v
+			}
+		}
+		return best
+	}, nil
+}
+
+// normalizeQueryText removes spaces and case from an expression so that "SUM( amount )"
+// matches "sum(amount)".
+func normalizeQueryText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), ""))
+}
+
+// projection compiles the select list of a query without aggregates.
+func (c *queryCompiler[T]) projection() (func(*queryRow[T]) *queryRow[T], error) {
+	exprs := make([]*expr.Expr[T], len(c.parsed.items))
+	for i, item := range c.parsed.items {
+		e, err := expr.Compile[T](item.text)
+		if err != nil {
+			return nil, inQuery(c.query, item.querySegment, err)
+		}
+		exprs[i] = e
+	}
+	return func(row *queryRow[T]) *queryRow[T] {
+		row.values = make([]any, len(exprs))
+		for i, e := range exprs {
+			row.values[i] = e.Eval(row.item)
+		}
+		return row
+	}, nil
+}
+
+// columnNames returns the alias or the text of every selected column.
+func (c *queryCompiler[T]) columnNames() []string {
+	names := make([]string, len(c.parsed.items))
+	for i, item := range c.parsed.items {
+		names[i] = item.text
+		if item.alias != "" {
+			names[i] = item.alias
+		}
+	}
+	return names
+}
+
+// selectText returns the select list as written.
+func (c *queryCompiler[T]) selectText() string {
+	texts := make([]string, len(c.parsed.items))
+	for i, item := range c.parsed.items {
+		texts[i] = item.text
+		if item.alias != "" {
+			texts[i] += " AS " + item.alias
+		}
+	}
+	return strings.Join(texts, ", ")
+}
+
+// resolveColumn finds the selected column an ORDER BY entry refers to, by alias or by text.
+func (c *queryCompiler[T]) resolveColumn(text string) int {
+	for i, item := range c.parsed.items {
+		if strings.EqualFold(item.alias, text) || normalizeQueryText(item.text) == normalizeQueryText(text) {
+			return i
+		}
+	}
+	return -1
+}
+
+// addOrder adds a stable MergeSort for ORDER BY. When resolve is set, entries must name a
+// selected column and are compared by the column values; otherwise they are expressions over
+// the elements, where an alias stands for its column's expression.
+func (c *queryCompiler[T]) addOrder(plan *queryPlan[T], resolve func(string) int) error {
+	if len(c.parsed.orderBy) == 0 {
+		return nil
+	}
+
+	keys := make([]func(a, b *queryRow[T]) int, 0, len(c.parsed.orderBy))
+	texts := make([]string, 0, len(c.parsed.orderBy))
+	for _, entry := range c.parsed.orderBy {
+		var compare func(a, b *queryRow[T]) int
+		if resolve != nil {
+			column := resolve(entry.text)
+			if column < 0 {
+				return queryError(c.query, entry.pos, "ORDER BY %s must name a selected column", entry.text)
+			}
+			compare = func(a, b *queryRow[T]) int { return compareQueryValues(a.values[column], b.values[column]) }
+		} else {
+			source := entry.querySegment
+			for _, item := range c.parsed.items {
+				if item.alias != "" && strings.EqualFold(item.alias, entry.text) {
+					source = item.querySegment
+				}
+			}
+			less, err := expr.CompileLess[T](source.text)
+			if err != nil {
+				return inQuery(c.query, source, err)
+			}
+			compare = func(a, b *queryRow[T]) int {
+				switch {
+				case less(a.item, b.item):
+					return -1
+				case less(b.item, a.item):
+					return 1
+				}
+				return 0
+			}
+		}
+		if entry.descending {
+			ascending := compare
+			compare = func(a, b *queryRow[T]) int { return -ascending(a, b) }
+		}
+		keys = append(keys, compare)
+
+		text := entry.text
+		if entry.descending {
+			text += " DESC"
+		}
+		texts = append(texts, text)
+	}
+
+	plan.add(&MergeSortOperation[*queryRow[T]]{Comparator: stableComparator(keys)}, "ORDER BY "+strings.Join(texts, ", "))
+	return nil
+}
+
+// stableComparator combines comparison keys into a MergeSort comparator that keeps equal rows
+// in their input order.
+func stableComparator[T any](keys []func(a, b T) int) func(a, b T) bool {
+	return func(a, b T) bool {
+		for _, key := range keys {
+			if c := key(a, b); c != 0 {
+				return c < 0
+			}
+		}
+		return true
+	}
+}
+
+// addDistinctColumns adds DISTINCT over the selected columns.
+func (c *queryCompiler[T]) addDistinctColumns(plan *queryPlan[T]) {
+	byValues := func(a, b *queryRow[T]) int { return compareRowValues(a.values, b.values) }
+	plan.add(&MergeSortOperation[*queryRow[T]]{Comparator: stableComparator([]func(a, b *queryRow[T]) int{byValues})},
+		"DISTINCT (sort by selected columns)")
+	plan.add(&DistinctOperation[*queryRow[T]]{Equal: func(a, b *queryRow[T]) bool { return byValues(a, b) == 0 }},
+		"DISTINCT")
+}
+
+// addDistinctItems adds DISTINCT over whole elements for SELECT DISTINCT *.
+func (c *queryCompiler[T]) addDistinctItems(plan *queryPlan[T]) {
+	plan.add(&distinctItemsOperation[T]{}, "DISTINCT")
+}
+
+// distinctItemsOperation keeps the first row of each distinct element, comparing elements with ==.
+type distinctItemsOperation[T comparable] struct{}
+
+// Apply removes the rows whose element was already seen, keeping the input order.
+func (d *distinctItemsOperation[T]) Apply(data []*queryRow[T]) ([]*queryRow[T], error) {
+	seen := make(map[T]struct{}, len(data))
+	distinctData := make([]*queryRow[T], 0, len(data))
+	for _, row := range data {
+		if _, ok := seen[row.item]; ok {
+			continue
+		}
+		seen[row.item] = struct{}{}
+		distinctData = append(distinctData, row)
+	}
+	return distinctData, nil
+}
+
+// addPaging adds OFFSET and LIMIT.
+func (c *queryCompiler[T]) addPaging(plan *queryPlan[T]) {
+	if c.parsed.offset >= 0 {
+		plan.add(&SkipOperation[*queryRow[T]]{Count: c.parsed.offset}, fmt.Sprintf("OFFSET %d", c.parsed.offset))
+	}
+	if c.parsed.limit >= 0 {
+		plan.add(&TakeOperation[*queryRow[T]]{Count: c.parsed.limit}, fmt.Sprintf("LIMIT %d", c.parsed.limit))
+	}
+}
@@ -0,0 +1,324 @@
+package algo
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/expr"
+)
+
+// querySegment is a piece of a query with the byte offset where it starts, so that errors in
+// expressions can be reported at their column in the whole query.
+type querySegment struct {
+	text string
+	pos  int
+}
+
+// selectItem is an entry of the select list, such as "region", "SUM(amount) AS total".
+// Aggregate is the upper-case aggregate function, if any, and arg its argument.
+type selectItem struct {
+	querySegment
+	alias     string
+	aggregate string
+	arg       querySegment
+}
+
+// orderItem is an entry of ORDER BY.
+type orderItem struct {
+	querySegment
+	descending bool
+}
+
+// parsedQuery is the clause structure of a query.
+// Items is nil for SELECT *; limit and offset are -1 when absent.
+type parsedQuery struct {
+	explain  bool
+	distinct bool
+	items    []selectItem
+	where    *querySegment
+	groupBy  []querySegment
+	orderBy  []orderItem
+	limit    int
+	offset   int
+}
+
+// queryKeywords lists the clause keywords in the order they must appear.
+// LIMIT and OFFSET share a rank so that either can come first.
+var queryKeywords = map[string]int{
+	"EXPLAIN": 0, "SELECT": 1, "DISTINCT": 2, "FROM": 3, "WHERE": 4, "GROUP BY": 5, "ORDER BY": 6,
+	"LIMIT": 7, "OFFSET": 7,
+}
+
+var (
+	aliasPattern     = regexp.MustCompile(`(?is)^(.*\S)\s+AS\s+([\p{L}_][\p{L}\p{N}_]*)$`)
+	aggregatePattern = regexp.MustCompile(`(?is)^(COUNT|SUM|AVG|MIN|MAX)\s*\((.*)\)$`)
+	directionPattern = regexp.MustCompile(`(?is)^(.*\S)\s+(ASC|DESC)$`)
+	tableNamePattern = regexp.MustCompile(`^[\p{L}_][\p{L}\p{N}_.]*$`)
+)
+
+// queryError creates an *expr.Error at a byte offset of the query.
+func queryError(query string, pos int, format string, args ...any) error {
+	return &expr.Error{Expr: query, Column: utf8.RuneCountInString(query[:pos]) + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+// inQuery moves an error of an expression compiled from a segment to its column in the whole query.
+func inQuery(query string, segment querySegment, err error) error {
+	if exprErr, ok := err.(*expr.Error); ok {
+		column := utf8.RuneCountInString(query[:segment.pos]) + exprErr.Column
+		return &expr.Error{Expr: query, Column: column, Message: exprErr.Message}
+	}
+	return err
+}
+
+// queryClause is a keyword of the query with the text that follows it.
+type queryClause struct {
+	keyword string
+	pos     int
+	body    querySegment
+}
+
+// parseQuery splits a query into its clauses.
+func parseQuery(query string) (*parsedQuery, error) {
+	clauses, err := scanClauses(query)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &parsedQuery{limit: -1, offset: -1}
+	rank := -1
+	seen := make(map[string]bool)
+	for i, clause := range clauses {
+		if seen[clause.keyword] || queryKeywords[clause.keyword] < rank {
+			return nil, queryError(query, clause.pos, "unexpected %s", clause.keyword)
+		}
+		seen[clause.keyword] = true
+		rank = queryKeywords[clause.keyword]
+		if clause.keyword != "SELECT" && !seen["SELECT"] && (clause.keyword != "EXPLAIN" || i > 0) {
+			return nil, queryError(query, clause.pos, "expected SELECT")
+		}
+		if clause.keyword == "DISTINCT" && (clauses[i-1].keyword != "SELECT" || clauses[i-1].body.text != "") {
+			return nil, queryError(query, clause.pos, "DISTINCT must directly follow SELECT")
+		}
+
+		body := clause.body
+		distinctNext := clause.keyword == "SELECT" && i+1 < len(clauses) && clauses[i+1].keyword == "DISTINCT"
+		if body.text == "" && clause.keyword != "EXPLAIN" && !distinctNext {
+			return nil, queryError(query, body.pos, "expected an expression after %s", clause.keyword)
+		}
+		switch clause.keyword {
+		case "EXPLAIN":
+			if body.text != "" {
+				return nil, queryError(query, body.pos, "expected SELECT")
+			}
+			q.explain = true
+		case "SELECT", "DISTINCT":
+			q.distinct = q.distinct || clause.keyword == "DISTINCT"
+			if body.text != "" && body.text != "*" {
+				if q.items, err = parseSelectList(query, body); err != nil {
+					return nil, err
+				}
+			}
+		case "FROM":
+			if !tableNamePattern.MatchString(body.text) {
+				return nil, queryError(query, body.pos, "expected a name after FROM")
+			}
+		case "WHERE":
+			q.where = &body
+		case "GROUP BY":
+			q.groupBy = splitQuery(body)
+		case "ORDER BY":
+			for _, segment := range splitQuery(body) {
+				item := orderItem{querySegment: segment}
+				if m := directionPattern.FindStringSubmatch(segment.text); m != nil {
+					item.text = m[1]
+					item.descending = strings.EqualFold(m[2], "DESC")
+				}
+				q.orderBy = append(q.orderBy, item)
+			}
+		case "LIMIT", "OFFSET":
+			n, err := strconv.Atoi(body.text)
+			if err != nil || n < 0 {
+				return nil, queryError(query, body.pos, "%s expects a non-negative integer", clause.keyword)
+			}
+			if clause.keyword == "LIMIT" {
+				q.limit = n
+			} else {
+				q.offset = n
+			}
+		}
+	}
+	if !seen["SELECT"] {
+		return nil, queryError(query, len(query), "expected SELECT")
+	}
+	return q, nil
+}
+
+// parseSelectList parses the select list into items with optional aliases and aggregates.
+func parseSelectList(query string, body querySegment) ([]selectItem, error) {
+	var items []selectItem
+	for _, segment := range splitQuery(body) {
+		if segment.text == "" {
+			return nil, queryError(query, segment.pos, "expected a column")
+		}
+		item := selectItem{querySegment: segment}
+		if m := aliasPattern.FindStringSubmatch(segment.text); m != nil {
+			item.text, item.alias = m[1], m[2]
+		}
+		if m := aggregatePattern.FindStringSubmatchIndex(item.text); m != nil && balanced(item.text[m[4]:m[5]]) {
+			item.aggregate = strings.ToUpper(item.text[m[2]:m[3]])
+			arg := item.text[m[4]:m[5]]
+			trimmed := strings.TrimLeft(arg, " \t\r\n")
+			item.arg = querySegment{text: strings.TrimSpace(arg), pos: item.pos + m[4] + len(arg) - len(trimmed)}
+			if item.arg.text == "" || (item.arg.text == "*" && item.aggregate != "COUNT") {
+				return nil, queryError(query, item.arg.pos, "%s expects an expression", item.aggregate)
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// balanced reports whether the parentheses outside quotes of s never close more than they open
+// and are all closed at the end.
+func balanced(s string) bool {
+	depth := 0
+	ok := scanQuery(s, func(i int, c byte) bool {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		return depth >= 0
+	})
+	return ok && depth == 0
+}
+
+// scanQuery calls visit for every byte of s outside quoted strings, stopping when visit
+// returns false. It returns false if it was stopped or a string is left open.
+func scanQuery(s string, visit func(i int, c byte) bool) bool {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		default:
+			if !visit(i, c) {
+				return false
+			}
+		}
+	}
+	return quote == 0
+}
+
+// splitQuery splits a segment at commas outside parentheses and quotes, trimming each part.
+func splitQuery(segment querySegment) []querySegment {
+	var parts []querySegment
+	depth, start := 0, 0
+	add := func(end int) {
+		raw := segment.text[start:end]
+		trimmed := strings.TrimLeft(raw, " \t\r\n")
+		parts = append(parts, querySegment{
+			text: strings.TrimRight(trimmed, " \t\r\n"),
+			pos:  segment.pos + start + len(raw) - len(trimmed),
+		})
+	}
+	scanQuery(segment.text, func(i int, c byte) bool {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				add(i)
+				start = i + 1
+			}
+		}
+		return true
+	})
+	add(len(segment.text))
+	return parts
+}
+
+// isIdentByte reports whether c can be part of an identifier.
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= utf8.RuneSelf || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// scanClauses finds the clause keywords outside parentheses and quotes and the text after each.
+func scanClauses(query string) ([]queryClause, error) {
+	var clauses []queryClause
+	depth := 0
+	closeClause := func(end int) {
+		if len(clauses) == 0 {
+			return
+		}
+		last := &clauses[len(clauses)-1]
+		raw := query[last.body.pos:end]
+		trimmed := strings.TrimLeft(raw, " \t\r\n")
+		last.body = querySegment{text: strings.TrimRight(trimmed, " \t\r\n"), pos: last.body.pos + len(raw) - len(trimmed)}
+	}
+
+	unexpected := -1
+	skipTo := 0
+	complete := scanQuery(query, func(i int, c byte) bool {
+		if i < skipTo {
+			return true
+		}
+		switch {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		}
+		if depth != 0 || !isIdentByte(c) || (i > 0 && isIdentByte(query[i-1])) {
+			if len(clauses) == 0 && unexpected < 0 && c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+				unexpected = i
+			}
+			return true
+		}
+
+		end := i
+		for end < len(query) && isIdentByte(query[end]) {
+			end++
+		}
+		keyword := strings.ToUpper(query[i:end])
+		if keyword == "GROUP" || keyword == "ORDER" {
+			next := end
+			for next < len(query) && (query[next] == ' ' || query[next] == '\t' || query[next] == '\r' || query[next] == '\n') {
+				next++
+			}
+			if next+2 <= len(query) && strings.EqualFold(query[next:next+2], "BY") &&
+				(next+2 == len(query) || !isIdentByte(query[next+2])) {
+				keyword, end = keyword+" BY", next+2
+			}
+		}
+		if _, ok := queryKeywords[keyword]; ok {
+			closeClause(i)
+			clauses = append(clauses, queryClause{keyword: keyword, pos: i, body: querySegment{pos: end}})
+		} else if len(clauses) == 0 && unexpected < 0 {
+			unexpected = i
+		}
+		skipTo = end
+		return true
+	})
+	if !complete {
+		return nil, queryError(query, len(query), "unterminated string")
+	}
+	if unexpected >= 0 {
+		return nil, queryError(query, unexpected, "expected SELECT")
+	}
+	closeClause(len(query))
+	return clauses, nil
+}
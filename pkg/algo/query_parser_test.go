@@ -0,0 +1,69 @@
+package algo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	query := `explain select distinct lower(name) as n, count(*), "order by, (" from t ` +
+		`where (a = 'limit 3' or b) group by lower(name) , c order  by n desc, count(*) limit 5 offset 2`
+	q, err := parseQuery(query)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !q.explain || !q.distinct || q.limit != 5 || q.offset != 2 {
+		t.Errorf("Unexpected flags %+v", q)
+	}
+
+	if len(q.items) != 3 {
+		t.Fatalf("Expected 3 items, got %+v", q.items)
+	}
+	if q.items[0].text != "lower(name)" || q.items[0].alias != "n" || q.items[0].aggregate != "" {
+		t.Errorf("Unexpected first item %+v", q.items[0])
+	}
+	if q.items[1].aggregate != "COUNT" || q.items[1].arg.text != "*" || query[q.items[1].arg.pos] != '*' {
+		t.Errorf("Unexpected second item %+v", q.items[1])
+	}
+	if q.items[2].text != `"order by, ("` || query[q.items[2].pos] != '"' {
+		t.Errorf("Unexpected third item %+v", q.items[2])
+	}
+
+	if q.where == nil || q.where.text != "(a = 'limit 3' or b)" || query[q.where.pos] != '(' {
+		t.Errorf("Unexpected where %+v", q.where)
+	}
+	var groupBy []string
+	for _, segment := range q.groupBy {
+		groupBy = append(groupBy, segment.text)
+	}
+	if !reflect.DeepEqual(groupBy, []string{"lower(name)", "c"}) {
+		t.Errorf("Unexpected group by %v", groupBy)
+	}
+	if len(q.orderBy) != 2 || q.orderBy[0].text != "n" || !q.orderBy[0].descending ||
+		q.orderBy[1].text != "count(*)" || q.orderBy[1].descending {
+		t.Errorf("Unexpected order by %+v", q.orderBy)
+	}
+}
+
+func TestSplitQuery(t *testing.T) {
+	parts := splitQuery(querySegment{text: ` a, f(b, c) ,'x,y', "p\",q"`, pos: 10})
+	var texts []string
+	for _, part := range parts {
+		texts = append(texts, part.text)
+	}
+	if !reflect.DeepEqual(texts, []string{"a", "f(b, c)", "'x,y'", `"p\",q"`}) {
+		t.Errorf("Unexpected parts %q", texts)
+	}
+	if parts[0].pos != 11 || parts[1].pos != 14 {
+		t.Errorf("Unexpected positions %d and %d", parts[0].pos, parts[1].pos)
+	}
+}
+
+func TestBalanced(t *testing.T) {
+	cases := map[string]bool{"a": true, "f(a)": true, "a) + sum(b": false, "(a": false, "')'": true}
+	for s, expected := range cases {
+		if balanced(s) != expected {
+			t.Errorf("%q: expected %v", s, expected)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package algo
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/expr"
+)
+
+type Sale struct {
+	ID       int
+	Region   string
+	Category string `algo:"cat"`
+	Status   string
+	Amount   float64
+	Units    int
+	Placed   time.Time
+}
+
+func generateSales() []Sale {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	return []Sale{
+		{1, "eu", "books", "completed", 120, 2, day(1)},
+		{2, "us", "games", "pending", 80, 1, day(2)},
+		{3, "eu", "games", "completed", 300, 5, day(3)},
+		{4, "us", "books", "completed", 150, 3, day(4)},
+		{5, "eu", "books", "completed", 120, 1, day(5)},
+		{6, "apac", "toys", "cancelled", 45, 1, day(6)},
+		{7, "us", "games", "completed", 99.5, 2, day(7)},
+	}
+}
+
+func saleIDs(sales []Sale) []int {
+	ids := make([]int, len(sales))
+	for i, s := range sales {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+func TestQuery_SelectStar(t *testing.T) {
+	cases := map[string][]int{
+		"SELECT *": {1, 2, 3, 4, 5, 6, 7},
+		"select * from sales where status = 'completed' order by amount desc limit 3":      {3, 4, 1},
+		"SELECT * WHERE status = 'completed' ORDER BY amount DESC LIMIT 2 OFFSET 1":        {4, 1},
+		"SELECT * WHERE status = 'completed' ORDER BY amount DESC OFFSET 1 LIMIT 2":        {4, 1},
+		"SELECT * ORDER BY region, amount":                                                 {6, 1, 5, 3, 2, 7, 4},
+		"SELECT * ORDER BY amount":                                                         {6, 2, 7, 1, 5, 4, 3},
+		"SELECT * WHERE cat = 'books' AND NOT (region <> 'eu') OR units >= 5":              {1, 3, 5},
+		"SELECT * WHERE placed >= date('2024-05-06') ORDER BY lower(region) DESC, id DESC": {7, 6},
+		"SELECT * OFFSET 10": {},
+	}
+	for query, expected := range cases {
+		result, err := Query(generateSales(), query)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", query, err)
+			continue
+		}
+		if ids := saleIDs(result.Items); !reflect.DeepEqual(ids, expected) {
+			t.Errorf("%q: expected %v, got %v", query, expected, ids)
+		}
+		if result.Columns != nil || result.Rows != nil {
+			t.Errorf("%q: expected no columns for SELECT *", query)
+		}
+	}
+}
+
+func TestQuery_Columns(t *testing.T) {
+	result, err := Query(generateSales(),
+		"SELECT id, upper(region) AS region, amount * units AS total WHERE units > 1 ORDER BY total DESC LIMIT 3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Columns, []string{"id", "region", "total"}) {
+		t.Errorf("Unexpected columns %v", result.Columns)
+	}
+	expected := [][]any{{int64(3), "EU", 1500.0}, {int64(4), "US", 450.0}, {int64(1), "EU", 240.0}}
+	if !reflect.DeepEqual(result.Rows, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Rows)
+	}
+}
+
+func TestQuery_Distinct(t *testing.T) {
+	result, err := Query(generateSales(), "SELECT DISTINCT region, cat WHERE status = 'completed' ORDER BY region DESC")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := [][]any{{"us", "books"}, {"us", "games"}, {"eu", "books"}, {"eu", "games"}}
+	if !reflect.DeepEqual(result.Rows, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Rows)
+	}
+
+	// More distinct values than Distinct compares against on its own.
+	var many []Sale
+	for i := 0; i < 500; i++ {
+		many = append(many, Sale{ID: i, Units: i % 100})
+	}
+	result, err = Query(many, "SELECT DISTINCT units")
+	if err != nil || len(result.Rows) != 100 {
+		t.Errorf("Expected 100 distinct rows, got %d (%v)", len(result.Rows), err)
+	}
+
+	duplicated := append(generateSales(), generateSales()[:3]...)
+	result, err = Query(duplicated, "SELECT DISTINCT * ORDER BY id DESC LIMIT 4")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ids := saleIDs(result.Items); !reflect.DeepEqual(ids, []int{7, 6, 5, 4}) {
+		t.Errorf("Expected [7 6 5 4], got %v", ids)
+	}
+
+	// Duplicates further apart than Distinct compares against, on elements without fields.
+	numbers := make([]int, 200)
+	for i := range numbers {
+		numbers[i] = i % 100
+	}
+	numberResult, err := Query(numbers, "SELECT DISTINCT *")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(numberResult.Items) != 100 || numberResult.Items[99] != 99 {
+		t.Errorf("Expected 0 to 99 in input order, got %d items", len(numberResult.Items))
+	}
+}
+
+func TestQuery_GroupBy(t *testing.T) {
+	result, err := Query(generateSales(), `
+		SELECT region, COUNT(*) AS orders, SUM(amount) AS revenue, SUM(units), AVG(amount), MAX(placed), MIN(cat)
+		WHERE status <> 'cancelled'
+		GROUP BY region`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Columns, []string{
+		"region", "orders", "revenue", "SUM(units)", "AVG(amount)", "MAX(placed)", "MIN(cat)",
+	}) {
+		t.Errorf("Unexpected columns %v", result.Columns)
+	}
+	expected := [][]any{
+		{"eu", int64(3), 540.0, int64(8), 180.0, time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC), "books"},
+		{"us", int64(3), 329.5, int64(6), 329.5 / 3, time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC), "books"},
+	}
+	if !reflect.DeepEqual(result.Rows, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Rows)
+	}
+
+	result, err = Query(generateSales(),
+		"SELECT lower(cat) AS category, SUM(amount) AS revenue GROUP BY category ORDER BY revenue DESC LIMIT 2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected = [][]any{{"games", 479.5}, {"books", 390.0}}
+	if !reflect.DeepEqual(result.Rows, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Rows)
+	}
+
+	result, err = Query(generateSales(), "SELECT COUNT(*), AVG(amount), MIN(amount) WHERE amount > 1000")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Rows, [][]any{{int64(0), nil, nil}}) {
+		t.Errorf("Expected a single row for an empty input, got %v", result.Rows)
+	}
+
+	result, err = Query(generateSales(), "SELECT DISTINCT status = 'completed', COUNT(*) GROUP BY region, status = 'completed'")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Rows) != 3 {
+		t.Errorf("Expected 3 distinct rows, got %v", result.Rows)
+	}
+}
+
+func TestQuery_GroupByTimeInstant(t *testing.T) {
+	sales := generateSales()[:2]
+	// The same instant in another location belongs to the same group.
+	sales[1].Placed = sales[0].Placed.In(time.FixedZone("JST", 9*60*60))
+	result, err := Query(sales, "SELECT placed, COUNT(*) GROUP BY placed")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Rows) != 1 || result.Rows[0][1] != int64(2) {
+		t.Errorf("Expected one group of 2, got %v", result.Rows)
+	}
+}
+
+func TestQuery_Explain(t *testing.T) {
+	result, err := Query(generateSales(), "EXPLAIN SELECT * WHERE amount > 100 ORDER BY amount DESC LIMIT 5 OFFSET 1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "FilterOperation     WHERE amount > 100\n" +
+		"MergeSortOperation  ORDER BY amount DESC\n" +
+		"SkipOperation       OFFSET 1\n" +
+		"TakeOperation       LIMIT 5\n"
+	if result.Plan != expected || result.Items != nil {
+		t.Errorf("Expected plan:\n%s\ngot:\n%s", expected, result.Plan)
+	}
+
+	plan, err := Explain[Sale]("SELECT region, SUM(amount) AS revenue GROUP BY region ORDER BY revenue DESC")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected = "GroupBy             GROUP BY region; SELECT region, SUM(amount) AS revenue\n" +
+		"MergeSortOperation  ORDER BY revenue DESC\n"
+	if plan != expected {
+		t.Errorf("Expected plan:\n%s\ngot:\n%s", expected, plan)
+	}
+}
+
+func TestPrepareQuery_Reuse(t *testing.T) {
+	q, err := PrepareQuery[Sale]("SELECT * WHERE region = 'eu' ORDER BY amount DESC LIMIT 1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sales := generateSales()
+	first, _ := q.Run(sales)
+	second, _ := q.Run(sales[:2])
+	if saleIDs(first.Items)[0] != 3 || saleIDs(second.Items)[0] != 1 {
+		t.Errorf("Unexpected results %v and %v", saleIDs(first.Items), saleIDs(second.Items))
+	}
+	if sales[0].ID != 1 || sales[2].ID != 3 {
+		t.Error("Expected the input to be left unchanged")
+	}
+}
+
+func TestQuery_Errors(t *testing.T) {
+	cases := []struct {
+		query   string
+		column  int
+		message string
+	}{
+		{"SELECT * WHERE amout > 100", 16, `unknown field "amout"`},
+		{"SELECT * WHERE amount > 'x'", 23, "mismatched types"},
+		{"SELECT * ORDER BY amount sideways", 26, "expected asc or desc"},
+		{"SELECT * LIMIT -1", 16, "LIMIT expects a non-negative integer"},
+		{"SELECT * LIMIT 1 LIMIT 2", 18, "unexpected LIMIT"},
+		{"SELECT * ORDER BY id WHERE id > 1", 22, "unexpected WHERE"},
+		{"SELECT", 7, "expected an expression after SELECT"},
+		{"WHERE id > 1", 1, "expected SELECT"},
+		{"DELETE FROM sales", 1, "expected SELECT"},
+		{"SELECT * WHERE status = 'open", 30, "unterminated string"},
+		{"SELECT region, SUM(amount)", 8, "region must appear in GROUP BY or be used in an aggregate"},
+		{"SELECT SUM(region) GROUP BY region", 12, "SUM expects a number, got string"},
+		{"SELECT * GROUP BY region", 1, "SELECT * cannot be used with GROUP BY"},
+		{"SELECT DISTINCT region ORDER BY amount", 33, "ORDER BY amount must name a selected column"},
+		{"SELECT region, COUNT(*) GROUP BY region ORDER BY amount", 50, "ORDER BY amount must name a selected column"},
+		{"SELECT MAX(*)", 12, "MAX expects an expression"},
+		{"SELECT region FROM 1", 20, "expected a name after FROM"},
+		{"SELECT id DISTINCT", 11, "DISTINCT must directly follow SELECT"},
+		{"SELECT id, lower(id)", 18, "argument 1 of lower must be string"},
+	}
+	for _, tc := range cases {
+		_, err := Query(generateSales(), tc.query)
+		var exprErr *expr.Error
+		if !errors.As(err, &exprErr) {
+			t.Errorf("%q: expected *expr.Error, got %v", tc.query, err)
+			continue
+		}
+		if exprErr.Column != tc.column || !strings.Contains(exprErr.Message, tc.message) || exprErr.Expr != tc.query {
+			t.Errorf("%q: expected column %d %q, got %v", tc.query, tc.column, tc.message, err)
+		}
+	}
+}
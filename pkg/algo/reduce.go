@@ -44,3 +44,56 @@ func (p *Pipeline[T]) Reduce(reducer func(acc, item T) T) *Pipeline[T] {
 	p.operations = append(p.operations, &ReduceOperation[T]{Reducer: reducer})
 	return p
 }
+
+// ReduceWithInitOperation aggregates all elements in the data into a single value, starting from a seed.
+// Unlike ReduceOperation, it accepts empty input and returns the seed in that case.
+type ReduceWithInitOperation[T any] struct {
+	Initial T
+	Reducer func(acc, item T) T
+}
+
+// Apply performs the seeded reduce operation on the data.
+// It returns a slice containing the single accumulated result.
+//
+// Example:
+//
+//	pipeline := NewPipeline[int]().
+//	    ReduceWithInit(0, func(acc, item int) int { return acc + item })
+//	result, err := pipeline.Execute() // [0] for empty input
+func (r *ReduceWithInitOperation[T]) Apply(data []T) ([]T, error) {
+	return []T{Fold(data, r.Initial, r.Reducer)}, nil
+}
+
+// ReduceWithInit adds a seeded reduce operation to the pipeline.
+// The reducer function combines the accumulator, starting from init, with each item.
+//
+// Example:
+//
+//	pipeline.ReduceWithInit(Order{}, func(acc, item Order) Order {
+//	    acc.TotalAmount += item.Amount
+//	    return acc
+//	})
+func (p *Pipeline[T]) ReduceWithInit(init T, reducer func(acc, item T) T) *Pipeline[T] {
+	p.operations = append(p.operations, &ReduceWithInitOperation[T]{Initial: init, Reducer: reducer})
+	return p
+}
+
+// Fold aggregates data into an accumulator of any type, starting from init.
+// It returns init unchanged when data is empty.
+//
+// Example:
+//
+//	orders, _ := NewPipelineWithData(orders).
+//	    Filter(func(o Order) bool { return o.Paid }).
+//	    Execute()
+//
+//	total := Fold(orders, 0.0, func(acc float64, o Order) float64 {
+//	    return acc + o.Amount
+//	})
+func Fold[T, A any](data []T, init A, folder func(acc A, item T) A) A {
+	acc := init
+	for _, item := range data {
+		acc = folder(acc, item)
+	}
+	return acc
+}
@@ -178,3 +178,67 @@ func BenchmarkReduce(b *testing.B) {
 		}
 	}
 }
+
+func TestReduceWithInitOperation_Sum(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		ReduceWithInit(10, func(acc, item int) int { return acc + item })
+
+	pipeline.WithData([]int{1, 2, 3})
+
+	reducedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ReduceWithInitOperation failed: %v", err)
+	}
+
+	if len(reducedData) != 1 || reducedData[0] != 16 {
+		t.Errorf("Expected [16], got %v", reducedData)
+	}
+}
+
+func TestReduceWithInitOperation_EmptySlice(t *testing.T) {
+	pipeline := NewPipeline[Item]().
+		ReduceWithInit(Item{Name: "seed"}, func(acc, item Item) Item {
+			acc.ID += item.ID
+			return acc
+		})
+
+	var data []Item
+
+	pipeline.WithData(data)
+
+	reducedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ReduceWithInitOperation failed on empty slice: %v", err)
+	}
+
+	expected := Item{Name: "seed"}
+	if len(reducedData) != 1 || reducedData[0] != expected {
+		t.Errorf("Expected [%+v], got %+v", expected, reducedData)
+	}
+}
+
+func TestFold_ChangesAccumulatorType(t *testing.T) {
+	data := []Item{
+		{ID: 1, Name: "Item1", Active: true},
+		{ID: 2, Name: "Item2", Active: false},
+		{ID: 3, Name: "Item3", Active: true},
+	}
+
+	names := Fold(data, "", func(acc string, item Item) string {
+		return acc + item.Name
+	})
+
+	if names != "Item1Item2Item3" {
+		t.Errorf("Expected %q, got %q", "Item1Item2Item3", names)
+	}
+}
+
+func TestFold_EmptySlice(t *testing.T) {
+	var data []int
+
+	count := Fold(data, 42, func(acc, item int) int { return acc + 1 })
+
+	if count != 42 {
+		t.Errorf("Expected seed 42, got %d", count)
+	}
+}
@@ -0,0 +1,200 @@
+package algo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// compileGlob translates a glob pattern into an anchored regular expression and compiles it.
+// '*' matches any sequence of characters, '?' matches a single character, '[...]' matches
+// a character class ('[!...]' or '[^...]' negates it) and '\' escapes the next character.
+func compileGlob(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			b.WriteString("(?s:.*)")
+		case '?':
+			b.WriteString("(?s:.)")
+		case '\\':
+			if i+1 == len(runes) {
+				return nil, fmt.Errorf("glob %q: trailing backslash", glob)
+			}
+			i++
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case '[':
+			end := i + 1
+			if end < len(runes) && (runes[end] == '!' || runes[end] == '^') {
+				end++
+			}
+			if end < len(runes) && runes[end] == ']' {
+				end++
+			}
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("glob %q: unterminated character class", glob)
+			}
+			class := string(runes[i+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i = end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// MatchRegexOperation selects the elements whose key matches a compiled pattern.
+// The pattern is compiled when the stage is added; a compilation error is stored in Err
+// and returned when the pipeline executes.
+type MatchRegexOperation[T any] struct {
+	Key    func(T) string
+	Regexp *regexp.Regexp
+	Err    error
+}
+
+// Apply performs the pattern match on the data.
+// It returns the matching elements in their original order, or the compilation error of the pattern.
+//
+// Example:
+//
+//	pipeline := NewPipeline[string]().
+//	    MatchRegex(func(s string) string { return s }, `^ERROR \d{3}`)
+//	result, err := pipeline.Execute()
+func (m *MatchRegexOperation[T]) Apply(data []T) ([]T, error) {
+	if m.Err != nil {
+		return nil, fmt.Errorf("MatchRegexOperation: %w", m.Err)
+	}
+
+	matchedData := make([]T, 0, len(data))
+	for _, item := range data {
+		if m.Regexp.MatchString(m.Key(item)) {
+			matchedData = append(matchedData, item)
+		}
+	}
+	return matchedData, nil
+}
+
+// MatchRegex adds a regular expression filter to the pipeline.
+// The pattern uses the syntax of the regexp package and matches anywhere in the key unless anchored.
+//
+// Example:
+//
+//	pipeline.MatchRegex(func(r LogRecord) string { return r.Message }, `user=\w+ action=(login|logout)`)
+func (p *Pipeline[T]) MatchRegex(key func(T) string, pattern string) *Pipeline[T] {
+	re, err := regexp.Compile(pattern)
+	p.operations = append(p.operations, &MatchRegexOperation[T]{Key: key, Regexp: re, Err: err})
+	return p
+}
+
+// MatchGlob adds a glob filter to the pipeline.
+// The glob must match the whole key: '*' matches any sequence, '?' a single character,
+// '[...]' a character class and '\' escapes the next character.
+//
+// Example:
+//
+//	pipeline.MatchGlob(func(f File) string { return f.Name }, "report-2024-??-*.csv")
+func (p *Pipeline[T]) MatchGlob(key func(T) string, glob string) *Pipeline[T] {
+	re, err := compileGlob(glob)
+	p.operations = append(p.operations, &MatchRegexOperation[T]{Key: key, Regexp: re, Err: err})
+	return p
+}
+
+// ExtractRegexOperation replaces each element whose key matches a pattern with the result of
+// a mapper that receives the named capture groups. Elements that do not match are dropped.
+type ExtractRegexOperation[T any] struct {
+	Key    func(T) string
+	Regexp *regexp.Regexp
+	Mapper func(item T, groups map[string]string) T
+	Err    error
+}
+
+// Apply performs the extraction on the data.
+// It returns the mapped matching elements in their original order, or the compilation error of the pattern.
+//
+// Example:
+//
+//	pipeline := NewPipeline[LogRecord]().
+//	    ExtractRegex(func(r LogRecord) string { return r.Message }, `status=(?P<status>\d+)`,
+//	        func(r LogRecord, groups map[string]string) LogRecord {
+//	            r.Status = groups["status"]
+//	            return r
+//	        })
+//	result, err := pipeline.Execute()
+func (e *ExtractRegexOperation[T]) Apply(data []T) ([]T, error) {
+	if e.Err != nil {
+		return nil, fmt.Errorf("ExtractRegexOperation: %w", e.Err)
+	}
+	return extractRegex(data, e.Key, e.Regexp, e.Mapper), nil
+}
+
+// ExtractRegex adds a capture group extraction stage to the pipeline.
+// The mapper receives each matching element with its named capture groups.
+//
+// Example:
+//
+//	pipeline.ExtractRegex(func(r LogRecord) string { return r.Line }, `(?P<level>[A-Z]+) (?P<msg>.*)`,
+//	    func(r LogRecord, groups map[string]string) LogRecord {
+//	        r.Level, r.Message = groups["level"], groups["msg"]
+//	        return r
+//	    })
+func (p *Pipeline[T]) ExtractRegex(
+	key func(T) string, pattern string, mapper func(item T, groups map[string]string) T,
+) *Pipeline[T] {
+	re, err := regexp.Compile(pattern)
+	p.operations = append(p.operations, &ExtractRegexOperation[T]{Key: key, Regexp: re, Mapper: mapper, Err: err})
+	return p
+}
+
+// ExtractRegex maps the elements of data whose key matches pattern into values of another type,
+// passing the named capture groups to the mapper. Elements that do not match are dropped.
+// Returns an error if the pattern does not compile.
+//
+// Example:
+//
+//	type Request struct{ Method, Path string }
+//	requests, err := ExtractRegex(lines, func(l string) string { return l },
+//	    `"(?P<method>[A-Z]+) (?P<path>\S+)`,
+//	    func(_ string, groups map[string]string) Request {
+//	        return Request{Method: groups["method"], Path: groups["path"]}
+//	    })
+func ExtractRegex[T, R any](
+	data []T, key func(T) string, pattern string, mapper func(item T, groups map[string]string) R,
+) ([]R, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return extractRegex(data, key, re, mapper), nil
+}
+
+// extractRegex maps the matching elements of data using the named capture groups of re.
+func extractRegex[T, R any](
+	data []T, key func(T) string, re *regexp.Regexp, mapper func(item T, groups map[string]string) R,
+) []R {
+	names := re.SubexpNames()
+	result := make([]R, 0, len(data))
+	for _, item := range data {
+		submatches := re.FindStringSubmatch(key(item))
+		if submatches == nil {
+			continue
+		}
+		groups := make(map[string]string, len(names))
+		for i, name := range names {
+			if name != "" {
+				groups[name] = submatches[i]
+			}
+		}
+		result = append(result, mapper(item, groups))
+	}
+	return result
+}
@@ -0,0 +1,147 @@
+package algo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatchRegexOperation_Filter(t *testing.T) {
+	pipeline := NewPipeline[string]().
+		MatchRegex(func(s string) string { return s }, `^ERROR \d{3}`)
+
+	pipeline.WithData([]string{"ERROR 500 upstream", "INFO ok", "ERROR x", "ERROR 404 missing"})
+
+	result, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("MatchRegexOperation failed: %v", err)
+	}
+
+	expected := []string{"ERROR 500 upstream", "ERROR 404 missing"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestMatchRegexOperation_CompileErrorFromExecute(t *testing.T) {
+	pipeline := NewPipeline[string]().
+		MatchRegex(func(s string) string { return s }, `(unclosed`)
+
+	pipeline.WithData([]string{"a"})
+
+	_, err := pipeline.Execute()
+	if err == nil {
+		t.Fatalf("Expected compilation error, but got nil")
+	}
+	if !strings.HasPrefix(err.Error(), "MatchRegexOperation: error parsing regexp") {
+		t.Errorf("Unexpected error message '%s'", err.Error())
+	}
+}
+
+func TestMatchRegex_CompiledWhenAdded(t *testing.T) {
+	pipeline := NewPipeline[string]().MatchRegex(func(s string) string { return s }, `stage-\d+`)
+	op := pipeline.GetOperations()[0].(*MatchRegexOperation[string])
+	if op.Err != nil || op.Regexp == nil || op.Regexp.String() != `stage-\d+` {
+		t.Fatalf("Expected the pattern to be compiled when the stage is added, got %v (%v)", op.Regexp, op.Err)
+	}
+
+	for _, input := range [][]string{{"stage-1", "x"}, {"stage-22"}} {
+		result, err := pipeline.WithData(input).Execute()
+		if err != nil || len(result) != 1 {
+			t.Errorf("Expected one match from %v, got %v (%v)", input, result, err)
+		}
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	files := []string{
+		"report-2024-01-a.csv",
+		"report-2024-1-a.csv",
+		"report-2024-02-b.json",
+		"summary.csv",
+		"report-2024-12-[x].csv",
+	}
+
+	cases := []struct {
+		glob     string
+		expected []string
+	}{
+		{"report-2024-??-*.csv", []string{"report-2024-01-a.csv", "report-2024-12-[x].csv"}},
+		{"*.csv", []string{"report-2024-01-a.csv", "report-2024-1-a.csv", "summary.csv", "report-2024-12-[x].csv"}},
+		{"report-2024-0[!1]-*", []string{"report-2024-02-b.json"}},
+		{`*\[x\].csv`, []string{"report-2024-12-[x].csv"}},
+	}
+
+	for _, c := range cases {
+		result, err := NewPipelineWithData(files).
+			MatchGlob(func(s string) string { return s }, c.glob).
+			Execute()
+		if err != nil {
+			t.Fatalf("MatchGlob(%q) failed: %v", c.glob, err)
+		}
+		if !reflect.DeepEqual(result, c.expected) {
+			t.Errorf("MatchGlob(%q): expected %v, got %v", c.glob, c.expected, result)
+		}
+	}
+}
+
+func TestMatchGlob_InvalidPattern(t *testing.T) {
+	_, err := NewPipelineWithData([]string{"a"}).
+		MatchGlob(func(s string) string { return s }, "file[0-9").
+		Execute()
+	if err == nil {
+		t.Fatalf("Expected error for unterminated character class, but got nil")
+	}
+}
+
+func TestExtractRegexOperation_SameType(t *testing.T) {
+	data := []Item{
+		{ID: 1, Name: "user=alice id=42"},
+		{ID: 2, Name: "garbage"},
+		{ID: 3, Name: "user=bob id=7"},
+	}
+
+	result, err := NewPipelineWithData(data).
+		ExtractRegex(func(a Item) string { return a.Name }, `user=(?P<user>\w+)`,
+			func(a Item, groups map[string]string) Item {
+				a.Name = groups["user"]
+				return a
+			}).
+		Execute()
+	if err != nil {
+		t.Fatalf("ExtractRegexOperation failed: %v", err)
+	}
+
+	expected := []Item{{ID: 1, Name: "alice"}, {ID: 3, Name: "bob"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestExtractRegex_MappedType(t *testing.T) {
+	type request struct {
+		Method string
+		Path   string
+	}
+
+	lines := []string{`"GET /index.html HTTP/1.1"`, `noise`, `"POST /api HTTP/1.1"`}
+
+	requests, err := ExtractRegex(lines, func(s string) string { return s },
+		`"(?P<method>[A-Z]+) (?P<path>\S+)`,
+		func(_ string, groups map[string]string) request {
+			return request{Method: groups["method"], Path: groups["path"]}
+		})
+	if err != nil {
+		t.Fatalf("ExtractRegex failed: %v", err)
+	}
+
+	expected := []request{{Method: "GET", Path: "/index.html"}, {Method: "POST", Path: "/api"}}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected %v, got %v", expected, requests)
+	}
+
+	if _, err := ExtractRegex(lines, func(s string) string { return s }, `(`,
+		func(s string, _ map[string]string) string { return s }); err == nil {
+		t.Errorf("Expected compilation error, but got nil")
+	}
+}
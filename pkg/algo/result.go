@@ -0,0 +1,106 @@
+package algo
+
+import (
+	"reflect"
+	"strings"
+)
+
+// IndexReporter is implemented by search operations that record the position of their match.
+// GetFoundIndex returns -1 when the operation found nothing.
+type IndexReporter interface {
+	GetFoundIndex() int
+}
+
+// MatchCounter is implemented by operations whose number of matches differs from their output size,
+// such as searches that pass the whole input through.
+type MatchCounter interface {
+	GetMatchCount() int
+}
+
+// StageResult holds the metadata recorded for one operation during ExecuteWithResult.
+type StageResult struct {
+	// Operation is the type name of the operation, such as "FilterOperation".
+	Operation string
+	// InputSize and OutputSize are the lengths of the slices passed to and returned by the operation.
+	InputSize  int
+	OutputSize int
+	// FoundIndex is the position reported by an IndexReporter, or -1 for other operations.
+	FoundIndex int
+	// Matches is the count reported by a MatchCounter, or OutputSize for other operations.
+	Matches int
+	// Err is the error returned by the operation, if any.
+	Err error
+}
+
+// Result is the outcome of ExecuteWithResult.
+// It carries the final data together with the metadata of every executed stage.
+type Result[T any] struct {
+	Data   []T
+	Stages []StageResult
+}
+
+// FoundIndex returns the index reported by the last stage that located an element.
+// Returns -1 if no stage reported a match.
+//
+// Example:
+//
+//	result, _ := NewPipelineWithData(sorted).
+//	    BinarySearch(func(x int) bool { return x >= 42 }).
+//	    ExecuteWithResult()
+//	index := result.FoundIndex()
+func (r *Result[T]) FoundIndex() int {
+	for i := len(r.Stages) - 1; i >= 0; i-- {
+		if r.Stages[i].FoundIndex >= 0 {
+			return r.Stages[i].FoundIndex
+		}
+	}
+	return -1
+}
+
+// ExecuteWithResult runs all operations in the pipeline in sequence and records per-stage metadata.
+// On failure it returns the result gathered up to and including the failing stage along with the error.
+//
+// Example:
+//
+//	result, err := NewPipelineWithData(items).
+//	    Filter(func(i Item) bool { return i.Active }).
+//	    LinearSearch(func(i Item) bool { return i.ID == 3 }).
+//	    ExecuteWithResult()
+//	for _, stage := range result.Stages {
+//	    fmt.Println(stage.Operation, stage.InputSize, stage.OutputSize, stage.FoundIndex)
+//	}
+func (p *Pipeline[T]) ExecuteWithResult() (*Result[T], error) {
+	result := &Result[T]{Stages: make([]StageResult, 0, len(p.operations))}
+	for _, op := range p.operations {
+		stage := StageResult{Operation: operationName(op), InputSize: len(p.data), FoundIndex: -1}
+
+		data, err := op.Apply(p.data)
+		stage.OutputSize = len(data)
+		stage.Matches = len(data)
+		if reporter, ok := op.(IndexReporter); ok {
+			stage.FoundIndex = reporter.GetFoundIndex()
+		}
+		if counter, ok := op.(MatchCounter); ok {
+			stage.Matches = counter.GetMatchCount()
+		}
+		stage.Err = err
+		result.Stages = append(result.Stages, stage)
+
+		if err != nil {
+			return result, err
+		}
+		p.data = data
+	}
+	result.Data = p.data
+	return result, nil
+}
+
+// operationName returns the type name of an operation without its package path and type arguments.
+func operationName(op any) string {
+	t := reflect.TypeOf(op)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name, _, _ := strings.Cut(t.Name(), "[")
+	return name
+}
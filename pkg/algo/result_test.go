@@ -0,0 +1,83 @@
+package algo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExecuteWithResult_StageMetadata(t *testing.T) {
+	data := []Item{
+		{ID: 1, Name: "Item1", Active: true},
+		{ID: 2, Name: "Item2", Active: false},
+		{ID: 3, Name: "Item3", Active: true},
+		{ID: 4, Name: "Item4", Active: true},
+	}
+
+	result, err := NewPipelineWithData(data).
+		Filter(func(a Item) bool { return a.Active }).
+		LinearSearch(func(a Item) bool { return a.ID == 3 }).
+		Take(2).
+		ExecuteWithResult()
+	if err != nil {
+		t.Fatalf("ExecuteWithResult failed: %v", err)
+	}
+
+	expected := []StageResult{
+		{Operation: "FilterOperation", InputSize: 4, OutputSize: 3, FoundIndex: -1, Matches: 3},
+		{Operation: "LinearSearchOperation", InputSize: 3, OutputSize: 3, FoundIndex: 1, Matches: 1},
+		{Operation: "TakeOperation", InputSize: 3, OutputSize: 2, FoundIndex: -1, Matches: 2},
+	}
+
+	if len(result.Stages) != len(expected) {
+		t.Fatalf("Expected %d stages, got %d", len(expected), len(result.Stages))
+	}
+	for i, stage := range result.Stages {
+		if stage != expected[i] {
+			t.Errorf("At stage %d, expected %+v, got %+v", i, expected[i], stage)
+		}
+	}
+
+	if len(result.Data) != 2 {
+		t.Errorf("Expected 2 items, got %d", len(result.Data))
+	}
+	if result.FoundIndex() != 1 {
+		t.Errorf("Expected found index to be 1, but got %d", result.FoundIndex())
+	}
+}
+
+func TestExecuteWithResult_BinarySearchIndex(t *testing.T) {
+	result, err := NewPipelineWithData([]int{4, 2, 3, 1}).
+		QuickSort(func(a, b int) bool { return a < b }).
+		BinarySearch(func(x int) bool { return x >= 3 }).
+		ExecuteWithResult()
+	if err != nil {
+		t.Fatalf("ExecuteWithResult failed: %v", err)
+	}
+
+	if result.FoundIndex() != 2 {
+		t.Errorf("Expected found index to be 2, but got %d", result.FoundIndex())
+	}
+}
+
+func TestExecuteWithResult_FailingStage(t *testing.T) {
+	result, err := NewPipelineWithData([]int{1, 2, 3}).
+		Map(func(x int) int { return x * 2 }).
+		LinearSearchExact(5).
+		Take(1).
+		ExecuteWithResult()
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	if len(result.Stages) != 2 {
+		t.Fatalf("Expected 2 recorded stages, got %d", len(result.Stages))
+	}
+
+	failed := result.Stages[1]
+	if failed.Err == nil || failed.FoundIndex != -1 || failed.Matches != 0 {
+		t.Errorf("Unexpected metadata for failing stage: %+v", failed)
+	}
+	if result.FoundIndex() != -1 {
+		t.Errorf("Expected found index to be -1, but got %d", result.FoundIndex())
+	}
+}
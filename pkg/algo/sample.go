@@ -0,0 +1,170 @@
+package algo
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// SampleNOperation selects N elements uniformly at random using reservoir sampling.
+// The selected elements keep their original relative order, and the same Seed always selects
+// the same elements from the same input.
+type SampleNOperation[T any] struct {
+	N    int
+	Seed int64
+}
+
+// Apply performs the reservoir sampling operation on the data.
+// It returns a new slice containing min(N, len(data)) elements.
+// Returns an error if N is negative.
+//
+// Example:
+//
+//	pipeline := NewPipeline[Order]().
+//	    SampleN(100, 42)
+//	result, err := pipeline.Execute()
+func (s *SampleNOperation[T]) Apply(data []T) ([]T, error) {
+	if s.N < 0 {
+		return nil, fmt.Errorf("SampleNOperation: sample size must not be negative, got %d", s.N)
+	}
+
+	indices := make([]int, len(data))
+	for i := range indices {
+		indices[i] = i
+	}
+	return pick(data, reservoir(indices, s.N, rand.New(rand.NewSource(s.Seed)))), nil
+}
+
+// SampleN adds a reservoir sampling operation to the pipeline.
+//
+// Example:
+//
+//	pipeline.SampleN(500, 2024) // 500 random orders, reproducible with seed 2024
+func (p *Pipeline[T]) SampleN(n int, seed int64) *Pipeline[T] {
+	p.operations = append(p.operations, &SampleNOperation[T]{N: n, Seed: seed})
+	return p
+}
+
+// SampleFractionOperation keeps each element independently with probability Fraction (Bernoulli sampling).
+// The number of elements kept varies around Fraction*len(data); the same Seed always keeps
+// the same elements from the same input.
+type SampleFractionOperation[T any] struct {
+	Fraction float64
+	Seed     int64
+}
+
+// Apply performs the Bernoulli sampling operation on the data.
+// It returns a new slice containing the kept elements in their original order.
+// Returns an error if Fraction is not between 0 and 1.
+//
+// Example:
+//
+//	pipeline := NewPipeline[Event]().
+//	    SampleFraction(0.01, 7)
+//	result, err := pipeline.Execute() // about 1% of events
+func (s *SampleFractionOperation[T]) Apply(data []T) ([]T, error) {
+	// Written so that NaN fails the check too.
+	if !(s.Fraction >= 0 && s.Fraction <= 1) {
+		return nil, fmt.Errorf("SampleFractionOperation: fraction must be between 0 and 1, got %v", s.Fraction)
+	}
+
+	rng := rand.New(rand.NewSource(s.Seed))
+	sampledData := make([]T, 0, int(float64(len(data))*s.Fraction))
+	for _, item := range data {
+		if rng.Float64() < s.Fraction {
+			sampledData = append(sampledData, item)
+		}
+	}
+	return sampledData, nil
+}
+
+// SampleFraction adds a Bernoulli sampling operation to the pipeline.
+//
+// Example:
+//
+//	pipeline.SampleFraction(0.1, 42) // keep roughly one element in ten
+func (p *Pipeline[T]) SampleFraction(fraction float64, seed int64) *Pipeline[T] {
+	p.operations = append(p.operations, &SampleFractionOperation[T]{Fraction: fraction, Seed: seed})
+	return p
+}
+
+// StratifiedSampleOperation selects up to PerGroup elements uniformly at random from every group
+// of elements sharing the same key, so small groups are represented as well as large ones.
+// The selected elements keep their original relative order, and the same Seed always selects
+// the same elements from the same input.
+type StratifiedSampleOperation[T any] struct {
+	Key      func(T) string
+	PerGroup int
+	Seed     int64
+}
+
+// Apply performs the stratified sampling operation on the data.
+// Groups with no more than PerGroup elements are kept entirely.
+// Returns an error if PerGroup is negative.
+//
+// Example:
+//
+//	pipeline := NewPipeline[Order]().
+//	    StratifiedSample(func(o Order) string { return o.Category }, 5, 42)
+//	result, err := pipeline.Execute()
+func (s *StratifiedSampleOperation[T]) Apply(data []T) ([]T, error) {
+	if s.PerGroup < 0 {
+		return nil, fmt.Errorf("StratifiedSampleOperation: group sample size must not be negative, got %d", s.PerGroup)
+	}
+
+	var keys []string
+	groups := make(map[string][]int)
+	for i, item := range data {
+		key := s.Key(item)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	// Groups are sampled in order of first appearance so the random stream, and therefore
+	// the result, does not depend on map iteration order.
+	rng := rand.New(rand.NewSource(s.Seed))
+	var selected []int
+	for _, key := range keys {
+		selected = append(selected, reservoir(groups[key], s.PerGroup, rng)...)
+	}
+	slices.Sort(selected)
+	return pick(data, selected), nil
+}
+
+// StratifiedSample adds a stratified sampling operation to the pipeline.
+//
+// Example:
+//
+//	pipeline.StratifiedSample(func(o Order) string { return o.Category }, 10, 2024) // 10 orders per category
+func (p *Pipeline[T]) StratifiedSample(key func(T) string, perGroup int, seed int64) *Pipeline[T] {
+	p.operations = append(p.operations, &StratifiedSampleOperation[T]{Key: key, PerGroup: perGroup, Seed: seed})
+	return p
+}
+
+// reservoir selects k of the given indices uniformly at random in a single pass (Algorithm R)
+// and returns them in ascending order.
+func reservoir(indices []int, k int, rng *rand.Rand) []int {
+	if k >= len(indices) {
+		return slices.Clone(indices)
+	}
+
+	selected := slices.Clone(indices[:k])
+	for i := k; i < len(indices); i++ {
+		if j := rng.Intn(i + 1); j < k {
+			selected[j] = indices[i]
+		}
+	}
+	slices.Sort(selected)
+	return selected
+}
+
+// pick returns the elements of data at the given indices.
+func pick[T any](data []T, indices []int) []T {
+	picked := make([]T, len(indices))
+	for i, index := range indices {
+		picked[i] = data[index]
+	}
+	return picked
+}
@@ -0,0 +1,153 @@
+package algo
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func rangeInts(n int) []int {
+	data := make([]int, n)
+	for i := range data {
+		data[i] = i
+	}
+	return data
+}
+
+func TestSampleNOperation(t *testing.T) {
+	data := rangeInts(1000)
+	result, err := NewPipelineWithData(data).SampleN(50, 42).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result) != 50 {
+		t.Fatalf("Expected 50 elements, got %d", len(result))
+	}
+	if !sort.IntsAreSorted(result) {
+		t.Error("Expected sampled elements to keep their original order")
+	}
+	for i := 1; i < len(result); i++ {
+		if result[i] == result[i-1] {
+			t.Errorf("Element %d selected twice", result[i])
+		}
+	}
+
+	again, _ := NewPipelineWithData(data).SampleN(50, 42).Execute()
+	if !reflect.DeepEqual(result, again) {
+		t.Error("Expected the same seed to select the same elements")
+	}
+	other, _ := NewPipelineWithData(data).SampleN(50, 43).Execute()
+	if reflect.DeepEqual(result, other) {
+		t.Error("Expected a different seed to select different elements")
+	}
+}
+
+func TestSampleNOperation_Uniform(t *testing.T) {
+	counts := make([]int, 10)
+	for seed := int64(0); seed < 2000; seed++ {
+		result, _ := NewPipelineWithData(rangeInts(10)).SampleN(3, seed).Execute()
+		for _, x := range result {
+			counts[x]++
+		}
+	}
+	// Each element is expected in 3/10 of the 2000 samples.
+	for x, n := range counts {
+		if n < 500 || n > 700 {
+			t.Errorf("Element %d selected %d times, expected about 600", x, n)
+		}
+	}
+}
+
+func TestSampleNOperation_EdgeCases(t *testing.T) {
+	result, err := NewPipelineWithData([]int{3, 1, 2}).SampleN(10, 1).Execute()
+	if err != nil || !reflect.DeepEqual(result, []int{3, 1, 2}) {
+		t.Errorf("Expected all elements when N exceeds the input, got %v (%v)", result, err)
+	}
+	result, err = NewPipelineWithData([]int{3, 1, 2}).SampleN(0, 1).Execute()
+	if err != nil || len(result) != 0 {
+		t.Errorf("Expected no elements for N=0, got %v (%v)", result, err)
+	}
+	if _, err := NewPipelineWithData([]int{1}).SampleN(-1, 1).Execute(); err == nil {
+		t.Error("Expected error for negative N")
+	}
+}
+
+func TestSampleFractionOperation(t *testing.T) {
+	data := rangeInts(10000)
+	result, err := NewPipelineWithData(data).SampleFraction(0.1, 7).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) < 900 || len(result) > 1100 {
+		t.Errorf("Expected about 1000 elements, got %d", len(result))
+	}
+	if !sort.IntsAreSorted(result) {
+		t.Error("Expected sampled elements to keep their original order")
+	}
+
+	again, _ := NewPipelineWithData(data).SampleFraction(0.1, 7).Execute()
+	if !reflect.DeepEqual(result, again) {
+		t.Error("Expected the same seed to keep the same elements")
+	}
+
+	all, _ := NewPipelineWithData(data).SampleFraction(1, 7).Execute()
+	none, _ := NewPipelineWithData(data).SampleFraction(0, 7).Execute()
+	if len(all) != len(data) || len(none) != 0 {
+		t.Errorf("Expected fractions 1 and 0 to keep all and none, got %d and %d", len(all), len(none))
+	}
+
+	for _, fraction := range []float64{-0.1, 1.5, math.NaN()} {
+		if _, err := NewPipelineWithData(data).SampleFraction(fraction, 7).Execute(); err == nil {
+			t.Errorf("Expected error for fraction %v", fraction)
+		}
+	}
+}
+
+func TestStratifiedSampleOperation(t *testing.T) {
+	var orders []Order
+	for i := 0; i < 300; i++ {
+		item := "common"
+		if i%50 == 0 {
+			item = "rare"
+		} else if i%3 == 0 {
+			item = "frequent-" + strconv.Itoa(i%2)
+		}
+		orders = append(orders, Order{OrderID: i, Item: item})
+	}
+	key := func(o Order) string { return o.Item }
+
+	result, err := NewPipelineWithData(orders).StratifiedSample(key, 4, 42).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	perGroup := make(map[string]int)
+	for i, o := range result {
+		perGroup[o.Item]++
+		if i > 0 && result[i-1].OrderID >= o.OrderID {
+			t.Error("Expected sampled orders to keep their original order")
+		}
+	}
+	expected := map[string]int{"common": 4, "rare": 4, "frequent-0": 4, "frequent-1": 4}
+	if !reflect.DeepEqual(perGroup, expected) {
+		t.Errorf("Expected %v, got %v", expected, perGroup)
+	}
+
+	again, _ := NewPipelineWithData(orders).StratifiedSample(key, 4, 42).Execute()
+	if !reflect.DeepEqual(result, again) {
+		t.Error("Expected the same seed to select the same elements")
+	}
+
+	small := []Order{{OrderID: 1, Item: "a"}, {OrderID: 2, Item: "b"}, {OrderID: 3, Item: "a"}}
+	result, _ = NewPipelineWithData(small).StratifiedSample(key, 5, 1).Execute()
+	if !reflect.DeepEqual(result, small) {
+		t.Errorf("Expected small groups to be kept entirely, got %v", result)
+	}
+
+	if _, err := NewPipelineWithData(small).StratifiedSample(key, -1, 1).Execute(); err == nil {
+		t.Error("Expected error for negative group sample size")
+	}
+}
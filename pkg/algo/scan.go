@@ -0,0 +1,50 @@
+package algo
+
+// ScanOperation computes a running accumulation over the data.
+// It emits the accumulator after each element, so the output has the same length as the input.
+type ScanOperation[T any] struct {
+	Initial     T
+	Accumulator func(acc, item T) T
+}
+
+// Apply performs the scan operation on the data.
+// It returns a new slice where the i-th element is the accumulation of the first i+1 elements.
+// The initial value itself is not emitted, and an empty input yields an empty output.
+//
+// Example:
+//
+//	pipeline := NewPipeline[int]().
+//	    Scan(0, func(acc, item int) int { return acc + item })
+//	result, err := pipeline.Execute() // Running totals
+func (s *ScanOperation[T]) Apply(data []T) ([]T, error) {
+	scannedData := make([]T, len(data))
+	acc := s.Initial
+	for i := 0; i < len(data); i++ {
+		acc = s.Accumulator(acc, data[i])
+		scannedData[i] = acc
+	}
+	return scannedData, nil
+}
+
+// Stream implements Streamer, passing on the accumulator after every element.
+func (s *ScanOperation[T]) Stream(emit func(T) bool) func(T) bool {
+	acc := s.Initial
+	return func(item T) bool {
+		acc = s.Accumulator(acc, item)
+		return emit(acc)
+	}
+}
+
+// Scan adds a scan operation to the pipeline.
+// The accumulator function combines the running value with each item, starting from init.
+//
+// Example:
+//
+//	pipeline.Scan(DailyBalance{}, func(acc, day DailyBalance) DailyBalance {
+//	    day.Balance += acc.Balance // Cumulative balance per day
+//	    return day
+//	})
+func (p *Pipeline[T]) Scan(init T, accumulator func(acc, item T) T) *Pipeline[T] {
+	p.operations = append(p.operations, &ScanOperation[T]{Initial: init, Accumulator: accumulator})
+	return p
+}
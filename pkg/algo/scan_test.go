@@ -0,0 +1,76 @@
+package algo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScanOperation_RunningTotal(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Scan(0, func(acc, item int) int { return acc + item })
+
+	data := []int{1, 2, 3, 4}
+	expected := []int{1, 3, 6, 10}
+
+	pipeline.WithData(data)
+
+	scannedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ScanOperation failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(scannedData, expected) {
+		t.Errorf("Expected %v, got %v", expected, scannedData)
+	}
+}
+
+func TestScanOperation_CumulativeMax(t *testing.T) {
+	pipeline := NewPipeline[Item]().
+		Scan(Item{}, func(acc, item Item) Item {
+			if item.ID > acc.ID {
+				return item
+			}
+			return acc
+		})
+
+	data := []Item{
+		{ID: 2, Name: "Item2", Active: true},
+		{ID: 1, Name: "Item1", Active: false},
+		{ID: 3, Name: "Item3", Active: true},
+	}
+
+	expected := []Item{
+		{ID: 2, Name: "Item2", Active: true},
+		{ID: 2, Name: "Item2", Active: true},
+		{ID: 3, Name: "Item3", Active: true},
+	}
+
+	pipeline.WithData(data)
+
+	scannedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ScanOperation failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(scannedData, expected) {
+		t.Errorf("Expected %v, got %v", expected, scannedData)
+	}
+}
+
+func TestScanOperation_EmptySlice(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Scan(100, func(acc, item int) int { return acc + item })
+
+	var data []int
+
+	pipeline.WithData(data)
+
+	scannedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ScanOperation failed on empty slice: %v", err)
+	}
+
+	if len(scannedData) != 0 {
+		t.Errorf("Expected no items, but got %+v", scannedData)
+	}
+}
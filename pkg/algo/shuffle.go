@@ -0,0 +1,40 @@
+package algo
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// ShuffleOperation randomly permutes the data using the Fisher-Yates algorithm.
+// The same Seed always produces the same permutation of the same input.
+type ShuffleOperation[T any] struct {
+	Seed int64
+}
+
+// Apply performs the shuffle operation on the data.
+// It returns a new slice; the input slice is left unchanged.
+//
+// Example:
+//
+//	pipeline := NewPipeline[int]().
+//	    Shuffle(42)
+//	result, err := pipeline.Execute()
+func (s *ShuffleOperation[T]) Apply(data []T) ([]T, error) {
+	shuffledData := slices.Clone(data)
+	rng := rand.New(rand.NewSource(s.Seed))
+	for i := len(shuffledData) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		shuffledData[i], shuffledData[j] = shuffledData[j], shuffledData[i]
+	}
+	return shuffledData, nil
+}
+
+// Shuffle adds a shuffle operation to the pipeline.
+//
+// Example:
+//
+//	pipeline.Shuffle(2024).Take(20) // 20 random elements in random order
+func (p *Pipeline[T]) Shuffle(seed int64) *Pipeline[T] {
+	p.operations = append(p.operations, &ShuffleOperation[T]{Seed: seed})
+	return p
+}
@@ -0,0 +1,57 @@
+package algo
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestShuffleOperation(t *testing.T) {
+	data := rangeInts(100)
+	result, err := NewPipelineWithData(data).Shuffle(42).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if reflect.DeepEqual(result, data) {
+		t.Error("Expected the order to change")
+	}
+	if !reflect.DeepEqual(data, rangeInts(100)) {
+		t.Error("Expected the input to be left unchanged")
+	}
+
+	sorted := append([]int(nil), result...)
+	sort.Ints(sorted)
+	if !reflect.DeepEqual(sorted, data) {
+		t.Error("Expected a permutation of the input")
+	}
+
+	again, _ := NewPipelineWithData(data).Shuffle(42).Execute()
+	if !reflect.DeepEqual(result, again) {
+		t.Error("Expected the same seed to produce the same permutation")
+	}
+}
+
+func TestShuffleOperation_Uniform(t *testing.T) {
+	// Each of the 6 permutations of 3 elements should appear about 1/6 of the time.
+	counts := make(map[[3]int]int)
+	for seed := int64(0); seed < 6000; seed++ {
+		result, _ := NewPipelineWithData([]int{0, 1, 2}).Shuffle(seed).Execute()
+		counts[[3]int{result[0], result[1], result[2]}]++
+	}
+	if len(counts) != 6 {
+		t.Fatalf("Expected 6 permutations, got %d", len(counts))
+	}
+	for permutation, n := range counts {
+		if n < 850 || n > 1150 {
+			t.Errorf("Permutation %v appeared %d times, expected about 1000", permutation, n)
+		}
+	}
+}
+
+func TestShuffleOperation_Empty(t *testing.T) {
+	result, err := NewPipelineWithData([]int{}).Shuffle(1).Execute()
+	if err != nil || len(result) != 0 {
+		t.Errorf("Expected empty result, got %v (%v)", result, err)
+	}
+}
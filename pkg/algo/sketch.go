@@ -0,0 +1,73 @@
+package algo
+
+// Sketch is a probabilistic summary that ingests string keys.
+// HyperLogLog, CountMinSketch, SpaceSaving and BloomFilter all implement it, so any combination of them
+// can be filled in a single pass with the Sketch terminal.
+type Sketch interface {
+	AddString(key string)
+}
+
+// Sketch executes the pipeline and adds the key of every element in its result to each sketch.
+// Sketches filled on different shards can later be combined with their Merge methods.
+// Returns an error if any operation fails.
+//
+// Example:
+//
+//	visitors, _ := NewHyperLogLog(14)
+//	pages, _ := NewSpaceSaving(100)
+//	err := NewPipelineWithData(clicks).
+//	    Filter(func(c Click) bool { return !c.Bot }).
+//	    Sketch(func(c Click) string { return c.UserID }, visitors)
+func (p *Pipeline[T]) Sketch(key func(T) string, sketches ...Sketch) error {
+	data, err := p.Execute()
+	if err != nil {
+		return err
+	}
+
+	for _, item := range data {
+		k := key(item)
+		for _, sketch := range sketches {
+			sketch.AddString(k)
+		}
+	}
+	return nil
+}
+
+// ApproxCountDistinct executes the pipeline and estimates the number of distinct keys in its result
+// using a HyperLogLog sketch with a standard error of about 0.8%.
+// Returns an error if any operation fails.
+//
+// Example:
+//
+//	visitors, err := NewPipelineWithData(clicks).
+//	    ApproxCountDistinct(func(c Click) string { return c.UserID })
+func (p *Pipeline[T]) ApproxCountDistinct(key func(T) string) (uint64, error) {
+	hll, err := NewHyperLogLog(14)
+	if err != nil {
+		return 0, err
+	}
+	if err := p.Sketch(key, hll); err != nil {
+		return 0, err
+	}
+	return hll.Count(), nil
+}
+
+// HeavyHitters executes the pipeline and returns up to k of the most frequent keys in its result,
+// tracked by a SpaceSaving summary that monitors capacity keys.
+// A capacity several times larger than k makes the reported counts more accurate.
+// Returns an error if any operation fails or capacity is not positive.
+//
+// Example:
+//
+//	topPages, err := NewPipelineWithData(clicks).
+//	    HeavyHitters(func(c Click) string { return c.Path }, 10, 1000)
+func (p *Pipeline[T]) HeavyHitters(key func(T) string, k, capacity int) ([]HeavyHitter, error) {
+	summary, err := NewSpaceSaving(capacity)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Sketch(key, summary); err != nil {
+		return nil, err
+	}
+	return summary.TopK(k), nil
+}
@@ -0,0 +1,100 @@
+package algo
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+type Click struct {
+	UserID string
+	Path   string
+	Bot    bool
+}
+
+func generateClicks(n int) []Click {
+	clicks := make([]Click, n)
+	for i := range clicks {
+		clicks[i] = Click{
+			UserID: "user-" + strconv.Itoa(i%3000),
+			Path:   "/page/" + strconv.Itoa(i%7*(i%3)),
+			Bot:    i%10 == 0,
+		}
+	}
+	return clicks
+}
+
+func TestPipeline_Sketch(t *testing.T) {
+	clicks := generateClicks(30000)
+	hll, _ := NewHyperLogLog(14)
+	cms, _ := NewCountMinSketch(1000, 4)
+	ss, _ := NewSpaceSaving(20)
+
+	err := NewPipelineWithData(clicks).
+		Filter(func(c Click) bool { return !c.Bot }).
+		Sketch(func(c Click) string { return c.Path }, hll, cms, ss)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	exact := make(map[string]uint64)
+	for _, c := range clicks {
+		if !c.Bot {
+			exact[c.Path]++
+		}
+	}
+	if hll.Count() != uint64(len(exact)) {
+		t.Errorf("Expected %d distinct paths, got %d", len(exact), hll.Count())
+	}
+	for path, count := range exact {
+		if cms.Estimate(path) < count {
+			t.Errorf("%s: estimate %d below true count %d", path, cms.Estimate(path), count)
+		}
+		if monitored, _ := ss.Estimate(path); monitored != count {
+			t.Errorf("%s: expected exact count %d, got %d", path, count, monitored)
+		}
+	}
+}
+
+func TestPipeline_ApproxCountDistinct(t *testing.T) {
+	clicks := generateClicks(30000)
+	result, err := NewPipelineWithData(clicks).
+		ApproxCountDistinct(func(c Click) string { return c.UserID })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(float64(result)-3000)/3000 > 0.02 {
+		t.Errorf("Expected about 3000 distinct users, got %d", result)
+	}
+}
+
+func TestPipeline_HeavyHitters(t *testing.T) {
+	clicks := []Click{{Path: "/a"}, {Path: "/b"}, {Path: "/a"}, {Path: "/c"}, {Path: "/a"}, {Path: "/b"}}
+	result, err := NewPipelineWithData(clicks).
+		HeavyHitters(func(c Click) string { return c.Path }, 2, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 2 || result[0] != (HeavyHitter{Key: "/a", Count: 3}) || result[1] != (HeavyHitter{Key: "/b", Count: 2}) {
+		t.Errorf("Unexpected heavy hitters: %v", result)
+	}
+
+	if _, err := NewPipelineWithData(clicks).HeavyHitters(func(c Click) string { return c.Path }, 2, 0); err == nil {
+		t.Error("Expected error for zero capacity")
+	}
+}
+
+func TestPipeline_SketchPropagatesError(t *testing.T) {
+	hll, _ := NewHyperLogLog(10)
+	err := NewPipelineWithData([]Click{}).
+		Reduce(func(a, b Click) Click { return a }).
+		Sketch(func(c Click) string { return c.UserID }, hll)
+	if err == nil {
+		t.Error("Expected error from Sketch")
+	}
+	if _, err := NewPipelineWithData([]Click{}).
+		Reduce(func(a, b Click) Click { return a }).
+		ApproxCountDistinct(func(c Click) string { return c.UserID }); err == nil {
+		t.Error("Expected error from ApproxCountDistinct")
+	}
+}
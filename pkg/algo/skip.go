@@ -25,6 +25,18 @@ func (s *SkipOperation[T]) Apply(data []T) ([]T, error) {
 	return skippedData, nil
 }
 
+// Stream implements Streamer, dropping the first Count elements and passing on the rest.
+func (s *SkipOperation[T]) Stream(emit func(T) bool) func(T) bool {
+	skipped := 0
+	return func(item T) bool {
+		if skipped < s.Count {
+			skipped++
+			return true
+		}
+		return emit(item)
+	}
+}
+
 // Skip adds a skip operation to the pipeline.
 // The count parameter specifies how many elements to skip from the start.
 //
@@ -0,0 +1,132 @@
+package algo
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SortedIndex is a prebuilt, ordered copy of a dataset that answers equality, range and
+// prefix queries with binary search instead of scanning the data.
+// A SortedIndex is safe for concurrent use.
+type SortedIndex[T comparable] struct {
+	mu    sync.RWMutex
+	less  func(a, b T) bool
+	items []T
+}
+
+// BuildSortedIndex builds a sorted index over a copy of data ordered by the less function.
+// Elements that compare equal keep their original order.
+//
+// Example:
+//
+//	index := BuildSortedIndex(orders, func(a, b Order) bool { return a.Amount < b.Amount })
+//	result, err := NewPipelineWithData(index.LookupRange(Order{Amount: 100}, Order{Amount: 500})).
+//	    Take(20).
+//	    Execute()
+func BuildSortedIndex[T comparable](data []T, less func(a, b T) bool) *SortedIndex[T] {
+	items := make([]T, len(data))
+	copy(items, data)
+	slices.SortStableFunc(items, func(a, b T) int {
+		if less(a, b) {
+			return -1
+		}
+		if less(b, a) {
+			return 1
+		}
+		return 0
+	})
+	return &SortedIndex[T]{less: less, items: items}
+}
+
+// Lookup returns the elements that compare equal to target.
+// The returned slice is a copy, so it can be passed to a pipeline that sorts in place.
+func (s *SortedIndex[T]) Lookup(target T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	start := s.lowerBound(target)
+	end := start + sort.Search(len(s.items)-start, func(i int) bool {
+		return s.less(target, s.items[start+i])
+	})
+	return slices.Clone(s.items[start:end])
+}
+
+// LookupRange returns the elements x with low <= x < high in index order.
+// The returned slice is a copy, so it can be passed to a pipeline that sorts in place.
+func (s *SortedIndex[T]) LookupRange(low, high T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	start := s.lowerBound(low)
+	end := start + sort.Search(len(s.items)-start, func(i int) bool {
+		return !s.less(s.items[start+i], high)
+	})
+	return slices.Clone(s.items[start:end])
+}
+
+// LookupPrefix returns the elements whose key starts with prefix in index order.
+// The index must be ordered by the same key, compared as strings, for the matches to be contiguous.
+//
+// Example:
+//
+//	index := BuildSortedIndex(users, func(a, b User) bool { return a.Name < b.Name })
+//	matches := index.LookupPrefix(func(u User) string { return u.Name }, "Al")
+func (s *SortedIndex[T]) LookupPrefix(key func(T) string, prefix string) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	start := sort.Search(len(s.items), func(i int) bool {
+		return key(s.items[i]) >= prefix
+	})
+	end := start
+	for end < len(s.items) && strings.HasPrefix(key(s.items[end]), prefix) {
+		end++
+	}
+	return slices.Clone(s.items[start:end])
+}
+
+// Insert adds elements to the index at their sorted positions.
+// Each element is placed after any elements that compare equal to it.
+func (s *SortedIndex[T]) Insert(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range items {
+		i := sort.Search(len(s.items), func(i int) bool {
+			return s.less(item, s.items[i])
+		})
+		s.items = slices.Insert(s.items, i, item)
+	}
+}
+
+// Delete removes the first element equal to item from the index.
+// Returns false if the index does not contain item.
+func (s *SortedIndex[T]) Delete(item T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := s.lowerBound(item); i < len(s.items) && !s.less(item, s.items[i]); i++ {
+		if s.items[i] == item {
+			s.items = slices.Delete(s.items, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of elements in the index.
+func (s *SortedIndex[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.items)
+}
+
+// lowerBound returns the index of the first element that is not less than target.
+func (s *SortedIndex[T]) lowerBound(target T) int {
+	return sort.Search(len(s.items), func(i int) bool {
+		return !s.less(s.items[i], target)
+	})
+}
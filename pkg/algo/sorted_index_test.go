@@ -0,0 +1,109 @@
+package algo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func lessOrderByUser(a, b Order) bool {
+	return a.UserID < b.UserID
+}
+
+func TestSortedIndex_LookupMatchesFind(t *testing.T) {
+	data := randomOrders(500, 2)
+	index := BuildSortedIndex(data, lessOrderByUser)
+
+	for userID := -1; userID <= 20; userID++ {
+		expected := findOrders(data, func(o Order) bool { return o.UserID == userID })
+		result := index.Lookup(Order{UserID: userID})
+
+		if len(result) != len(expected) {
+			t.Fatalf("UserID %d: expected %d items, got %d", userID, len(expected), len(result))
+		}
+		for i := range expected {
+			if result[i] != expected[i] {
+				t.Errorf("UserID %d: at index %d, expected %+v, got %+v", userID, i, expected[i], result[i])
+			}
+		}
+	}
+}
+
+func TestSortedIndex_LookupRangeMatchesFind(t *testing.T) {
+	data := randomOrders(500, 3)
+	index := BuildSortedIndex(data, lessOrderByUser)
+
+	for low := 0; low < 20; low += 3 {
+		high := low + 5
+		expected := findOrders(data, func(o Order) bool { return o.UserID >= low && o.UserID < high })
+		result := index.LookupRange(Order{UserID: low}, Order{UserID: high})
+
+		if len(result) != len(expected) {
+			t.Fatalf("Range [%d, %d): expected %d items, got %d", low, high, len(expected), len(result))
+		}
+		for i := 1; i < len(result); i++ {
+			if lessOrderByUser(result[i], result[i-1]) {
+				t.Fatalf("Range [%d, %d): result is not sorted at index %d", low, high, i)
+			}
+		}
+	}
+}
+
+func TestSortedIndex_LookupPrefix(t *testing.T) {
+	data := []User{
+		{ID: 1, Name: "Bob"},
+		{ID: 2, Name: "Alice"},
+		{ID: 3, Name: "Alfred"},
+		{ID: 4, Name: "Albert"},
+		{ID: 5, Name: "Charlie"},
+	}
+	index := BuildSortedIndex(data, func(a, b User) bool { return a.Name < b.Name })
+
+	result := index.LookupPrefix(func(u User) string { return u.Name }, "Al")
+
+	expected := []User{
+		{ID: 4, Name: "Albert"},
+		{ID: 3, Name: "Alfred"},
+		{ID: 2, Name: "Alice"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if result := index.LookupPrefix(func(u User) string { return u.Name }, "Z"); len(result) != 0 {
+		t.Errorf("Expected no items, but got %v", result)
+	}
+}
+
+func TestSortedIndex_InsertDeleteMatchesFind(t *testing.T) {
+	data := randomOrders(200, 4)
+	index := BuildSortedIndex(data[:100], lessOrderByUser)
+
+	index.Insert(data[100:]...)
+	for i := 0; i < len(data); i += 3 {
+		if !index.Delete(data[i]) {
+			t.Fatalf("Expected Delete to find %+v", data[i])
+		}
+	}
+
+	var remaining []Order
+	for i := range data {
+		if i%3 != 0 {
+			remaining = append(remaining, data[i])
+		}
+	}
+
+	if index.Len() != len(remaining) {
+		t.Fatalf("Expected length %d, got %d", len(remaining), index.Len())
+	}
+	for userID := 0; userID < 20; userID++ {
+		expected := findOrders(remaining, func(o Order) bool { return o.UserID == userID })
+		result := index.Lookup(Order{UserID: userID})
+		if len(result) != len(expected) {
+			t.Fatalf("UserID %d: expected %d items, got %d", userID, len(expected), len(result))
+		}
+	}
+
+	if index.Delete(Order{OrderID: -1, UserID: 5}) {
+		t.Errorf("Expected Delete of a missing item to report false")
+	}
+}
@@ -0,0 +1,266 @@
+package algo
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// spaceSavingVersion is the version byte written by SpaceSaving.MarshalBinary.
+const spaceSavingVersion = 1
+
+// HeavyHitter is a frequently occurring key reported by SpaceSaving.
+// Count never underestimates the true frequency and exceeds it by at most Error.
+type HeavyHitter struct {
+	Key   string
+	Count uint64
+	Error uint64
+}
+
+// SpaceSaving tracks the most frequent keys of a stream while monitoring at most capacity keys.
+// Every key occurring more than N/capacity times, where N is the total count added, is guaranteed
+// to be monitored. When a new key arrives and the summary is full, it replaces the key with the
+// smallest count and inherits that count as its error bound.
+// Summaries can be merged, so shards can be processed independently.
+type SpaceSaving struct {
+	capacity int
+	total    uint64
+	heap     []*spaceSavingEntry
+	entries  map[string]*spaceSavingEntry
+}
+
+// spaceSavingEntry is a monitored key and its position in the min-heap.
+type spaceSavingEntry struct {
+	HeavyHitter
+	pos int
+}
+
+// NewSpaceSaving creates a summary that monitors at most capacity keys.
+// Returns an error if capacity is not positive.
+//
+// Example:
+//
+//	ss, err := NewSpaceSaving(100)
+//	ss.AddString("/checkout")
+//	top := ss.TopK(10)
+func NewSpaceSaving(capacity int) (*SpaceSaving, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("SpaceSaving: capacity must be positive, got %d", capacity)
+	}
+	return &SpaceSaving{
+		capacity: capacity,
+		heap:     make([]*spaceSavingEntry, 0, capacity),
+		entries:  make(map[string]*spaceSavingEntry, capacity),
+	}, nil
+}
+
+// AddString adds a single occurrence of a key.
+func (s *SpaceSaving) AddString(key string) {
+	s.AddStringCount(key, 1)
+}
+
+// AddStringCount adds count occurrences of a key.
+func (s *SpaceSaving) AddStringCount(key string, count uint64) {
+	s.add(key, count, 0)
+}
+
+// add increments a key by count, adding extraError to its error bound if it is newly monitored.
+func (s *SpaceSaving) add(key string, count, extraError uint64) {
+	s.total += count
+	if entry, ok := s.entries[key]; ok {
+		entry.Count += count
+		entry.Error += extraError
+		s.siftDown(entry.pos)
+		return
+	}
+
+	if len(s.heap) < s.capacity {
+		entry := &spaceSavingEntry{HeavyHitter: HeavyHitter{Key: key, Count: count, Error: extraError}, pos: len(s.heap)}
+		s.heap = append(s.heap, entry)
+		s.entries[key] = entry
+		s.siftUp(entry.pos)
+		return
+	}
+
+	evicted := s.heap[0]
+	delete(s.entries, evicted.Key)
+	evicted.Error = evicted.Count + extraError
+	evicted.Count += count
+	evicted.Key = key
+	s.entries[key] = evicted
+	s.siftDown(0)
+}
+
+// Estimate returns the estimated count of a key and its error bound.
+// Keys that are not monitored report a count of zero.
+func (s *SpaceSaving) Estimate(key string) (count, errorBound uint64) {
+	if entry, ok := s.entries[key]; ok {
+		return entry.Count, entry.Error
+	}
+	return 0, 0
+}
+
+// TopK returns up to k monitored keys ordered by count descending, then key ascending.
+func (s *SpaceSaving) TopK(k int) []HeavyHitter {
+	hitters := make([]HeavyHitter, len(s.heap))
+	for i, entry := range s.heap {
+		hitters[i] = entry.HeavyHitter
+	}
+	sortHeavyHitters(hitters)
+	return hitters[:min(max(k, 0), len(hitters))]
+}
+
+// Total returns the sum of all counts added.
+func (s *SpaceSaving) Total() uint64 {
+	return s.total
+}
+
+// Merge combines another summary into s.
+// A key monitored by only one summary may have been evicted from the other, so when the other
+// summary is full its smallest count is added to the key as additional error.
+// If the combined keys exceed the capacity, the keys with the smallest counts are dropped.
+func (s *SpaceSaving) Merge(other *SpaceSaving) error {
+	if s.capacity != other.capacity {
+		return fmt.Errorf("SpaceSaving: cannot merge capacity %d into %d", other.capacity, s.capacity)
+	}
+
+	ownMin, otherMin := s.minCount(), other.minCount()
+	merged := make([]HeavyHitter, 0, len(s.heap)+len(other.heap))
+	for _, entry := range s.heap {
+		hitter := entry.HeavyHitter
+		if match, ok := other.entries[hitter.Key]; ok {
+			hitter.Count += match.Count
+			hitter.Error += match.Error
+		} else {
+			hitter.Count += otherMin
+			hitter.Error += otherMin
+		}
+		merged = append(merged, hitter)
+	}
+	for _, entry := range other.heap {
+		if _, ok := s.entries[entry.Key]; !ok {
+			merged = append(merged, HeavyHitter{Key: entry.Key, Count: entry.Count + ownMin, Error: entry.Error + ownMin})
+		}
+	}
+	sortHeavyHitters(merged)
+
+	total := s.total + other.total
+	s.heap = s.heap[:0]
+	clear(s.entries)
+	for _, hitter := range merged[:min(s.capacity, len(merged))] {
+		s.add(hitter.Key, hitter.Count, hitter.Error)
+	}
+	s.total = total
+	return nil
+}
+
+// minCount returns the smallest monitored count if the summary is full, and zero otherwise,
+// which bounds the count of any key that is not monitored.
+func (s *SpaceSaving) minCount() uint64 {
+	if len(s.heap) < s.capacity {
+		return 0
+	}
+	return s.heap[0].Count
+}
+
+// sortHeavyHitters orders hitters by count descending, then key ascending.
+func sortHeavyHitters(hitters []HeavyHitter) {
+	slices.SortFunc(hitters, func(a, b HeavyHitter) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+}
+
+// MarshalBinary encodes the summary so that it can be persisted or sent to another process.
+func (s *SpaceSaving) MarshalBinary() ([]byte, error) {
+	data := []byte{spaceSavingVersion}
+	data = binary.AppendUvarint(data, uint64(s.capacity))
+	data = binary.AppendUvarint(data, s.total)
+	data = binary.AppendUvarint(data, uint64(len(s.heap)))
+	for _, entry := range s.heap {
+		data = binary.AppendUvarint(data, uint64(len(entry.Key)))
+		data = append(data, entry.Key...)
+		data = binary.AppendUvarint(data, entry.Count)
+		data = binary.AppendUvarint(data, entry.Error)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a summary encoded by MarshalBinary, replacing the contents of s.
+func (s *SpaceSaving) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != spaceSavingVersion {
+		return errors.New("SpaceSaving: unsupported encoding")
+	}
+	r := &uvarintReader{data: data[1:]}
+	capacity, total, n := r.next(), r.next(), r.next()
+	if r.err != nil || capacity == 0 || n > capacity || capacity > uint64(len(data))*1024 {
+		return errors.New("SpaceSaving: corrupt header")
+	}
+
+	decoded, err := NewSpaceSaving(int(capacity))
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < n; i++ {
+		length := r.next()
+		if r.err != nil || length > uint64(len(r.data)) {
+			return errors.New("SpaceSaving: corrupt entry")
+		}
+		key := string(r.data[:length])
+		r.data = r.data[length:]
+		count, errorBound := r.next(), r.next()
+		if r.err != nil {
+			return errors.New("SpaceSaving: corrupt entry")
+		}
+		decoded.add(key, count, errorBound)
+	}
+	if len(r.data) != 0 {
+		return errors.New("SpaceSaving: trailing data")
+	}
+	decoded.total = total
+	*s = *decoded
+	return nil
+}
+
+// siftUp moves the entry at position i towards the root until the heap property holds.
+func (s *SpaceSaving) siftUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if s.heap[parent].Count <= s.heap[i].Count {
+			return
+		}
+		s.swap(i, parent)
+		i = parent
+	}
+}
+
+// siftDown moves the entry at position i towards the leaves until the heap property holds.
+func (s *SpaceSaving) siftDown(i int) {
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+		if left < len(s.heap) && s.heap[left].Count < s.heap[smallest].Count {
+			smallest = left
+		}
+		if right < len(s.heap) && s.heap[right].Count < s.heap[smallest].Count {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		s.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// swap exchanges two heap positions and keeps their recorded positions in sync.
+func (s *SpaceSaving) swap(i, j int) {
+	s.heap[i], s.heap[j] = s.heap[j], s.heap[i]
+	s.heap[i].pos = i
+	s.heap[j].pos = j
+}
@@ -0,0 +1,145 @@
+package algo
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// zipfKeys returns n keys whose frequencies follow a Zipf distribution, plus their exact counts.
+func zipfKeys(n int, seed int64) ([]string, map[string]uint64) {
+	zipf := rand.NewZipf(rand.New(rand.NewSource(seed)), 1.2, 1, 10000)
+	keys := make([]string, n)
+	exact := make(map[string]uint64)
+	for i := range keys {
+		keys[i] = "item-" + strconv.FormatUint(zipf.Uint64(), 10)
+		exact[keys[i]]++
+	}
+	return keys, exact
+}
+
+func TestNewSpaceSaving_InvalidCapacity(t *testing.T) {
+	if _, err := NewSpaceSaving(0); err == nil {
+		t.Error("Expected error for zero capacity")
+	}
+}
+
+func TestSpaceSaving_ExactWhenUnderCapacity(t *testing.T) {
+	ss, _ := NewSpaceSaving(10)
+	for _, key := range []string{"b", "a", "c", "a", "b", "a"} {
+		ss.AddString(key)
+	}
+
+	expected := []HeavyHitter{{Key: "a", Count: 3}, {Key: "b", Count: 2}, {Key: "c", Count: 1}}
+	if result := ss.TopK(5); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if result := ss.TopK(1); len(result) != 1 || result[0].Key != "a" {
+		t.Errorf("Expected only a, got %v", result)
+	}
+	if count, errorBound := ss.Estimate("missing"); count != 0 || errorBound != 0 {
+		t.Errorf("Expected 0 for missing key, got %d±%d", count, errorBound)
+	}
+}
+
+func TestSpaceSaving_Guarantees(t *testing.T) {
+	keys, exact := zipfKeys(50000, 1)
+	ss, _ := NewSpaceSaving(200)
+	for _, key := range keys {
+		ss.AddString(key)
+	}
+
+	for _, hitter := range ss.TopK(200) {
+		trueCount := exact[hitter.Key]
+		if hitter.Count < trueCount || hitter.Count-hitter.Error > trueCount {
+			t.Errorf("%s: count %d±%d does not bound the true count %d", hitter.Key, hitter.Count, hitter.Error, trueCount)
+		}
+	}
+
+	threshold := ss.Total() / 200
+	for key, count := range exact {
+		if count > threshold {
+			if monitored, _ := ss.Estimate(key); monitored == 0 {
+				t.Errorf("%s occurs %d times but is not monitored", key, count)
+			}
+		}
+	}
+
+	top := ss.TopK(3)
+	for i, key := range []string{"item-0", "item-1", "item-2"} {
+		if top[i].Key != key {
+			t.Errorf("At rank %d, expected %s, got %s", i, key, top[i].Key)
+		}
+	}
+}
+
+func TestSpaceSaving_Merge(t *testing.T) {
+	keys, exact := zipfKeys(40000, 2)
+	shardA, _ := NewSpaceSaving(100)
+	shardB, _ := NewSpaceSaving(100)
+	for i, key := range keys {
+		if i%2 == 0 {
+			shardA.AddString(key)
+		} else {
+			shardB.AddStringCount(key, 1)
+		}
+	}
+
+	if err := shardA.Merge(shardB); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if shardA.Total() != 40000 {
+		t.Errorf("Expected total 40000, got %d", shardA.Total())
+	}
+	if len(shardA.TopK(1000)) != 100 {
+		t.Errorf("Expected merged summary to keep 100 keys, got %d", len(shardA.TopK(1000)))
+	}
+	for _, hitter := range shardA.TopK(100) {
+		trueCount := exact[hitter.Key]
+		if hitter.Count < trueCount || hitter.Count-hitter.Error > trueCount {
+			t.Errorf("%s: count %d±%d does not bound the true count %d", hitter.Key, hitter.Count, hitter.Error, trueCount)
+		}
+	}
+	if top := shardA.TopK(1); top[0].Key != "item-0" {
+		t.Errorf("Expected item-0 on top, got %v", top[0])
+	}
+
+	other, _ := NewSpaceSaving(50)
+	if err := shardA.Merge(other); err == nil {
+		t.Error("Expected error when merging different capacities")
+	}
+}
+
+func TestSpaceSaving_MarshalRoundTrip(t *testing.T) {
+	keys, _ := zipfKeys(5000, 3)
+	ss, _ := NewSpaceSaving(50)
+	for _, key := range keys {
+		ss.AddString(key)
+	}
+
+	data, err := ss.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded SpaceSaving
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.TopK(50), ss.TopK(50)) || decoded.Total() != ss.Total() {
+		t.Error("Decoded summary differs from the original")
+	}
+
+	// The decoded summary keeps working as a live summary.
+	before, _ := ss.Estimate("item-0")
+	decoded.AddString("item-0")
+	if count, _ := decoded.Estimate("item-0"); count != before+1 {
+		t.Errorf("Expected %d after decoding and adding, got %d", before+1, count)
+	}
+
+	for _, corrupt := range [][]byte{nil, {7}, data[:len(data)-1], append(data, 0)} {
+		if err := decoded.UnmarshalBinary(corrupt); err == nil {
+			t.Errorf("Expected error for corrupt encoding of length %d", len(corrupt))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package algo
+
+import "fmt"
+
+// SplitRouter routes the output of a pipeline to downstream sub-pipelines by key.
+// Each sub-pipeline acts as a template: its operations are applied to the elements routed
+// to it, and any data set on it is ignored.
+type SplitRouter[T comparable, K comparable] struct {
+	source   *Pipeline[T]
+	key      func(T) K
+	routes   map[K]*Pipeline[T]
+	fallback *Pipeline[T]
+}
+
+// SplitBy creates a router that groups the output of p by the key function.
+// Elements whose key has no route and no default are returned unchanged under their key.
+//
+// Example:
+//
+//	results, err := SplitBy(NewPipelineWithData(orders), func(o Order) string { return o.Region }).
+//	    Route("eu", NewPipeline[Order]().Filter(isVATRegistered)).
+//	    Route("us", NewPipeline[Order]().QuickSort(byAmount)).
+//	    Default(NewPipeline[Order]().Take(10)).
+//	    Execute()
+func SplitBy[T comparable, K comparable](p *Pipeline[T], key func(T) K) *SplitRouter[T, K] {
+	return &SplitRouter[T, K]{source: p, key: key, routes: make(map[K]*Pipeline[T])}
+}
+
+// Route sends the elements with the given key to the sub-pipeline.
+// Returns the router for method chaining.
+func (r *SplitRouter[T, K]) Route(key K, sub *Pipeline[T]) *SplitRouter[T, K] {
+	r.routes[key] = sub
+	return r
+}
+
+// Default sends the elements whose key has no route to the sub-pipeline.
+// Returns the router for method chaining.
+func (r *SplitRouter[T, K]) Default(sub *Pipeline[T]) *SplitRouter[T, K] {
+	r.fallback = sub
+	return r
+}
+
+// Execute runs the source pipeline once, splits its output by key and runs every group
+// through its sub-pipeline.
+// Returns the results of every group keyed by the group key, or an error if any pipeline fails.
+func (r *SplitRouter[T, K]) Execute() (map[K][]T, error) {
+	data, err := r.source.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[K][]T)
+	for _, item := range data {
+		key := r.key(item)
+		groups[key] = append(groups[key], item)
+	}
+
+	results := make(map[K][]T, len(groups))
+	for key, items := range groups {
+		sub, ok := r.routes[key]
+		if !ok {
+			sub = r.fallback
+		}
+		if sub == nil {
+			results[key] = items
+			continue
+		}
+
+		routed, err := sub.run(items)
+		if err != nil {
+			return nil, fmt.Errorf("SplitBy: route %v: %w", key, err)
+		}
+		results[key] = routed
+	}
+	return results, nil
+}
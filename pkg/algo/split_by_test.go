@@ -0,0 +1,95 @@
+package algo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitBy_RoutesToSubPipelines(t *testing.T) {
+	data := []Order{
+		{OrderID: 1, UserID: 1, Item: "Book"},
+		{OrderID: 2, UserID: 2, Item: "Pen"},
+		{OrderID: 3, UserID: 1, Item: "Paper"},
+		{OrderID: 4, UserID: 2, Item: "Ink"},
+		{OrderID: 5, UserID: 3, Item: "Desk"},
+	}
+
+	results, err := SplitBy(NewPipelineWithData(data), func(o Order) int { return o.UserID }).
+		Route(1, NewPipeline[Order]().QuickSort(func(a, b Order) bool { return a.Item < b.Item })).
+		Route(2, NewPipeline[Order]().Filter(func(o Order) bool { return o.Item != "Ink" })).
+		Execute()
+	if err != nil {
+		t.Fatalf("SplitBy failed: %v", err)
+	}
+
+	expected := map[int][]Order{
+		1: {
+			{OrderID: 1, UserID: 1, Item: "Book"},
+			{OrderID: 3, UserID: 1, Item: "Paper"},
+		},
+		2: {
+			{OrderID: 2, UserID: 2, Item: "Pen"},
+		},
+		3: {
+			{OrderID: 5, UserID: 3, Item: "Desk"},
+		},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %v, got %v", expected, results)
+	}
+}
+
+func TestSplitBy_Default(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6}
+
+	results, err := SplitBy(NewPipelineWithData(data), func(x int) string {
+		if x%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	}).
+		Route("even", NewPipeline[int]().Map(func(x int) int { return x * 10 })).
+		Default(NewPipeline[int]().Take(1)).
+		Execute()
+	if err != nil {
+		t.Fatalf("SplitBy failed: %v", err)
+	}
+
+	expected := map[string][]int{
+		"even": {20, 40, 60},
+		"odd":  {1},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %v, got %v", expected, results)
+	}
+}
+
+func TestSplitBy_RouteError(t *testing.T) {
+	_, err := SplitBy(NewPipelineWithData([]int{1, 2, 3}), func(x int) bool { return x > 1 }).
+		Route(true, NewPipeline[int]().LinearSearchExact(42)).
+		Execute()
+	if err == nil {
+		t.Fatalf("Expected error from failing route, but got nil")
+	}
+
+	expectedError := "SplitBy: route true: target not found in data"
+	if err.Error() != expectedError {
+		t.Errorf("Expected error message '%s', got '%s'", expectedError, err.Error())
+	}
+}
+
+func TestSplitBy_SubPipelineReusable(t *testing.T) {
+	sub := NewPipeline[int]().Take(2)
+	router := SplitBy(NewPipelineWithData([]int{1, 2, 3, 4, 5}), func(x int) int { return x % 2 }).
+		Default(sub)
+
+	results, err := router.Execute()
+	if err != nil {
+		t.Fatalf("SplitBy failed: %v", err)
+	}
+
+	expected := map[int][]int{0: {2, 4}, 1: {1, 3}}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %v, got %v", expected, results)
+	}
+}
@@ -0,0 +1,157 @@
+package algo
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrEmptyInput is returned by aggregations that are undefined for empty input, such as Mean.
+var ErrEmptyInput = errors.New("empty input")
+
+// Number is the set of numeric types accepted by the statistical aggregations.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// RunningStats accumulates count, sum, mean, variance and extrema of a stream of values
+// in a single pass using Welford's online algorithm, which stays numerically stable for
+// long streams. The zero value is an empty accumulator ready to use.
+type RunningStats struct {
+	count    int
+	sum      float64
+	mean     float64
+	m2       float64
+	min      float64
+	max      float64
+	minIndex int
+	maxIndex int
+}
+
+// Add adds a value to the accumulator.
+// The first occurrence of the smallest and largest values determines MinIndex and MaxIndex.
+func (s *RunningStats) Add(x float64) {
+	if s.count == 0 || x < s.min {
+		s.min, s.minIndex = x, s.count
+	}
+	if s.count == 0 || x > s.max {
+		s.max, s.maxIndex = x, s.count
+	}
+
+	s.count++
+	s.sum += x
+	delta := x - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (x - s.mean)
+}
+
+// Merge combines another accumulator into s, as if the values of other had been added after those of s.
+// It allows statistics computed over shards to be combined exactly.
+func (s *RunningStats) Merge(other RunningStats) {
+	if other.count == 0 {
+		return
+	}
+	if s.count == 0 {
+		*s = other
+		return
+	}
+
+	if other.min < s.min {
+		s.min, s.minIndex = other.min, s.count+other.minIndex
+	}
+	if other.max > s.max {
+		s.max, s.maxIndex = other.max, s.count+other.maxIndex
+	}
+
+	n := float64(s.count + other.count)
+	delta := other.mean - s.mean
+	s.m2 += other.m2 + delta*delta*float64(s.count)*float64(other.count)/n
+	s.mean += delta * float64(other.count) / n
+	s.sum += other.sum
+	s.count += other.count
+}
+
+// Count returns the number of values added.
+func (s *RunningStats) Count() int {
+	return s.count
+}
+
+// Sum returns the sum of the values added.
+func (s *RunningStats) Sum() float64 {
+	return s.sum
+}
+
+// Mean returns the arithmetic mean, or NaN if no values were added.
+func (s *RunningStats) Mean() float64 {
+	if s.count == 0 {
+		return math.NaN()
+	}
+	return s.mean
+}
+
+// Variance returns the population variance, or NaN if no values were added.
+func (s *RunningStats) Variance() float64 {
+	if s.count == 0 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.count)
+}
+
+// SampleVariance returns the unbiased sample variance, or NaN if fewer than two values were added.
+func (s *RunningStats) SampleVariance() float64 {
+	if s.count < 2 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.count-1)
+}
+
+// StdDev returns the population standard deviation, or NaN if no values were added.
+func (s *RunningStats) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// SampleStdDev returns the sample standard deviation, or NaN if fewer than two values were added.
+func (s *RunningStats) SampleStdDev() float64 {
+	return math.Sqrt(s.SampleVariance())
+}
+
+// Min returns the smallest value and the position at which it was added.
+// Returns NaN and -1 if no values were added.
+func (s *RunningStats) Min() (float64, int) {
+	if s.count == 0 {
+		return math.NaN(), -1
+	}
+	return s.min, s.minIndex
+}
+
+// Max returns the largest value and the position at which it was added.
+// Returns NaN and -1 if no values were added.
+func (s *RunningStats) Max() (float64, int) {
+	if s.count == 0 {
+		return math.NaN(), -1
+	}
+	return s.max, s.maxIndex
+}
+
+// Stats executes the pipeline and summarizes the values extracted from its result.
+// Returns an error if any operation fails; an empty result yields an accumulator with a count of zero.
+//
+// Example:
+//
+//	stats, err := NewPipelineWithData(orders).
+//	    Filter(func(o Order) bool { return o.Status == "completed" }).
+//	    Stats(func(o Order) float64 { return o.Amount })
+//	fmt.Println(stats.Count(), stats.Mean(), stats.StdDev())
+func (p *Pipeline[T]) Stats(value func(T) float64) (*RunningStats, error) {
+	data, err := p.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &RunningStats{}
+	for _, item := range data {
+		stats.Add(value(item))
+	}
+	return stats, nil
+}
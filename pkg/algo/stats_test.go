@@ -0,0 +1,107 @@
+package algo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+func TestRunningStats_Basic(t *testing.T) {
+	var stats RunningStats
+	for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		stats.Add(x)
+	}
+
+	if stats.Count() != 8 || stats.Sum() != 40 || stats.Mean() != 5 {
+		t.Errorf("Unexpected count/sum/mean: %d %v %v", stats.Count(), stats.Sum(), stats.Mean())
+	}
+	if !almostEqual(stats.Variance(), 4) || !almostEqual(stats.StdDev(), 2) {
+		t.Errorf("Expected variance 4 and stddev 2, got %v and %v", stats.Variance(), stats.StdDev())
+	}
+	if !almostEqual(stats.SampleVariance(), 32.0/7) {
+		t.Errorf("Expected sample variance %v, got %v", 32.0/7, stats.SampleVariance())
+	}
+	if low, index := stats.Min(); low != 2 || index != 0 {
+		t.Errorf("Expected min 2 at 0, got %v at %d", low, index)
+	}
+	if high, index := stats.Max(); high != 9 || index != 7 {
+		t.Errorf("Expected max 9 at 7, got %v at %d", high, index)
+	}
+}
+
+func TestRunningStats_Empty(t *testing.T) {
+	var stats RunningStats
+
+	if !math.IsNaN(stats.Mean()) || !math.IsNaN(stats.Variance()) {
+		t.Errorf("Expected NaN mean and variance for empty stats")
+	}
+	if _, index := stats.Min(); index != -1 {
+		t.Errorf("Expected min index -1 for empty stats, got %d", index)
+	}
+}
+
+func TestRunningStats_MergeMatchesSinglePass(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+	values := make([]float64, 1000)
+	for i := range values {
+		values[i] = rng.NormFloat64()*10 + 1e6
+	}
+
+	var whole, left, right RunningStats
+	for i, x := range values {
+		whole.Add(x)
+		if i < 300 {
+			left.Add(x)
+		} else {
+			right.Add(x)
+		}
+	}
+	left.Merge(right)
+
+	if left.Count() != whole.Count() || !almostEqual(left.Mean(), whole.Mean()) {
+		t.Errorf("Merged mean %v differs from single pass %v", left.Mean(), whole.Mean())
+	}
+	if !almostEqual(left.Variance(), whole.Variance()) {
+		t.Errorf("Merged variance %v differs from single pass %v", left.Variance(), whole.Variance())
+	}
+	_, mergedMax := left.Max()
+	_, wholeMax := whole.Max()
+	if mergedMax != wholeMax {
+		t.Errorf("Merged max index %d differs from single pass %d", mergedMax, wholeMax)
+	}
+}
+
+func TestPipeline_Stats(t *testing.T) {
+	data := []Item{
+		{ID: 1, Active: true},
+		{ID: 2, Active: false},
+		{ID: 3, Active: true},
+		{ID: 5, Active: true},
+	}
+
+	stats, err := NewPipelineWithData(data).
+		Filter(func(a Item) bool { return a.Active }).
+		Stats(func(a Item) float64 { return float64(a.ID) })
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+
+	if stats.Count() != 3 || stats.Mean() != 3 {
+		t.Errorf("Expected count 3 and mean 3, got %d and %v", stats.Count(), stats.Mean())
+	}
+}
+
+func TestPipeline_StatsEmpty(t *testing.T) {
+	stats, err := NewPipeline[int]().Stats(func(x int) float64 { return float64(x) })
+	if err != nil {
+		t.Fatalf("Stats failed on empty pipeline: %v", err)
+	}
+
+	if stats.Count() != 0 || stats.Sum() != 0 {
+		t.Errorf("Expected empty stats, got count %d and sum %v", stats.Count(), stats.Sum())
+	}
+}
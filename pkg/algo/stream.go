@@ -0,0 +1,114 @@
+package algo
+
+// Source produces elements one at a time by calling yield for each of them.
+// It must stop as soon as yield returns false, and returns any error encountered while producing elements.
+type Source[T any] func(yield func(T) bool) error
+
+// Sink consumes the elements of a stream one at a time. Returning an error stops the stream.
+type Sink[T any] func(item T) error
+
+// Streamer is implemented by operations that can process elements one at a time.
+// Stream wraps emit, which passes an element downstream, and returns the function that receives
+// elements from upstream. Both functions return false when no further elements are wanted, which
+// lets a stage such as Take stop the source from reading any more input.
+// Each call to Stream must start with fresh state.
+type Streamer[T any] interface {
+	Stream(emit func(T) bool) func(T) bool
+}
+
+// FromSlice returns a source that yields the elements of data in order.
+//
+// Example:
+//
+//	err := pipeline.Stream(FromSlice(orders), func(o Order) error {
+//	    return publish(o)
+//	})
+func FromSlice[T any](data []T) Source[T] {
+	return func(yield func(T) bool) error {
+		for _, item := range data {
+			if !yield(item) {
+				return nil
+			}
+		}
+		return nil
+	}
+}
+
+// Stream runs the pipeline over the elements of source and passes every result to sink.
+// The leading operations that implement Streamer (Filter, Map, FlatMap, Scan, Take and Skip) process
+// one element at a time, so the source is never materialized and stops being read as soon as a Take
+// is satisfied. The first operation that needs the whole data, such as a sort, collects the elements
+// that reach it and the remaining operations run as in Execute.
+// The pipeline's own data is ignored. Returns the first error from the source, an operation or the sink.
+//
+// Example:
+//
+//	err := NewPipeline[Event]().
+//	    Filter(func(e Event) bool { return e.Level == "error" }).
+//	    Take(10).
+//	    Stream(events, func(e Event) error { return alert(e) }) // reads only until 10 errors are found
+func (p *Pipeline[T]) Stream(source Source[T], sink Sink[T]) error {
+	streaming := 0
+	for streaming < len(p.operations) {
+		if _, ok := p.operations[streaming].(Streamer[T]); !ok {
+			break
+		}
+		streaming++
+	}
+	remaining := &Pipeline[T]{operations: p.operations[streaming:]}
+
+	var sinkErr error
+	var collected []T
+	push := func(item T) bool {
+		if len(remaining.operations) > 0 {
+			collected = append(collected, item)
+			return true
+		}
+		if sinkErr = sink(item); sinkErr != nil {
+			return false
+		}
+		return true
+	}
+	for i := streaming - 1; i >= 0; i-- {
+		push = p.operations[i].(Streamer[T]).Stream(push)
+	}
+
+	if err := source(push); err != nil {
+		return err
+	}
+	if sinkErr != nil || len(remaining.operations) == 0 {
+		return sinkErr
+	}
+
+	data, err := remaining.run(collected)
+	if err != nil {
+		return err
+	}
+	for _, item := range data {
+		if err := sink(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExecuteSource runs the pipeline over the elements of source and returns the result,
+// reading no more of the source than the operations need. See Stream.
+//
+// Example:
+//
+//	firstErrors, err := NewPipeline[Event]().
+//	    Filter(func(e Event) bool { return e.Level == "error" }).
+//	    Take(10).
+//	    ExecuteSource(events)
+func (p *Pipeline[T]) ExecuteSource(source Source[T]) ([]T, error) {
+	result := []T{}
+	err := p.Stream(source, func(item T) error {
+		result = append(result, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
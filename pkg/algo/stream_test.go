@@ -0,0 +1,110 @@
+package algo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// countingSource yields 0, 1, 2, ... up to n-1 and records how many elements were produced.
+func countingSource(n int, produced *int) Source[int] {
+	return func(yield func(int) bool) error {
+		for i := 0; i < n; i++ {
+			*produced++
+			if !yield(i) {
+				return nil
+			}
+		}
+		return nil
+	}
+}
+
+func TestPipeline_ExecuteSource_StopsEarly(t *testing.T) {
+	produced := 0
+	result, err := NewPipeline[int]().
+		Filter(func(x int) bool { return x%3 == 0 }).
+		Map(func(x int) int { return x * 10 }).
+		Take(4).
+		ExecuteSource(countingSource(1000000, &produced))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, []int{0, 30, 60, 90}) {
+		t.Errorf("Expected [0 30 60 90], got %v", result)
+	}
+	if produced != 10 {
+		t.Errorf("Expected the source to stop after 10 elements, produced %d", produced)
+	}
+}
+
+func TestPipeline_ExecuteSource_MatchesExecute(t *testing.T) {
+	data := rangeInts(200)
+	build := func() *Pipeline[int] {
+		return NewPipeline[int]().
+			Skip(5).
+			FlatMap(func(x int) []int { return []int{x, -x} }).
+			Filter(func(x int) bool { return x%4 != 0 }).
+			Scan(0, func(acc, x int) int { return acc + x }).
+			QuickSort(func(a, b int) bool { return a > b }).
+			Take(7)
+	}
+
+	expected, err := build().WithData(data).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result, err := build().ExecuteSource(FromSlice(data))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestPipeline_Stream_ReusableAndEmpty(t *testing.T) {
+	pipeline := NewPipeline[int]().Take(2)
+	for i := 0; i < 2; i++ {
+		result, err := pipeline.ExecuteSource(FromSlice([]int{1, 2, 3}))
+		if err != nil || !reflect.DeepEqual(result, []int{1, 2}) {
+			t.Errorf("Run %d: expected [1 2], got %v (%v)", i, result, err)
+		}
+	}
+
+	result, err := NewPipeline[int]().Take(0).ExecuteSource(FromSlice([]int{1, 2}))
+	if err != nil || len(result) != 0 {
+		t.Errorf("Expected empty result, got %v (%v)", result, err)
+	}
+}
+
+func TestPipeline_Stream_Errors(t *testing.T) {
+	sourceErr := errors.New("read failed")
+	failing := func(yield func(int) bool) error {
+		yield(1)
+		return sourceErr
+	}
+	if _, err := NewPipeline[int]().ExecuteSource(failing); !errors.Is(err, sourceErr) {
+		t.Errorf("Expected source error, got %v", err)
+	}
+
+	sinkErr := errors.New("write failed")
+	produced := 0
+	err := NewPipeline[int]().Stream(countingSource(100, &produced), func(x int) error {
+		if x == 2 {
+			return sinkErr
+		}
+		return nil
+	})
+	if !errors.Is(err, sinkErr) || produced != 3 {
+		t.Errorf("Expected sink error after 3 elements, got %v after %d", err, produced)
+	}
+
+	_, err = NewPipeline[int]().
+		Filter(func(x int) bool { return false }).
+		Reduce(func(a, b int) int { return a + b }).
+		ExecuteSource(FromSlice([]int{1, 2}))
+	if err == nil {
+		t.Error("Expected error from non-streaming operation")
+	}
+}
@@ -0,0 +1,143 @@
+package algo
+
+// Levenshtein returns the edit distance between a and b: the minimum number of single-rune
+// insertions, deletions and substitutions needed to turn a into b.
+//
+// Example:
+//
+//	Levenshtein("kitten", "sitting") // 3
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// DamerauLevenshtein returns the optimal string alignment distance between a and b.
+// It extends Levenshtein by counting a transposition of two adjacent runes as a single edit,
+// which matches common typing mistakes such as "teh" for "the".
+//
+// Example:
+//
+//	DamerauLevenshtein("teh", "the") // 1
+func DamerauLevenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prevPrev := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+	return prev[len(rb)]
+}
+
+// Jaro returns the Jaro similarity of a and b, between 0 (no similarity) and 1 (identical).
+//
+// Example:
+//
+//	Jaro("MARTHA", "MARHTA") // 0.944...
+func Jaro(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	window = max(window, 0)
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		start := max(0, i-window)
+		end := min(len(rb), i+window+1)
+		for j := start; j < end; j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// JaroWinkler returns the Jaro-Winkler similarity of a and b, between 0 and 1.
+// It boosts the Jaro similarity of strings that share a common prefix of up to four runes,
+// which suits short strings such as personal names.
+//
+// Example:
+//
+//	JaroWinkler("MARTHA", "MARHTA") // 0.961...
+func JaroWinkler(a, b string) float64 {
+	const (
+		prefixScale = 0.1
+		maxPrefix   = 4
+	)
+
+	similarity := Jaro(a, b)
+	ra, rb := []rune(a), []rune(b)
+	prefix := 0
+	for prefix < min(len(ra), len(rb), maxPrefix) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return similarity + float64(prefix)*prefixScale*(1-similarity)
+}
@@ -0,0 +1,68 @@
+package algo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"teh", "the", 2},
+		{"café", "cafe", 1},
+	}
+
+	for _, c := range cases {
+		if d := Levenshtein(c.a, c.b); d != c.expected {
+			t.Errorf("Levenshtein(%q, %q): expected %d, got %d", c.a, c.b, c.expected, d)
+		}
+		if d := Levenshtein(c.b, c.a); d != c.expected {
+			t.Errorf("Levenshtein(%q, %q): expected %d, got %d", c.b, c.a, c.expected, d)
+		}
+	}
+}
+
+func TestDamerauLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "abc", 3},
+		{"teh", "the", 1},
+		{"ca", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"AB1C23", "A1BC23", 1},
+	}
+
+	for _, c := range cases {
+		if d := DamerauLevenshtein(c.a, c.b); d != c.expected {
+			t.Errorf("DamerauLevenshtein(%q, %q): expected %d, got %d", c.a, c.b, c.expected, d)
+		}
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"MARTHA", "MARHTA", 0.9611},
+		{"DWAYNE", "DUANE", 0.84},
+		{"DIXON", "DICKSONX", 0.8133},
+		{"abc", "abc", 1},
+		{"abc", "xyz", 0},
+		{"", "", 1},
+	}
+
+	for _, c := range cases {
+		if score := JaroWinkler(c.a, c.b); math.Abs(score-c.expected) > 0.0001 {
+			t.Errorf("JaroWinkler(%q, %q): expected %.4f, got %.4f", c.a, c.b, c.expected, score)
+		}
+	}
+}
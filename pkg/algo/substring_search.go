@@ -0,0 +1,189 @@
+package algo
+
+// KMPSearch returns the byte offsets of every occurrence of pattern in text, including overlapping
+// ones, using the Knuth-Morris-Pratt algorithm. It runs in O(len(text) + len(pattern)) time.
+// An empty pattern matches nothing.
+//
+// Example:
+//
+//	KMPSearch("abababa", "aba") // [0 2 4]
+func KMPSearch(text, pattern string) []int {
+	if pattern == "" {
+		return nil
+	}
+	return kmpSearch(text, pattern, kmpTable(pattern))
+}
+
+// HorspoolSearch returns the byte offsets of every occurrence of pattern in text, including
+// overlapping ones, using the Boyer-Moore-Horspool algorithm. It skips ahead by up to
+// len(pattern) bytes on a mismatch, which makes it fast for long patterns.
+// An empty pattern matches nothing.
+//
+// Example:
+//
+//	HorspoolSearch("abababa", "aba") // [0 2 4]
+func HorspoolSearch(text, pattern string) []int {
+	if pattern == "" {
+		return nil
+	}
+	return horspoolSearch(text, pattern, horspoolTable(pattern))
+}
+
+// horspoolTable returns the bad-character shift table of pattern: for each byte, how far the
+// search window may move when that byte is the last one of the window.
+func horspoolTable(pattern string) *[256]int {
+	m := len(pattern)
+	var shift [256]int
+	for i := range shift {
+		shift[i] = m
+	}
+	for i := 0; i < m-1; i++ {
+		shift[pattern[i]] = m - 1 - i
+	}
+	return &shift
+}
+
+// horspoolSearch scans text for pattern using a precomputed shift table.
+func horspoolSearch(text, pattern string, shift *[256]int) []int {
+	m := len(pattern)
+	var offsets []int
+	for pos := 0; pos+m <= len(text); pos += shift[text[pos+m-1]] {
+		if text[pos:pos+m] == pattern {
+			offsets = append(offsets, pos)
+		}
+	}
+	return offsets
+}
+
+// kmpTable returns the failure table of pattern: for each prefix length i+1, the length
+// of the longest proper prefix of pattern[:i+1] that is also its suffix.
+func kmpTable(pattern string) []int {
+	table := make([]int, len(pattern))
+	k := 0
+	for i := 1; i < len(pattern); i++ {
+		for k > 0 && pattern[i] != pattern[k] {
+			k = table[k-1]
+		}
+		if pattern[i] == pattern[k] {
+			k++
+		}
+		table[i] = k
+	}
+	return table
+}
+
+// kmpSearch scans text for pattern using a precomputed failure table.
+func kmpSearch(text, pattern string, table []int) []int {
+	var offsets []int
+	k := 0
+	for i := 0; i < len(text); i++ {
+		for k > 0 && text[i] != pattern[k] {
+			k = table[k-1]
+		}
+		if text[i] == pattern[k] {
+			k++
+		}
+		if k == len(pattern) {
+			offsets = append(offsets, i+1-k)
+			k = table[k-1]
+		}
+	}
+	return offsets
+}
+
+// horspoolMinLength is the pattern length from which ContainsPattern uses Boyer-Moore-Horspool,
+// whose skips grow with the pattern, instead of Knuth-Morris-Pratt.
+const horspoolMinLength = 8
+
+// ContainsPatternOperation selects the elements whose key contains a pattern
+// and records where the pattern occurs. With Mapper set, each selected element is
+// replaced by the result of Mapper, which receives the byte offsets of every occurrence.
+type ContainsPatternOperation[T any] struct {
+	Key     func(T) string
+	Pattern string
+	Mapper  func(item T, offsets []int) T
+	// Offsets holds, for each element of the last output, the byte offsets of every occurrence.
+	Offsets [][]int
+	table   []int
+	shift   *[256]int
+}
+
+// Apply performs the single-pattern search on the data, using Knuth-Morris-Pratt for short
+// patterns and Boyer-Moore-Horspool for long ones.
+// It returns the matching elements in their original order and records their match offsets.
+//
+// Example:
+//
+//	pipeline := NewPipeline[string]().
+//	    ContainsPattern(func(s string) string { return s }, "timeout")
+//	result, err := pipeline.Execute()
+func (c *ContainsPatternOperation[T]) Apply(data []T) ([]T, error) {
+	matchedData := make([]T, 0, len(data))
+	c.Offsets = c.Offsets[:0]
+	if c.Pattern == "" {
+		return matchedData, nil
+	}
+
+	for _, item := range data {
+		if offsets := c.search(c.Key(item)); len(offsets) > 0 {
+			if c.Mapper != nil {
+				item = c.Mapper(item, offsets)
+			}
+			matchedData = append(matchedData, item)
+			c.Offsets = append(c.Offsets, offsets)
+		}
+	}
+	return matchedData, nil
+}
+
+// search returns the offsets of the pattern in text, preparing the searcher's table on first use.
+func (c *ContainsPatternOperation[T]) search(text string) []int {
+	if len(c.Pattern) >= horspoolMinLength {
+		if c.shift == nil {
+			c.shift = horspoolTable(c.Pattern)
+		}
+		return horspoolSearch(text, c.Pattern, c.shift)
+	}
+	if c.table == nil {
+		c.table = kmpTable(c.Pattern)
+	}
+	return kmpSearch(text, c.Pattern, c.table)
+}
+
+// GetMatchCount returns the total number of occurrences found by the last search.
+func (c *ContainsPatternOperation[T]) GetMatchCount() int {
+	count := 0
+	for _, offsets := range c.Offsets {
+		count += len(offsets)
+	}
+	return count
+}
+
+// ContainsPattern adds a single-pattern substring search to the pipeline.
+// The total number of occurrences is reported by ExecuteWithResult; use LocatePattern
+// to receive the offsets of each element.
+//
+// Example:
+//
+//	pipeline.ContainsPattern(func(r LogRecord) string { return r.Message }, "connection reset")
+func (p *Pipeline[T]) ContainsPattern(key func(T) string, pattern string) *Pipeline[T] {
+	p.operations = append(p.operations, &ContainsPatternOperation[T]{Key: key, Pattern: pattern})
+	return p
+}
+
+// LocatePattern adds a single-pattern substring search to the pipeline that passes the byte
+// offsets of every occurrence to a mapper. Elements whose key does not contain the pattern are dropped.
+//
+// Example:
+//
+//	pipeline.LocatePattern(func(r LogRecord) string { return r.Message }, "timeout",
+//	    func(r LogRecord, offsets []int) LogRecord {
+//	        r.Timeouts = len(offsets)
+//	        return r
+//	    })
+func (p *Pipeline[T]) LocatePattern(
+	key func(T) string, pattern string, mapper func(item T, offsets []int) T,
+) *Pipeline[T] {
+	p.operations = append(p.operations, &ContainsPatternOperation[T]{Key: key, Pattern: pattern, Mapper: mapper})
+	return p
+}
@@ -0,0 +1,112 @@
+package algo
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKMPSearch(t *testing.T) {
+	if offsets := KMPSearch("abababa", "aba"); !reflect.DeepEqual(offsets, []int{0, 2, 4}) {
+		t.Errorf("Expected [0 2 4], got %v", offsets)
+	}
+	if offsets := KMPSearch("aaaa", "b"); offsets != nil {
+		t.Errorf("Expected no offsets, got %v", offsets)
+	}
+	if offsets := KMPSearch("abc", ""); offsets != nil {
+		t.Errorf("Expected empty pattern to match nothing, got %v", offsets)
+	}
+}
+
+func TestHorspoolSearch(t *testing.T) {
+	if offsets := HorspoolSearch("abababa", "aba"); !reflect.DeepEqual(offsets, []int{0, 2, 4}) {
+		t.Errorf("Expected [0 2 4], got %v", offsets)
+	}
+	if offsets := HorspoolSearch("ab", "abc"); offsets != nil {
+		t.Errorf("Expected no offsets, got %v", offsets)
+	}
+}
+
+func TestSubstringSearch_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	randomString := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = "ab"[rng.Intn(2)]
+		}
+		return string(b)
+	}
+
+	for i := 0; i < 300; i++ {
+		text := randomString(rng.Intn(30))
+		pattern := randomString(1 + rng.Intn(4))
+
+		var expected []int
+		for j := 0; j+len(pattern) <= len(text); j++ {
+			if strings.HasPrefix(text[j:], pattern) {
+				expected = append(expected, j)
+			}
+		}
+
+		if offsets := KMPSearch(text, pattern); !reflect.DeepEqual(offsets, expected) {
+			t.Fatalf("KMPSearch(%q, %q): expected %v, got %v", text, pattern, expected, offsets)
+		}
+		if offsets := HorspoolSearch(text, pattern); !reflect.DeepEqual(offsets, expected) {
+			t.Fatalf("HorspoolSearch(%q, %q): expected %v, got %v", text, pattern, expected, offsets)
+		}
+	}
+}
+
+func TestContainsPatternOperation_Offsets(t *testing.T) {
+	pipeline := NewPipeline[string]().
+		ContainsPattern(func(s string) string { return s }, "timeout")
+
+	pipeline.WithData([]string{"timeout after timeout", "ok", "read timeout"})
+
+	result, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ContainsPatternOperation failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, []string{"timeout after timeout", "read timeout"}) {
+		t.Errorf("Unexpected result %v", result)
+	}
+
+	cpOp := pipeline.GetOperations()[0].(*ContainsPatternOperation[string])
+	if !reflect.DeepEqual(cpOp.Offsets, [][]int{{0, 14}, {5}}) {
+		t.Errorf("Expected offsets [[0 14] [5]], got %v", cpOp.Offsets)
+	}
+	if cpOp.GetMatchCount() != 3 {
+		t.Errorf("Expected 3 matches, got %d", cpOp.GetMatchCount())
+	}
+}
+
+func TestContainsPatternOperation_LongPatternUsesHorspool(t *testing.T) {
+	op := &ContainsPatternOperation[string]{Key: func(s string) string { return s }, Pattern: "connection reset"}
+	result, err := op.Apply([]string{"connection reset by peer", "reset", "connection reset; connection reset"})
+	if err != nil {
+		t.Fatalf("ContainsPatternOperation failed: %v", err)
+	}
+	if len(result) != 2 || !reflect.DeepEqual(op.Offsets, [][]int{{0}, {0, 18}}) {
+		t.Errorf("Unexpected result %v with offsets %v", result, op.Offsets)
+	}
+	if op.shift == nil || op.table != nil {
+		t.Error("Expected the long pattern to be searched with Horspool")
+	}
+}
+
+func TestLocatePattern(t *testing.T) {
+	result, err := NewPipelineWithData([]string{"ab", "abcab", "c"}).
+		LocatePattern(func(s string) string { return s }, "ab", func(s string, offsets []int) string {
+			return fmt.Sprint(s, offsets)
+		}).
+		Execute()
+	if err != nil {
+		t.Fatalf("LocatePattern failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, []string{"ab[0]", "abcab[0 3]"}) {
+		t.Errorf("Unexpected result %v", result)
+	}
+}
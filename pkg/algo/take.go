@@ -18,13 +18,22 @@ func (t *TakeOperation[T]) Apply(data []T) ([]T, error) {
 	if t.Count <= 0 {
 		return []T{}, nil
 	}
-	if t.Count > len(data) {
-		t.Count = len(data)
-	}
-	takenData := data[:t.Count]
+	takenData := data[:min(t.Count, len(data))]
 	return takenData, nil
 }
 
+// Stream implements Streamer, passing on the first Count elements and then stopping the stream.
+func (t *TakeOperation[T]) Stream(emit func(T) bool) func(T) bool {
+	taken := 0
+	return func(item T) bool {
+		if taken >= t.Count {
+			return false
+		}
+		taken++
+		return emit(item) && taken < t.Count
+	}
+}
+
 // Take adds a take operation to the pipeline.
 // The count parameter specifies how many elements to select from the start.
 //
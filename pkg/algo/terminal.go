@@ -0,0 +1,125 @@
+package algo
+
+import "errors"
+
+// errStopScan stops the stream of a terminal once its answer is known.
+var errStopScan = errors.New("scan stopped")
+
+// scan streams the pipeline's data through its operations and passes each resulting element
+// with its index to visit, stopping as soon as visit returns false. Leading operations that
+// implement Streamer only process the elements that the scan reaches; see Stream.
+func (p *Pipeline[T]) scan(visit func(index int, item T) bool) error {
+	index := 0
+	err := p.Stream(FromSlice(p.data), func(item T) error {
+		if !visit(index, item) {
+			return errStopScan
+		}
+		index++
+		return nil
+	})
+	if err == errStopScan {
+		return nil
+	}
+	return err
+}
+
+// First runs the pipeline and returns the first element that satisfies the predicate.
+// The scan stops at the first match, and leading Filter, Map, FlatMap, Scan, Skip and Take
+// stages stop processing the input there too. Returns ErrNotFound if no element matches.
+//
+// Example:
+//
+//	user, err := NewPipelineWithData(users).
+//	    Filter(func(u User) bool { return u.Active }).
+//	    First(func(u User) bool { return u.Email == email })
+func (p *Pipeline[T]) First(predicate func(T) bool) (T, error) {
+	var found T
+	matched := false
+	err := p.scan(func(_ int, item T) bool {
+		if predicate(item) {
+			found, matched = item, true
+		}
+		return !matched
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if !matched {
+		return found, ErrNotFound
+	}
+	return found, nil
+}
+
+// FirstIndex runs the pipeline and returns the index of the first element in its result
+// that satisfies the predicate. The scan stops at the first match, as in First. Returns -1 if no element matches.
+//
+// Example:
+//
+//	index, err := NewPipelineWithData(products).
+//	    FirstIndex(func(p Product) bool { return p.SKU == "ABC123" })
+func (p *Pipeline[T]) FirstIndex(predicate func(T) bool) (int, error) {
+	found := -1
+	err := p.scan(func(i int, item T) bool {
+		if predicate(item) {
+			found = i
+		}
+		return found < 0
+	})
+	if err != nil {
+		return -1, err
+	}
+	return found, nil
+}
+
+// Any runs the pipeline and reports whether any element satisfies the predicate.
+// The scan stops at the first match, as in First.
+//
+// Example:
+//
+//	hasAdmin, err := NewPipelineWithData(users).
+//	    Any(func(u User) bool { return u.Role == "admin" })
+func (p *Pipeline[T]) Any(predicate func(T) bool) (bool, error) {
+	index, err := p.FirstIndex(predicate)
+	return index >= 0, err
+}
+
+// All runs the pipeline and reports whether every element satisfies the predicate.
+// The scan stops at the first element that does not match, as in First. An empty result reports true.
+//
+// Example:
+//
+//	allPaid, err := NewPipelineWithData(orders).
+//	    All(func(o Order) bool { return o.Paid })
+func (p *Pipeline[T]) All(predicate func(T) bool) (bool, error) {
+	all := true
+	err := p.scan(func(_ int, item T) bool {
+		all = predicate(item)
+		return all
+	})
+	if err != nil {
+		return false, err
+	}
+	return all, nil
+}
+
+// Count runs the pipeline and returns the number of elements that satisfy the predicate.
+// Leading streaming stages pass elements one at a time, so their output is never collected.
+//
+// Example:
+//
+//	pending, err := NewPipelineWithData(orders).
+//	    Count(func(o Order) bool { return o.Status == "pending" })
+func (p *Pipeline[T]) Count(predicate func(T) bool) (int, error) {
+	count := 0
+	err := p.scan(func(_ int, item T) bool {
+		if predicate(item) {
+			count++
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,126 @@
+package algo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPipeline_First(t *testing.T) {
+	data := []Item{
+		{ID: 1, Name: "Item1", Active: false},
+		{ID: 2, Name: "Item2", Active: true},
+		{ID: 3, Name: "Item3", Active: true},
+	}
+
+	item, err := NewPipelineWithData(data).
+		First(func(a Item) bool { return a.Active })
+	if err != nil {
+		t.Fatalf("First failed: %v", err)
+	}
+
+	expected := Item{ID: 2, Name: "Item2", Active: true}
+	if item != expected {
+		t.Errorf("Expected %+v, got %+v", expected, item)
+	}
+}
+
+func TestPipeline_FirstNotFound(t *testing.T) {
+	_, err := NewPipelineWithData([]int{1, 2, 3}).
+		First(func(x int) bool { return x > 3 })
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPipeline_FirstIndexShortCircuits(t *testing.T) {
+	calls := 0
+	index, err := NewPipelineWithData([]int{5, 6, 7, 8}).
+		FirstIndex(func(x int) bool {
+			calls++
+			return x == 6
+		})
+	if err != nil {
+		t.Fatalf("FirstIndex failed: %v", err)
+	}
+
+	if index != 1 {
+		t.Errorf("Expected index 1, got %d", index)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the scan to stop after 2 calls, got %d", calls)
+	}
+}
+
+func TestPipeline_AnyAll(t *testing.T) {
+	data := []int{2, 4, 6}
+
+	anyOdd, err := NewPipelineWithData(data).Any(func(x int) bool { return x%2 == 1 })
+	if err != nil || anyOdd {
+		t.Errorf("Expected Any to report false, got %v (err %v)", anyOdd, err)
+	}
+
+	allEven, err := NewPipelineWithData(data).All(func(x int) bool { return x%2 == 0 })
+	if err != nil || !allEven {
+		t.Errorf("Expected All to report true, got %v (err %v)", allEven, err)
+	}
+
+	allEmpty, err := NewPipeline[int]().All(func(x int) bool { return false })
+	if err != nil || !allEmpty {
+		t.Errorf("Expected All on empty data to report true, got %v (err %v)", allEmpty, err)
+	}
+}
+
+func TestPipeline_Count(t *testing.T) {
+	count, err := NewPipelineWithData([]int{1, 2, 3, 4, 5}).
+		Filter(func(x int) bool { return x > 1 }).
+		Count(func(x int) bool { return x%2 == 1 })
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+
+	if count != 2 {
+		t.Errorf("Expected count 2, got %d", count)
+	}
+}
+
+func TestPipeline_TerminalPropagatesError(t *testing.T) {
+	_, err := NewPipelineWithData([]int{}).
+		Reduce(func(acc, item int) int { return acc + item }).
+		Count(func(x int) bool { return true })
+	if err == nil {
+		t.Fatalf("Expected error from failing pipeline, but got nil")
+	}
+}
+
+func TestPipeline_TerminalsStopStreamingStages(t *testing.T) {
+	mapped := 0
+	pipeline := func() *Pipeline[int] {
+		mapped = 0
+		return NewPipelineWithData([]int{1, 2, 3, 4, 5, 6}).
+			Map(func(x int) int {
+				mapped++
+				return x * 10
+			})
+	}
+
+	if item, err := pipeline().First(func(x int) bool { return x == 20 }); err != nil || item != 20 || mapped != 2 {
+		t.Errorf("First: expected 20 after mapping 2 elements, got %d after %d (%v)", item, mapped, err)
+	}
+	if found, err := pipeline().Any(func(x int) bool { return x > 25 }); err != nil || !found || mapped != 3 {
+		t.Errorf("Any: expected a match after mapping 3 elements, got %v after %d (%v)", found, mapped, err)
+	}
+	if all, err := pipeline().All(func(x int) bool { return x < 20 }); err != nil || all || mapped != 2 {
+		t.Errorf("All: expected false after mapping 2 elements, got %v after %d (%v)", all, mapped, err)
+	}
+
+	// Stages that need the whole input still run over all of it.
+	index, err := pipeline().MergeSort(func(a, b int) bool { return a > b }).FirstIndex(func(x int) bool { return x < 50 })
+	if err != nil || index != 2 || mapped != 6 {
+		t.Errorf("FirstIndex: expected index 2 after mapping 6 elements, got %d after %d (%v)", index, mapped, err)
+	}
+
+	failing := NewPipelineWithData([]string{"a"}).MatchRegex(func(s string) string { return s }, "(")
+	if _, err := failing.Any(func(string) bool { return true }); err == nil {
+		t.Error("Expected the error of a failing operation")
+	}
+}
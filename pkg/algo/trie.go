@@ -0,0 +1,175 @@
+package algo
+
+import (
+	"sort"
+	"sync"
+)
+
+// TrieMatch is a single autocomplete result returned by Trie.Search.
+type TrieMatch[T any] struct {
+	Key    string
+	Value  T
+	Weight float64
+}
+
+// Trie is a prefix tree of string keys for autocomplete and prefix search.
+// A prefix query visits only the keys under the prefix instead of scanning the whole dataset.
+// A Trie is safe for concurrent use.
+type Trie[T any] struct {
+	mu   sync.RWMutex
+	root *trieNode[T]
+	size int
+}
+
+// trieNode is a node of the trie. Entries hold the values whose key ends at the node.
+type trieNode[T any] struct {
+	children map[rune]*trieNode[T]
+	entries  []trieEntry[T]
+}
+
+// trieEntry is a value stored at a trie node together with its ranking weight.
+type trieEntry[T any] struct {
+	value  T
+	weight float64
+}
+
+// NewTrie creates an empty Trie.
+//
+// Example:
+//
+//	trie := NewTrie[Product]()
+//	trie.InsertWithWeight("laptop", laptop, 120)
+func NewTrie[T any]() *Trie[T] {
+	return &Trie[T]{root: &trieNode[T]{}}
+}
+
+// BuildTrie creates a Trie containing every element of data under its key.
+//
+// Example:
+//
+//	trie := BuildTrie(products, func(p Product) string { return strings.ToLower(p.Name) })
+//	suggestions := trie.Values("lap", 10)
+func BuildTrie[T any](data []T, key func(T) string) *Trie[T] {
+	t := NewTrie[T]()
+	for _, item := range data {
+		t.insert(key(item), item, 0)
+	}
+	return t
+}
+
+// Insert adds value under key with a weight of zero.
+func (t *Trie[T]) Insert(key string, value T) {
+	t.InsertWithWeight(key, value, 0)
+}
+
+// InsertWithWeight adds value under key. Higher weights rank earlier in search results.
+func (t *Trie[T]) InsertWithWeight(key string, value T, weight float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.insert(key, value, weight)
+}
+
+// insert adds value under key without locking.
+func (t *Trie[T]) insert(key string, value T, weight float64) {
+	node := t.root
+	for _, r := range key {
+		child, ok := node.children[r]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode[T])
+			}
+			child = &trieNode[T]{}
+			node.children[r] = child
+		}
+		node = child
+	}
+	node.entries = append(node.entries, trieEntry[T]{value: value, weight: weight})
+	t.size++
+}
+
+// Search returns the entries whose key starts with prefix, ranked by weight (highest first),
+// then by key length (shortest first), then alphabetically by key.
+// Entries that tie on all three keep their insertion order.
+// At most limit matches are returned; a limit of zero or less returns every match.
+//
+// Example:
+//
+//	for _, match := range trie.Search("lap", 5) {
+//	    fmt.Println(match.Key, match.Weight)
+//	}
+func (t *Trie[T]) Search(prefix string, limit int) []TrieMatch[T] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	node := t.root
+	for _, r := range prefix {
+		node = node.children[r]
+		if node == nil {
+			return []TrieMatch[T]{}
+		}
+	}
+
+	var matches []TrieMatch[T]
+	node.collect([]rune(prefix), &matches)
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		if len(a.Key) != len(b.Key) {
+			return len(a.Key) < len(b.Key)
+		}
+		return a.Key < b.Key
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Values returns the values of Search(prefix, limit) in ranked order.
+// The result can be used directly as pipeline data.
+//
+// Example:
+//
+//	result, err := NewPipelineWithData(trie.Values("lap", 20)).
+//	    Filter(func(p Product) bool { return p.InStock }).
+//	    Take(5).
+//	    Execute()
+func (t *Trie[T]) Values(prefix string, limit int) []T {
+	matches := t.Search(prefix, limit)
+	values := make([]T, len(matches))
+	for i, match := range matches {
+		values[i] = match.Value
+	}
+	return values
+}
+
+// Len returns the number of values stored in the trie.
+func (t *Trie[T]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.size
+}
+
+// collect appends every entry in the subtree rooted at n to matches.
+// Children are visited in rune order so that the traversal is deterministic.
+func (n *trieNode[T]) collect(key []rune, matches *[]TrieMatch[T]) {
+	for _, entry := range n.entries {
+		*matches = append(*matches, TrieMatch[T]{Key: string(key), Value: entry.value, Weight: entry.weight})
+	}
+
+	runes := make([]rune, 0, len(n.children))
+	for r := range n.children {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	for _, r := range runes {
+		n.children[r].collect(append(key, r), matches)
+	}
+}
@@ -0,0 +1,95 @@
+package algo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTrie_SearchRanksByLengthThenKey(t *testing.T) {
+	trie := BuildTrie([]string{"apricot", "banana", "apple", "ape", "app"}, func(s string) string { return s })
+
+	result := trie.Values("ap", 0)
+
+	expected := []string{"ape", "app", "apple", "apricot"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestTrie_SearchRanksByWeight(t *testing.T) {
+	trie := NewTrie[Item]()
+	trie.InsertWithWeight("laptop", Item{ID: 1, Name: "laptop"}, 5)
+	trie.InsertWithWeight("lamp", Item{ID: 2, Name: "lamp"}, 10)
+	trie.Insert("label", Item{ID: 3, Name: "label"})
+	trie.InsertWithWeight("mouse", Item{ID: 4, Name: "mouse"}, 100)
+
+	matches := trie.Search("la", 2)
+
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Key != "lamp" || matches[1].Key != "laptop" {
+		t.Errorf("Expected [lamp laptop], got [%s %s]", matches[0].Key, matches[1].Key)
+	}
+	if matches[0].Value.ID != 2 || matches[0].Weight != 10 {
+		t.Errorf("Unexpected first match: %+v", matches[0])
+	}
+}
+
+func TestTrie_DuplicateKeysAndUnicode(t *testing.T) {
+	trie := NewTrie[int]()
+	trie.Insert("東京", 1)
+	trie.Insert("東京", 2)
+	trie.Insert("東北", 3)
+
+	if result := trie.Values("東京", 0); !reflect.DeepEqual(result, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", result)
+	}
+	if result := trie.Values("東", 0); len(result) != 3 {
+		t.Errorf("Expected 3 values, got %v", result)
+	}
+	if result := trie.Values("大", 0); len(result) != 0 {
+		t.Errorf("Expected no values, but got %v", result)
+	}
+	if trie.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", trie.Len())
+	}
+}
+
+func TestTrie_MatchesPrefixSearch(t *testing.T) {
+	var catalog []Item
+	for i := 0; i < 300; i++ {
+		catalog = append(catalog, Item{ID: i, Name: fmt.Sprintf("product-%d", i)})
+	}
+	key := func(a Item) string { return a.Name }
+	trie := BuildTrie(catalog, key)
+
+	for _, prefix := range []string{"product-1", "product-25", "product-299", "x", ""} {
+		expected, err := NewPipelineWithData(catalog).
+			PrefixSearch(key, prefix, 0).
+			Execute()
+		if err != nil {
+			t.Fatalf("PrefixSearch failed: %v", err)
+		}
+
+		result := trie.Values(prefix, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Prefix %q: trie and PrefixSearch disagree (%d vs %d items)", prefix, len(result), len(expected))
+		}
+	}
+}
+
+func BenchmarkTrieSearch(b *testing.B) {
+	var catalog []string
+	for i := 0; i < 100000; i++ {
+		catalog = append(catalog, "product-"+strings.Repeat("x", i%5)+fmt.Sprint(i))
+	}
+	trie := BuildTrie(catalog, func(s string) string { return s })
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie.Search("product-xxxx1234", 10)
+	}
+}
@@ -0,0 +1,88 @@
+package algo
+
+import "fmt"
+
+// WindowOperation slides a fixed-size window over the data and replaces each window
+// with the value produced by the aggregate function.
+// A step equal to the size yields tumbling windows; a smaller step yields overlapping sliding windows.
+type WindowOperation[T any] struct {
+	Size      int
+	Step      int
+	Aggregate func(window []T) T
+}
+
+// Apply performs the window operation on the data.
+// It returns a new slice containing one aggregated element per complete window.
+// Returns an error if Size or Step is not positive.
+//
+// Example:
+//
+//	pipeline := NewPipeline[float64]().
+//	    Window(3, 1, func(w []float64) float64 { return (w[0] + w[1] + w[2]) / 3 })
+//	result, err := pipeline.Execute() // 3-point moving average
+func (w *WindowOperation[T]) Apply(data []T) ([]T, error) {
+	aggregatedData, err := WindowAggregate(data, w.Size, w.Step, w.Aggregate)
+	if err != nil {
+		return nil, fmt.Errorf("WindowOperation: %w", err)
+	}
+	return aggregatedData, nil
+}
+
+// Window adds a window operation to the pipeline.
+// Windows of size elements start every step elements; windows that would run past the end are dropped.
+//
+// Example:
+//
+//	pipeline.Window(7, 1, func(days []Sale) Sale {
+//	    return averageSale(days) // 7-day moving average
+//	})
+func (p *Pipeline[T]) Window(size, step int, aggregate func(window []T) T) *Pipeline[T] {
+	p.operations = append(p.operations, &WindowOperation[T]{Size: size, Step: step, Aggregate: aggregate})
+	return p
+}
+
+// Window returns the windows of the given size that start every step elements.
+// Only complete windows are returned, so data shorter than size yields no windows.
+// Each window shares memory with data but has its capacity clipped.
+// Returns an error if size or step is not positive.
+//
+// Example:
+//
+//	windows, err := Window([]int{1, 2, 3, 4, 5}, 3, 1)
+//	// [[1 2 3] [2 3 4] [3 4 5]]
+func Window[T any](data []T, size, step int) ([][]T, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("window size must be positive, got %d", size)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("window step must be positive, got %d", step)
+	}
+
+	windows := make([][]T, 0, max(0, (len(data)-size)/step+1))
+	for start := 0; start+size <= len(data); start += step {
+		end := start + size
+		windows = append(windows, data[start:end:end])
+	}
+	return windows, nil
+}
+
+// WindowAggregate applies the aggregate function to every window returned by Window.
+// Unlike the Window pipeline stage, the aggregate may produce a value of a different type.
+//
+// Example:
+//
+//	averages, err := WindowAggregate(prices, 5, 1, func(w []Price) float64 {
+//	    return meanPrice(w)
+//	})
+func WindowAggregate[T, R any](data []T, size, step int, aggregate func(window []T) R) ([]R, error) {
+	windows, err := Window(data, size, step)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregated := make([]R, len(windows))
+	for i, window := range windows {
+		aggregated[i] = aggregate(window)
+	}
+	return aggregated, nil
+}
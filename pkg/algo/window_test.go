@@ -0,0 +1,102 @@
+package algo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWindow_Sliding(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	expected := [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}
+
+	windows, err := Window(data, 3, 1)
+	if err != nil {
+		t.Fatalf("Window failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(windows, expected) {
+		t.Errorf("Expected %v, got %v", expected, windows)
+	}
+}
+
+func TestWindow_Tumbling(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	expected := [][]int{{1, 2}, {3, 4}}
+
+	windows, err := Window(data, 2, 2)
+	if err != nil {
+		t.Fatalf("Window failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(windows, expected) {
+		t.Errorf("Expected %v, got %v", expected, windows)
+	}
+}
+
+func TestWindow_ShorterThanSize(t *testing.T) {
+	windows, err := Window([]int{1, 2}, 3, 1)
+	if err != nil {
+		t.Fatalf("Window failed: %v", err)
+	}
+
+	if len(windows) != 0 {
+		t.Errorf("Expected no windows, but got %v", windows)
+	}
+}
+
+func TestWindow_InvalidStep(t *testing.T) {
+	if _, err := Window([]int{1, 2, 3}, 2, 0); err == nil {
+		t.Fatalf("Expected error for zero step, but got nil")
+	}
+}
+
+func TestWindowAggregate_MovingAverage(t *testing.T) {
+	data := []int{2, 4, 6, 8}
+	expected := []float64{4, 6}
+
+	averages, err := WindowAggregate(data, 3, 1, func(w []int) float64 {
+		sum := 0
+		for _, x := range w {
+			sum += x
+		}
+		return float64(sum) / float64(len(w))
+	})
+	if err != nil {
+		t.Fatalf("WindowAggregate failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(averages, expected) {
+		t.Errorf("Expected %v, got %v", expected, averages)
+	}
+}
+
+func TestWindowOperation_MovingMax(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Window(2, 1, func(w []int) int { return max(w[0], w[1]) })
+
+	data := []int{3, 1, 4, 1, 5}
+	expected := []int{3, 4, 4, 5}
+
+	pipeline.WithData(data)
+
+	windowedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("WindowOperation failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(windowedData, expected) {
+		t.Errorf("Expected %v, got %v", expected, windowedData)
+	}
+}
+
+func TestWindowOperation_InvalidSize(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Window(0, 1, func(w []int) int { return 0 })
+
+	pipeline.WithData([]int{1, 2, 3})
+
+	_, err := pipeline.Execute()
+	if err == nil {
+		t.Fatalf("Expected error for zero window size, but got nil")
+	}
+}
@@ -0,0 +1,75 @@
+package algo
+
+// ZipMode controls how Zip handles inputs of different lengths.
+type ZipMode int
+
+const (
+	// ZipTruncate stops at the end of the shorter input.
+	ZipTruncate ZipMode = iota
+	// ZipPad continues to the end of the longer input, passing the zero value for the missing side.
+	ZipPad
+)
+
+// ZipOperation combines the data element-wise with another slice.
+// The i-th output element is produced from the i-th element of both inputs.
+type ZipOperation[T any] struct {
+	Other    []T
+	Combiner func(a, b T) T
+	Mode     ZipMode
+}
+
+// Apply performs the zip operation on the data.
+// It returns a new slice of combined elements whose length depends on Mode.
+//
+// Example:
+//
+//	pipeline := NewPipelineWithData([]int{1, 2, 3}).
+//	    Zip([]int{10, 20, 30}, func(a, b int) int { return a + b }, ZipTruncate)
+//	result, err := pipeline.Execute() // [11 22 33]
+func (z *ZipOperation[T]) Apply(data []T) ([]T, error) {
+	return Zip(data, z.Other, z.Combiner, z.Mode), nil
+}
+
+// Zip adds a zip operation to the pipeline.
+// The combiner function receives the pipeline element and the corresponding element of other.
+//
+// Example:
+//
+//	pipeline.Zip(adjustments, func(price, adj Price) Price {
+//	    price.Amount += adj.Amount
+//	    return price
+//	}, ZipPad)
+func (p *Pipeline[T]) Zip(other []T, combiner func(a, b T) T, mode ZipMode) *Pipeline[T] {
+	p.operations = append(p.operations, &ZipOperation[T]{Other: other, Combiner: combiner, Mode: mode})
+	return p
+}
+
+// Zip combines two slices element-wise into a slice of a possibly different type.
+// With ZipTruncate the result is as long as the shorter input; with ZipPad it is as long
+// as the longer input and the combiner receives the zero value for the missing side.
+//
+// Example:
+//
+//	labels := Zip(names, scores, func(name string, score int) string {
+//	    return fmt.Sprintf("%s: %d", name, score)
+//	}, ZipTruncate)
+func Zip[A, B, R any](a []A, b []B, combiner func(a A, b B) R, mode ZipMode) []R {
+	n := min(len(a), len(b))
+	if mode == ZipPad {
+		n = max(len(a), len(b))
+	}
+
+	zipped := make([]R, n)
+	for i := 0; i < n; i++ {
+		var left A
+		var right B
+		if i < len(a) {
+			left = a[i]
+		}
+		if i < len(b) {
+			right = b[i]
+		}
+		zipped[i] = combiner(left, right)
+	}
+	return zipped
+}
@@ -0,0 +1,55 @@
+package algo
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestZipOperation_Truncate(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Zip([]int{10, 20}, func(a, b int) int { return a + b }, ZipTruncate)
+
+	pipeline.WithData([]int{1, 2, 3})
+
+	zippedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ZipOperation failed: %v", err)
+	}
+
+	expected := []int{11, 22}
+	if !reflect.DeepEqual(zippedData, expected) {
+		t.Errorf("Expected %v, got %v", expected, zippedData)
+	}
+}
+
+func TestZipOperation_Pad(t *testing.T) {
+	pipeline := NewPipeline[int]().
+		Zip([]int{10, 20}, func(a, b int) int { return a + b }, ZipPad)
+
+	pipeline.WithData([]int{1, 2, 3})
+
+	zippedData, err := pipeline.Execute()
+	if err != nil {
+		t.Fatalf("ZipOperation failed: %v", err)
+	}
+
+	expected := []int{11, 22, 3}
+	if !reflect.DeepEqual(zippedData, expected) {
+		t.Errorf("Expected %v, got %v", expected, zippedData)
+	}
+}
+
+func TestZip_DifferentTypes(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	counts := []int{1, 2}
+
+	labels := Zip(names, counts, func(name string, count int) string {
+		return name + strconv.Itoa(count)
+	}, ZipPad)
+
+	expected := []string{"a1", "b2", "c0"}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("Expected %v, got %v", expected, labels)
+	}
+}
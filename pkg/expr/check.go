@@ -0,0 +1,226 @@
+package expr
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/fieldpath"
+)
+
+// Type is the type of an expression.
+type Type int
+
+const (
+	// Invalid is the type of fields that expressions cannot use.
+	Invalid Type = iota
+	// Bool is the type of true, false, comparisons and logical operators.
+	Bool
+	// Int is the type of integer literals and fields of any integer kind, including time.Duration.
+	Int
+	// Float is the type of decimal literals and float fields.
+	Float
+	// String is the type of string literals and fields.
+	String
+	// Time is the type of time.Time fields and of date().
+	Time
+)
+
+// typeNames holds the name of each type.
+var typeNames = [...]string{
+	Invalid: "invalid", Bool: "bool", Int: "int", Float: "float", String: "string", Time: "time",
+}
+
+// String returns the name of the type.
+func (t Type) String() string {
+	if t == numberParam {
+		return "number"
+	}
+	if t < 0 || int(t) >= len(typeNames) {
+		return "invalid"
+	}
+	return typeNames[t]
+}
+
+// numeric reports whether the type is Int or Float.
+func (t Type) numeric() bool {
+	return t == Int || t == Float
+}
+
+// ordered reports whether values of the type can be compared with < and >.
+func (t Type) ordered() bool {
+	return t == Int || t == Float || t == String || t == Time
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// typeOf returns the expression type of a Go type.
+func typeOf(t reflect.Type) Type {
+	if t == timeType {
+		return Time
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return Bool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Int
+	case reflect.Float32, reflect.Float64:
+		return Float
+	case reflect.String:
+		return String
+	}
+	return Invalid
+}
+
+// field is a resolved field path of the element type.
+type field struct {
+	*fieldpath.Field
+	typ Type
+}
+
+// checker resolves fields and assigns types to the nodes of a syntax tree.
+type checker struct {
+	src  string
+	root reflect.Type
+}
+
+// check type-checks a node and returns its type.
+func (c *checker) check(n node) (Type, error) {
+	switch n := n.(type) {
+	case *literal:
+		switch n.value.(type) {
+		case bool:
+			return Bool, nil
+		case int64:
+			return Int, nil
+		case float64:
+			return Float, nil
+		}
+		return String, nil
+	case *fieldRef:
+		f, err := c.resolve(n)
+		if err != nil {
+			return Invalid, err
+		}
+		n.field = f
+		return f.typ, nil
+	case *unaryExpr:
+		return c.checkUnary(n)
+	case *binaryExpr:
+		return c.checkBinary(n)
+	case *callExpr:
+		return c.checkCall(n)
+	}
+	return Invalid, newError(c.src, n.position(), "unsupported expression")
+}
+
+// resolve looks up a field path in the element type with fieldpath.Resolve, reporting
+// the column of the segment that does not resolve.
+func (c *checker) resolve(ref *fieldRef) (*field, error) {
+	resolved, err := fieldpath.Resolve(c.root, strings.Join(ref.path, "."))
+	if err != nil {
+		var pathErr *fieldpath.Error
+		if errors.As(err, &pathErr) {
+			return nil, newError(c.src, ref.offsets[pathErr.Segment], "%v", err)
+		}
+		return nil, newError(c.src, ref.pos, "%v", err)
+	}
+
+	f := &field{Field: resolved, typ: typeOf(resolved.Type)}
+	if f.typ == Invalid {
+		return nil, newError(c.src, ref.pos, "field %s of type %s cannot be used in expressions", f.Name, f.Type)
+	}
+	return f, nil
+}
+
+// checkUnary type-checks ! and unary -.
+func (c *checker) checkUnary(n *unaryExpr) (Type, error) {
+	x, err := c.check(n.x)
+	if err != nil {
+		return Invalid, err
+	}
+	switch {
+	case n.op == tokenNot && x == Bool, n.op == tokenMinus && x.numeric():
+		n.typ = x
+		return x, nil
+	}
+	return Invalid, newError(c.src, n.pos, "operator %s is not defined for %s", tokenNames[n.op], x)
+}
+
+// checkBinary type-checks logical, comparison and arithmetic operators.
+// Int operands are converted to Float when mixed with Float operands.
+func (c *checker) checkBinary(n *binaryExpr) (Type, error) {
+	x, err := c.check(n.x)
+	if err != nil {
+		return Invalid, err
+	}
+	y, err := c.check(n.y)
+	if err != nil {
+		return Invalid, err
+	}
+
+	operand := x
+	if x != y {
+		if !x.numeric() || !y.numeric() {
+			return Invalid, newError(c.src, n.pos, "mismatched types %s and %s for %s", x, y, tokenNames[n.op])
+		}
+		operand = Float
+	}
+	n.opType = operand
+
+	defined := false
+	switch n.op {
+	case tokenAnd, tokenOr:
+		defined, n.typ = operand == Bool, Bool
+	case tokenEq, tokenNe:
+		defined, n.typ = true, Bool
+	case tokenLt, tokenLe, tokenGt, tokenGe:
+		defined, n.typ = operand.ordered(), Bool
+	case tokenPlus:
+		defined, n.typ = operand.numeric() || operand == String, operand
+	case tokenMinus, tokenStar, tokenSlash:
+		defined, n.typ = operand.numeric(), operand
+	case tokenPercent:
+		defined, n.typ = operand == Int, operand
+	}
+	if !defined {
+		return Invalid, newError(c.src, n.pos, "operator %s is not defined for %s", tokenNames[n.op], operand)
+	}
+	return n.typ, nil
+}
+
+// checkCall type-checks a call of a built-in function.
+func (c *checker) checkCall(n *callExpr) (Type, error) {
+	fn, ok := functions[strings.ToLower(n.name)]
+	if !ok {
+		return Invalid, newError(c.src, n.pos, "unknown function %q", n.name)
+	}
+	if len(n.args) != len(fn.params) {
+		return Invalid, newError(c.src, n.pos, "%s expects %d argument(s), got %d", fn.name, len(fn.params), len(n.args))
+	}
+
+	args := make([]Type, len(n.args))
+	for i, arg := range n.args {
+		t, err := c.check(arg)
+		if err != nil {
+			return Invalid, err
+		}
+		if want := fn.params[i]; t != want && !(want == numberParam && t.numeric()) {
+			return Invalid, newError(c.src, arg.position(), "argument %d of %s must be %s, got %s", i+1, fn.name, want, t)
+		}
+		args[i] = t
+	}
+
+	n.fn = fn
+	n.typ = fn.result(args)
+	if fn.prepare != nil {
+		constant, err := fn.prepare(n)
+		if err != nil {
+			return Invalid, newError(c.src, n.pos, "%s: %v", fn.name, err)
+		}
+		n.constant = constant
+	}
+	return n.typ, nil
+}
@@ -0,0 +1,45 @@
+package expr
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypeOf(t *testing.T) {
+	type Named float32
+	cases := map[reflect.Type]Type{
+		reflect.TypeOf(true):             Bool,
+		reflect.TypeOf(uint8(0)):         Int,
+		reflect.TypeOf(time.Duration(0)): Int,
+		reflect.TypeOf(Named(0)):         Float,
+		reflect.TypeOf(""):               String,
+		reflect.TypeOf(time.Time{}):      Time,
+		reflect.TypeOf([]int{}):          Invalid,
+		reflect.TypeOf(&Customer{}):      Invalid,
+	}
+	for goType, expected := range cases {
+		if got := typeOf(goType); got != expected {
+			t.Errorf("%s: expected %s, got %s", goType, expected, got)
+		}
+	}
+
+	if Type(42).String() != "invalid" || numberParam.String() != "number" || Time.String() != "time" {
+		t.Error("Unexpected type names")
+	}
+}
+
+func TestChecker_ResolvesFields(t *testing.T) {
+	n, err := parse("CUSTOMER.Region_Code")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	c := &checker{src: "CUSTOMER.Region_Code", root: reflect.TypeOf(Order{})}
+	typ, err := c.check(n)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if f := n.(*fieldRef).field; typ != String || f.Name != "Customer.Region" {
+		t.Errorf("Expected Customer.Region of type string, got %s of type %s", f.Name, typ)
+	}
+}
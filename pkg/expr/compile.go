@@ -0,0 +1,244 @@
+package expr
+
+import (
+	"cmp"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// compiled is a type-checked node compiled to a closure over the element.
+// Only the closure matching typ is set.
+type compiled struct {
+	typ Type
+	b   func(reflect.Value) bool
+	i   func(reflect.Value) int64
+	f   func(reflect.Value) float64
+	s   func(reflect.Value) string
+	t   func(reflect.Value) time.Time
+}
+
+// float returns the value as a float, converting Int values.
+func (c compiled) float() func(reflect.Value) float64 {
+	if c.typ == Int {
+		i := c.i
+		return func(v reflect.Value) float64 { return float64(i(v)) }
+	}
+	return c.f
+}
+
+// value returns the value as an any holding a bool, int64, float64, string or time.Time.
+func (c compiled) value() func(reflect.Value) any {
+	switch c.typ {
+	case Bool:
+		return func(v reflect.Value) any { return c.b(v) }
+	case Int:
+		return func(v reflect.Value) any { return c.i(v) }
+	case Float:
+		return func(v reflect.Value) any { return c.f(v) }
+	case String:
+		return func(v reflect.Value) any { return c.s(v) }
+	}
+	return func(v reflect.Value) any { return c.t(v) }
+}
+
+// compare returns a function ordering two elements by the value, with false before true.
+func (c compiled) compare() func(a, b reflect.Value) int {
+	switch c.typ {
+	case Bool:
+		return func(a, b reflect.Value) int {
+			x, y := c.b(a), c.b(b)
+			switch {
+			case x == y:
+				return 0
+			case y:
+				return -1
+			}
+			return 1
+		}
+	case Int:
+		return func(a, b reflect.Value) int { return cmp.Compare(c.i(a), c.i(b)) }
+	case Float:
+		return func(a, b reflect.Value) int { return cmp.Compare(c.f(a), c.f(b)) }
+	case String:
+		return func(a, b reflect.Value) int { return strings.Compare(c.s(a), c.s(b)) }
+	}
+	return func(a, b reflect.Value) int { return c.t(a).Compare(c.t(b)) }
+}
+
+// compileNode compiles a type-checked node.
+func compileNode(n node) compiled {
+	switch n := n.(type) {
+	case *literal:
+		return compileLiteral(n)
+	case *fieldRef:
+		return compileField(n.field)
+	case *unaryExpr:
+		x := compileNode(n.x)
+		switch {
+		case n.op == tokenNot:
+			return compiled{typ: Bool, b: func(v reflect.Value) bool { return !x.b(v) }}
+		case x.typ == Int:
+			return compiled{typ: Int, i: func(v reflect.Value) int64 { return -x.i(v) }}
+		}
+		return compiled{typ: Float, f: func(v reflect.Value) float64 { return -x.f(v) }}
+	case *binaryExpr:
+		return compileBinary(n)
+	case *callExpr:
+		args := make([]compiled, len(n.args))
+		for i, arg := range n.args {
+			args[i] = compileNode(arg)
+		}
+		return n.fn.compile(n, args)
+	}
+	panic("expr: unexpected node")
+}
+
+// compileLiteral compiles a constant.
+func compileLiteral(n *literal) compiled {
+	switch value := n.value.(type) {
+	case bool:
+		return compiled{typ: Bool, b: func(reflect.Value) bool { return value }}
+	case int64:
+		return compiled{typ: Int, i: func(reflect.Value) int64 { return value }}
+	case float64:
+		return compiled{typ: Float, f: func(reflect.Value) float64 { return value }}
+	}
+	s := n.value.(string)
+	return compiled{typ: String, s: func(reflect.Value) string { return s }}
+}
+
+// compileField compiles a field access. A nil pointer along the path yields the zero value.
+func compileField(f *field) compiled {
+	get := f.Value
+	switch f.typ {
+	case Bool:
+		return compiled{typ: Bool, b: func(v reflect.Value) bool { return get(v).Bool() }}
+	case Int:
+		if kind := f.Type.Kind(); kind >= reflect.Uint && kind <= reflect.Uintptr {
+			return compiled{typ: Int, i: func(v reflect.Value) int64 { return int64(get(v).Uint()) }}
+		}
+		return compiled{typ: Int, i: func(v reflect.Value) int64 { return get(v).Int() }}
+	case Float:
+		return compiled{typ: Float, f: func(v reflect.Value) float64 { return get(v).Float() }}
+	case String:
+		return compiled{typ: String, s: func(v reflect.Value) string { return get(v).String() }}
+	}
+	return compiled{typ: Time, t: func(v reflect.Value) time.Time { return get(v).Interface().(time.Time) }}
+}
+
+// compileBinary compiles logical, comparison and arithmetic operators.
+func compileBinary(n *binaryExpr) compiled {
+	x, y := compileNode(n.x), compileNode(n.y)
+	switch n.op {
+	case tokenAnd:
+		return compiled{typ: Bool, b: func(v reflect.Value) bool { return x.b(v) && y.b(v) }}
+	case tokenOr:
+		return compiled{typ: Bool, b: func(v reflect.Value) bool { return x.b(v) || y.b(v) }}
+	case tokenEq, tokenNe, tokenLt, tokenLe, tokenGt, tokenGe:
+		return compileComparison(n.op, n.opType, x, y)
+	}
+
+	if n.typ == String {
+		return compiled{typ: String, s: func(v reflect.Value) string { return x.s(v) + y.s(v) }}
+	}
+	if n.typ == Int {
+		return compiled{typ: Int, i: intArithmetic(n.op, x.i, y.i)}
+	}
+	return compiled{typ: Float, f: floatArithmetic(n.op, x.float(), y.float())}
+}
+
+// compileComparison compiles a comparison of two operands of the given type.
+func compileComparison(op tokenKind, operand Type, x, y compiled) compiled {
+	var compare func(v reflect.Value) int
+	switch operand {
+	case Bool:
+		compare = func(v reflect.Value) int {
+			if x.b(v) == y.b(v) {
+				return 0
+			}
+			return 1
+		}
+	case Int:
+		compare = func(v reflect.Value) int { return cmp.Compare(x.i(v), y.i(v)) }
+	case Float:
+		return compiled{typ: Bool, b: floatComparison(op, x.float(), y.float())}
+	case String:
+		compare = func(v reflect.Value) int { return strings.Compare(x.s(v), y.s(v)) }
+	default:
+		compare = func(v reflect.Value) int { return x.t(v).Compare(y.t(v)) }
+	}
+
+	var accept func(int) bool
+	switch op {
+	case tokenEq:
+		accept = func(c int) bool { return c == 0 }
+	case tokenNe:
+		accept = func(c int) bool { return c != 0 }
+	case tokenLt:
+		accept = func(c int) bool { return c < 0 }
+	case tokenLe:
+		accept = func(c int) bool { return c <= 0 }
+	case tokenGt:
+		accept = func(c int) bool { return c > 0 }
+	default:
+		accept = func(c int) bool { return c >= 0 }
+	}
+	return compiled{typ: Bool, b: func(v reflect.Value) bool { return accept(compare(v)) }}
+}
+
+// floatComparison compiles a float comparison with Go semantics, so that every comparison
+// involving NaN is false except !=.
+func floatComparison(op tokenKind, x, y func(reflect.Value) float64) func(reflect.Value) bool {
+	switch op {
+	case tokenEq:
+		return func(v reflect.Value) bool { return x(v) == y(v) }
+	case tokenNe:
+		return func(v reflect.Value) bool { return x(v) != y(v) }
+	case tokenLt:
+		return func(v reflect.Value) bool { return x(v) < y(v) }
+	case tokenLe:
+		return func(v reflect.Value) bool { return x(v) <= y(v) }
+	case tokenGt:
+		return func(v reflect.Value) bool { return x(v) > y(v) }
+	}
+	return func(v reflect.Value) bool { return x(v) >= y(v) }
+}
+
+// intArithmetic compiles integer arithmetic. Division and remainder by zero yield 0.
+func intArithmetic(op tokenKind, x, y func(reflect.Value) int64) func(reflect.Value) int64 {
+	switch op {
+	case tokenPlus:
+		return func(v reflect.Value) int64 { return x(v) + y(v) }
+	case tokenMinus:
+		return func(v reflect.Value) int64 { return x(v) - y(v) }
+	case tokenStar:
+		return func(v reflect.Value) int64 { return x(v) * y(v) }
+	case tokenSlash:
+		return func(v reflect.Value) int64 {
+			if d := y(v); d != 0 {
+				return x(v) / d
+			}
+			return 0
+		}
+	}
+	return func(v reflect.Value) int64 {
+		if d := y(v); d != 0 {
+			return x(v) % d
+		}
+		return 0
+	}
+}
+
+// floatArithmetic compiles floating-point arithmetic.
+func floatArithmetic(op tokenKind, x, y func(reflect.Value) float64) func(reflect.Value) float64 {
+	switch op {
+	case tokenPlus:
+		return func(v reflect.Value) float64 { return x(v) + y(v) }
+	case tokenMinus:
+		return func(v reflect.Value) float64 { return x(v) - y(v) }
+	case tokenStar:
+		return func(v reflect.Value) float64 { return x(v) * y(v) }
+	}
+	return func(v reflect.Value) float64 { return x(v) / y(v) }
+}
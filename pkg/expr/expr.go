@@ -0,0 +1,211 @@
+// Package expr is a small expression language for predicates and key selectors over structs,
+// so that filters and sort keys can be supplied as text, for example from an admin UI.
+//
+//	amount > 100 && status == "completed"
+//	startsWith(lower(customer.name), "a") || !customer.vip
+//
+// Identifiers name fields of the element type by Go name or `algo` tag, ignoring case, with
+// dots for nested structs; a nil pointer along the path reads as the zero value. Literals are
+// numbers, "double" or 'single' quoted strings, true and false. Operators, loosest first:
+//
+//	|| or
+//	&& and
+//	== != < <= > >= (also = and <>)
+//	+ - (+ also joins strings)
+//	* / %
+//	! not, unary -
+//
+// Arithmetic follows Go, except that integer division and remainder by zero yield 0 rather than
+// panicking. Float division by zero yields ±Inf or NaN, and every comparison with NaN is false
+// except !=. Sort keys order NaN before all other floats.
+//
+// Built-in functions are lower, upper, trim, len, contains, startsWith, endsWith, abs,
+// matches(s, "regexp") and date("2024-03-01").
+//
+// Expressions are parsed and type-checked against the element type when compiled; errors
+// report the column of the offending token:
+//
+//	filter, err := expr.CompileFilter[Order](`amount > 100 && status == "completed"`)
+//	result, err := algo.NewPipelineWithData(orders).Filter(filter).Execute()
+package expr
+
+import (
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
+
+// Error reports an invalid expression.
+// Column is the 1-based position, in characters, of the offending part of Expr.
+type Error struct {
+	Expr    string
+	Column  int
+	Message string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+// newError creates an Error at a byte offset of src.
+func newError(src string, pos int, format string, args ...any) *Error {
+	return &Error{Expr: src, Column: utf8.RuneCountInString(src[:pos]) + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+// Expr is an expression compiled for elements of type T.
+type Expr[T any] struct {
+	src  string
+	code compiled
+}
+
+// Compile parses and type-checks an expression against T.
+//
+// Example:
+//
+//	e, err := expr.Compile[Order]("amount * quantity")
+//	total := e.Eval(order) // float64
+func Compile[T any](src string) (*Expr[T], error) {
+	n, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	code, err := compileChecked[T](src, n)
+	if err != nil {
+		return nil, err
+	}
+	return &Expr[T]{src: src, code: code}, nil
+}
+
+// compileChecked type-checks a parsed node against T and compiles it.
+func compileChecked[T any](src string, n node) (compiled, error) {
+	c := &checker{src: src, root: reflect.TypeOf((*T)(nil)).Elem()}
+	if _, err := c.check(n); err != nil {
+		return compiled{}, err
+	}
+	return compileNode(n), nil
+}
+
+// root returns the reflect.Value that compiled closures read fields from.
+func root[T any](item *T) reflect.Value {
+	return reflect.ValueOf(item).Elem()
+}
+
+// String returns the source of the expression.
+func (e *Expr[T]) String() string {
+	return e.src
+}
+
+// Type returns the type of the expression.
+func (e *Expr[T]) Type() Type {
+	return e.code.typ
+}
+
+// Eval evaluates the expression for an element. The result is a bool, int64, float64,
+// string or time.Time depending on Type.
+func (e *Expr[T]) Eval(item T) any {
+	return e.code.value()(root(&item))
+}
+
+// CompileFilter compiles a boolean expression into a predicate for Filter or Find.
+//
+// Example:
+//
+//	filter, err := expr.CompileFilter[Order](`status = 'completed' and amount >= 100`)
+//	result, err := algo.NewPipelineWithData(orders).Filter(filter).Execute()
+func CompileFilter[T any](src string) (func(T) bool, error) {
+	e, err := Compile[T](src)
+	if err != nil {
+		return nil, err
+	}
+	if e.code.typ != Bool {
+		return nil, &Error{Expr: src, Column: 1, Message: fmt.Sprintf("expected a bool expression, got %s", e.code.typ)}
+	}
+	b := e.code.b
+	return func(item T) bool { return b(root(&item)) }, nil
+}
+
+// CompileKey compiles an expression into a key selector for GroupBy and similar functions.
+// K must hold the type of the expression: bool, a string type, an integer or float type for
+// numbers, time.Time, or any.
+//
+// Example:
+//
+//	region, err := expr.CompileKey[Order, string]("upper(customer.region)")
+//	groups := algo.GroupBy(orders, region)
+func CompileKey[T any, K comparable](src string) (func(T) K, error) {
+	e, err := Compile[T](src)
+	if err != nil {
+		return nil, err
+	}
+
+	keyType := reflect.TypeOf((*K)(nil)).Elem()
+	value := e.code.value()
+	if keyType.Kind() == reflect.Interface || goTypes[e.code.typ] == keyType {
+		return func(item T) K { return value(root(&item)).(K) }, nil
+	}
+	if !assignable(e.code.typ, keyType) {
+		message := fmt.Sprintf("cannot use %s expression as %s key", e.code.typ, keyType)
+		return nil, &Error{Expr: src, Column: 1, Message: message}
+	}
+	return func(item T) K {
+		return reflect.ValueOf(value(root(&item))).Convert(keyType).Interface().(K)
+	}, nil
+}
+
+// goTypes holds the Go type of the values of each expression type, as returned by Eval.
+var goTypes = map[Type]reflect.Type{
+	Bool:   reflect.TypeOf(false),
+	Int:    reflect.TypeOf(int64(0)),
+	Float:  reflect.TypeOf(float64(0)),
+	String: reflect.TypeOf(""),
+	Time:   timeType,
+}
+
+// assignable reports whether values of an expression type convert to a Go type without loss of meaning.
+func assignable(t Type, goType reflect.Type) bool {
+	target := typeOf(goType)
+	return t == target || (t == Int && target == Float)
+}
+
+// CompileLess compiles a comma-separated list of key expressions, each optionally followed
+// by asc or desc, into a comparator for QuickSort, MergeSort and the other sorting operations.
+// Later keys break ties of earlier ones.
+//
+// Example:
+//
+//	less, err := expr.CompileLess[Order]("lower(region), amount desc")
+//	result, err := algo.NewPipelineWithData(orders).MergeSort(less).Execute()
+func CompileLess[T any](src string) (func(a, b T) bool, error) {
+	keys, err := parseOrder(src)
+	if err != nil {
+		return nil, err
+	}
+
+	type compiledKey struct {
+		compare    func(a, b reflect.Value) int
+		descending bool
+	}
+	compiledKeys := make([]compiledKey, 0, len(keys))
+	for _, key := range keys {
+		code, err := compileChecked[T](src, key.x)
+		if err != nil {
+			return nil, err
+		}
+		compiledKeys = append(compiledKeys, compiledKey{compare: code.compare(), descending: key.descending})
+	}
+
+	return func(a, b T) bool {
+		ra, rb := root(&a), root(&b)
+		for _, key := range compiledKeys {
+			c := key.compare(ra, rb)
+			if key.descending {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	}, nil
+}
@@ -0,0 +1,242 @@
+package expr
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Customer struct {
+	Name   string
+	Region string `algo:"region_code"`
+	VIP    bool
+}
+
+type Order struct {
+	ID       int
+	Status   string
+	Amount   float64
+	Quantity uint16
+	Placed   time.Time
+	Wait     time.Duration
+	Customer Customer
+	Referrer *Customer
+	Tags     []string
+}
+
+func sampleOrders() []Order {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	return []Order{
+		{ID: 1, Status: "completed", Amount: 120, Quantity: 2, Placed: day(1), Customer: Customer{Name: "Alice", Region: "eu"}},
+		{ID: 2, Status: "pending", Amount: 80, Quantity: 1, Placed: day(2), Customer: Customer{Name: "bob", Region: "us", VIP: true}},
+		{ID: 3, Status: "completed", Amount: 300, Quantity: 5, Placed: day(3),
+			Customer: Customer{Name: "Carol", Region: "EU"}, Referrer: &Customer{Name: "Alice"}},
+		{ID: 4, Status: "completed", Amount: 99.5, Quantity: 3, Placed: day(4), Customer: Customer{Name: "dave", Region: "us"}},
+	}
+}
+
+func filterIDs(t *testing.T, src string) []int {
+	t.Helper()
+	filter, err := CompileFilter[Order](src)
+	if err != nil {
+		t.Fatalf("%q: unexpected error: %v", src, err)
+	}
+	var ids []int
+	for _, o := range sampleOrders() {
+		if filter(o) {
+			ids = append(ids, o.ID)
+		}
+	}
+	return ids
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCompileFilter(t *testing.T) {
+	cases := map[string][]int{
+		`amount > 100 && status == "completed"`:           {1, 3},
+		`status = 'completed' and not (amount >= 100)`:    {4},
+		`customer.vip || customer.region_code == "eu"`:    {1, 2},
+		`lower(customer.region) = 'eu'`:                   {1, 3},
+		`referrer.name == "Alice"`:                        {3},
+		`amount * quantity > 280`:                         {3, 4},
+		`quantity % 2 == 1 && -amount < -90`:              {3, 4},
+		`placed >= date("2024-03-03")`:                    {3, 4},
+		`startsWith(upper(customer.name), "A") or id = 4`: {1, 4},
+		`matches(customer.name, "^[a-z]")`:                {2, 4},
+		`len(customer.name) == 5 && id <> 1`:              {3},
+		`wait == 0 && true`:                               {1, 2, 3, 4},
+		`customer.name + "/" + status == "bob/pending"`:   {2},
+	}
+	for src, expected := range cases {
+		if ids := filterIDs(t, src); !equalInts(ids, expected) {
+			t.Errorf("%q: expected %v, got %v", src, expected, ids)
+		}
+	}
+}
+
+func TestCompileFilter_NaN(t *testing.T) {
+	// (amount - amount) / 0 is NaN for every order.
+	cases := map[string][]int{
+		`(amount - amount) / 0 < 1`:                          nil,
+		`(amount - amount) / 0 >= 1`:                         nil,
+		`(amount - amount) / 0 == (amount - amount) / 0`:     nil,
+		`(amount - amount) / 0 != (amount - amount) / 0`:     {1, 2, 3, 4},
+		`amount / 0 > 1000`:                                  {1, 2, 3, 4},
+		`!((amount - amount) / 0 <= amount) && amount > 100`: {1, 3},
+	}
+	for src, expected := range cases {
+		if ids := filterIDs(t, src); !equalInts(ids, expected) {
+			t.Errorf("%q: expected %v, got %v", src, expected, ids)
+		}
+	}
+}
+
+func TestCompileFilter_Errors(t *testing.T) {
+	cases := []struct {
+		src     string
+		column  int
+		message string
+	}{
+		{`amout > 100`, 1, `unknown field "amout" in expr.Order`},
+		{`customer.nmae == "x"`, 10, `unknown field "nmae" in expr.Customer`},
+		{`amount > "100"`, 8, `mismatched types float and string for '>'`},
+		{`status == "x" && amount`, 15, `mismatched types bool and float for '&&'`},
+		{`amount > 100 &`, 14, `unexpected '&' (did you mean "&&"?)`},
+		{`(amount > 100`, 14, `expected ')', found end of expression`},
+		{`1 < amount < 3`, 12, `comparisons cannot be chained; use && to combine them`},
+		{`tags == ""`, 1, `field Tags of type []string cannot be used in expressions`},
+		{`lower(amount) == ""`, 7, `argument 1 of lower must be string, got float`},
+		{`shout(status)`, 1, `unknown function "shout"`},
+		{`matches(status, "(")`, 1, "matches: error parsing regexp"},
+		{`matches(status, status)`, 1, "matches: argument must be a string literal"},
+		{`date("soon") < placed`, 1, "date: expected a date"},
+		{`"été" == 'x' && 1 # 2`, 19, `unexpected character '#'`},
+		{`amount`, 1, `expected a bool expression, got float`},
+		{`status == "open`, 11, `unterminated string`},
+		{`!amount`, 1, `operator '!' is not defined for float`},
+		{`amount % 2 == 0`, 8, `operator '%' is not defined for float`},
+		{`id.value == 1`, 4, `int has no field "value"`},
+		{`12abc > 1`, 3, `unexpected character 'a' after number`},
+		{`amount > `, 10, `unexpected end of expression`},
+	}
+	for _, tc := range cases {
+		_, err := CompileFilter[Order](tc.src)
+		var exprErr *Error
+		if !errors.As(err, &exprErr) {
+			t.Errorf("%q: expected *Error, got %v", tc.src, err)
+			continue
+		}
+		if exprErr.Column != tc.column || !strings.Contains(exprErr.Message, tc.message) {
+			t.Errorf("%q: expected column %d %q, got %v", tc.src, tc.column, tc.message, err)
+		}
+	}
+}
+
+func TestCompile_Eval(t *testing.T) {
+	order := sampleOrders()[0]
+	cases := map[string]any{
+		"amount * quantity":         240.0,
+		"quantity + 1":              int64(3),
+		"quantity / 0":              int64(0),
+		"7 / 2":                     int64(3),
+		"7 / 2.0":                   3.5,
+		"abs(-2) + abs(-1.5)":       3.5,
+		`upper(status) + "!"`:       "COMPLETED!",
+		"placed":                    order.Placed,
+		"id == 1.0":                 true,
+		`"tab\there" == 'tab	here'`: true,
+		`'it''s' == "it's"`:         true,
+	}
+	for src, expected := range cases {
+		e, err := Compile[Order](src)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", src, err)
+			continue
+		}
+		if got := e.Eval(order); got != expected {
+			t.Errorf("%q: expected %v (%T), got %v (%T)", src, expected, expected, got, got)
+		}
+		if e.String() != src {
+			t.Errorf("Expected source %q, got %q", src, e.String())
+		}
+	}
+
+	e, _ := Compile[*Order]("referrer.name")
+	if e.Type() != String || e.Eval(&order) != "" || e.Eval(nil) != "" {
+		t.Error("Expected nil pointers to read as the zero value")
+	}
+}
+
+type Region string
+
+func TestCompileKey(t *testing.T) {
+	region, err := CompileKey[Order, Region]("lower(customer.region_code)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	counts := map[Region]int{}
+	for _, o := range sampleOrders() {
+		counts[region(o)]++
+	}
+	if counts["eu"] != 2 || counts["us"] != 2 {
+		t.Errorf("Unexpected groups %v", counts)
+	}
+
+	quantity, err := CompileKey[Order, int]("quantity * 2")
+	if err != nil || quantity(sampleOrders()[2]) != 10 {
+		t.Errorf("Expected 10, got error %v", err)
+	}
+	asFloat, err := CompileKey[Order, float64]("quantity")
+	if err != nil || asFloat(sampleOrders()[2]) != 5 {
+		t.Errorf("Expected 5, got error %v", err)
+	}
+	anyKey, err := CompileKey[Order, any]("customer.vip")
+	if err != nil || anyKey(sampleOrders()[1]) != true {
+		t.Errorf("Expected true, got error %v", err)
+	}
+
+	if _, err := CompileKey[Order, int]("amount"); err == nil {
+		t.Error("Expected error for a float expression as an int key")
+	}
+	if _, err := CompileKey[Order, string]("amount >"); err == nil {
+		t.Error("Expected syntax error")
+	}
+}
+
+func TestCompileLess(t *testing.T) {
+	less, err := CompileLess[Order]("lower(customer.region), amount DESC")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	orders := sampleOrders()
+	sort.SliceStable(orders, func(i, j int) bool { return less(orders[i], orders[j]) })
+
+	var ids []int
+	for _, o := range orders {
+		ids = append(ids, o.ID)
+	}
+	if expected := []int{3, 1, 4, 2}; !equalInts(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+
+	for src, column := range map[string]int{"amount sideways": 8, "amount,": 8, "amout": 1, "amount desc desc": 13} {
+		_, err := CompileLess[Order](src)
+		var exprErr *Error
+		if !errors.As(err, &exprErr) || exprErr.Column != column {
+			t.Errorf("%q: expected an error at column %d, got %v", src, column, err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package expr
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// numberParam marks a function parameter that accepts Int or Float.
+const numberParam Type = -1
+
+// function is a built-in function.
+// Prepare, if set, computes a constant from literal arguments when the call is checked,
+// so that invalid constants are reported with the expression.
+type function struct {
+	name    string
+	params  []Type
+	result  func(args []Type) Type
+	prepare func(call *callExpr) (any, error)
+	compile func(call *callExpr, args []compiled) compiled
+}
+
+// returns builds a result function for a fixed result type.
+func returns(t Type) func([]Type) Type {
+	return func([]Type) Type { return t }
+}
+
+// stringFunction builds a function from string to string.
+func stringFunction(name string, fn func(string) string) *function {
+	return &function{
+		name: name, params: []Type{String}, result: returns(String),
+		compile: func(_ *callExpr, args []compiled) compiled {
+			s := args[0].s
+			return compiled{typ: String, s: func(v reflect.Value) string { return fn(s(v)) }}
+		},
+	}
+}
+
+// predicateFunction builds a function from two strings to bool.
+func predicateFunction(name string, fn func(s, arg string) bool) *function {
+	return &function{
+		name: name, params: []Type{String, String}, result: returns(Bool),
+		compile: func(_ *callExpr, args []compiled) compiled {
+			s, arg := args[0].s, args[1].s
+			return compiled{typ: Bool, b: func(v reflect.Value) bool { return fn(s(v), arg(v)) }}
+		},
+	}
+}
+
+// stringLiteral returns the value of a string literal argument.
+func stringLiteral(call *callExpr, i int) (string, error) {
+	lit, ok := call.args[i].(*literal)
+	if !ok {
+		return "", errors.New("argument must be a string literal")
+	}
+	return lit.value.(string), nil
+}
+
+// functions holds the built-in functions by lower-case name.
+var functions = map[string]*function{
+	"lower":      stringFunction("lower", strings.ToLower),
+	"upper":      stringFunction("upper", strings.ToUpper),
+	"trim":       stringFunction("trim", strings.TrimSpace),
+	"contains":   predicateFunction("contains", strings.Contains),
+	"startswith": predicateFunction("startsWith", strings.HasPrefix),
+	"endswith":   predicateFunction("endsWith", strings.HasSuffix),
+	"len": {
+		name: "len", params: []Type{String}, result: returns(Int),
+		compile: func(_ *callExpr, args []compiled) compiled {
+			s := args[0].s
+			return compiled{typ: Int, i: func(v reflect.Value) int64 { return int64(utf8.RuneCountInString(s(v))) }}
+		},
+	},
+	"abs": {
+		name: "abs", params: []Type{numberParam}, result: func(args []Type) Type { return args[0] },
+		compile: func(_ *callExpr, args []compiled) compiled {
+			if args[0].typ == Int {
+				i := args[0].i
+				return compiled{typ: Int, i: func(v reflect.Value) int64 {
+					n := i(v)
+					if n < 0 {
+						return -n
+					}
+					return n
+				}}
+			}
+			f := args[0].f
+			return compiled{typ: Float, f: func(v reflect.Value) float64 { return math.Abs(f(v)) }}
+		},
+	},
+	"matches": {
+		name: "matches", params: []Type{String, String}, result: returns(Bool),
+		prepare: func(call *callExpr) (any, error) {
+			pattern, err := stringLiteral(call, 1)
+			if err != nil {
+				return nil, err
+			}
+			return regexp.Compile(pattern)
+		},
+		compile: func(call *callExpr, args []compiled) compiled {
+			s, re := args[0].s, call.constant.(*regexp.Regexp)
+			return compiled{typ: Bool, b: func(v reflect.Value) bool { return re.MatchString(s(v)) }}
+		},
+	},
+	"date": {
+		name: "date", params: []Type{String}, result: returns(Time),
+		prepare: func(call *callExpr) (any, error) {
+			text, err := stringLiteral(call, 0)
+			if err != nil {
+				return nil, err
+			}
+			if t, err := time.Parse(time.RFC3339, text); err == nil {
+				return t, nil
+			}
+			t, err := time.Parse(time.DateOnly, text)
+			if err != nil {
+				return nil, errors.New("expected a date such as \"2024-03-01\" or an RFC 3339 time")
+			}
+			return t, nil
+		},
+		compile: func(call *callExpr, _ []compiled) compiled {
+			t := call.constant.(time.Time)
+			return compiled{typ: Time, t: func(reflect.Value) time.Time { return t }}
+		},
+	},
+}
@@ -0,0 +1,35 @@
+package expr
+
+import "testing"
+
+func TestFunctions(t *testing.T) {
+	order := Order{Status: " Shipped ", Amount: -2.5, Quantity: 3, Customer: Customer{Name: "Ünal"}}
+	cases := map[string]any{
+		"lower(status)":                                     " shipped ",
+		"UPPER(trim(status))":                               "SHIPPED",
+		"len(customer.name)":                                int64(4),
+		`contains(status, "hip")`:                           true,
+		`startsWith(trim(status), "Sh")`:                    true,
+		`endsWith(status, "d")`:                             false,
+		"abs(amount)":                                       2.5,
+		"abs(0 - quantity)":                                 int64(3),
+		`matches(status, "(?i)shipped")`:                    true,
+		`date("2024-03-01T10:00:00Z") < date("2024-03-02")`: true,
+	}
+	for src, expected := range cases {
+		e, err := Compile[Order](src)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", src, err)
+			continue
+		}
+		if got := e.Eval(order); got != expected {
+			t.Errorf("%q: expected %v, got %v", src, expected, got)
+		}
+	}
+
+	for _, src := range []string{"len()", `contains(status)`, "abs(status)", `lower("a", "b")`} {
+		if _, err := Compile[Order](src); err == nil {
+			t.Errorf("%q: expected error", src)
+		}
+	}
+}
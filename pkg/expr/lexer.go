@@ -0,0 +1,215 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind identifies the kind of a token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenTrue
+	tokenFalse
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenDot
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenEq
+	tokenNe
+	tokenLt
+	tokenLe
+	tokenGt
+	tokenGe
+	tokenPlus
+	tokenMinus
+	tokenStar
+	tokenSlash
+	tokenPercent
+)
+
+// tokenNames holds how each token kind is shown in error messages.
+var tokenNames = map[tokenKind]string{
+	tokenEOF: "end of expression", tokenIdent: "identifier", tokenInt: "number", tokenFloat: "number",
+	tokenString: "string", tokenTrue: "true", tokenFalse: "false", tokenLParen: "'('", tokenRParen: "')'",
+	tokenComma: "','", tokenDot: "'.'", tokenNot: "'!'", tokenAnd: "'&&'", tokenOr: "'||'", tokenEq: "'=='",
+	tokenNe: "'!='", tokenLt: "'<'", tokenLe: "'<='", tokenGt: "'>'", tokenGe: "'>='", tokenPlus: "'+'",
+	tokenMinus: "'-'", tokenStar: "'*'", tokenSlash: "'/'", tokenPercent: "'%'",
+}
+
+// keywords maps the case-insensitive word forms of literals and logical operators to tokens.
+var keywords = map[string]tokenKind{
+	"true": tokenTrue, "false": tokenFalse, "and": tokenAnd, "or": tokenOr, "not": tokenNot,
+}
+
+// token is a lexical token. Pos is the byte offset of the token in the source and
+// text its source text; for strings, text holds the unquoted value.
+type token struct {
+	kind tokenKind
+	pos  int
+	text string
+}
+
+// String describes the token for error messages.
+func (t token) String() string {
+	switch t.kind {
+	case tokenIdent, tokenInt, tokenFloat:
+		return fmt.Sprintf("%q", t.text)
+	case tokenString:
+		return "string " + fmt.Sprintf("%q", t.text)
+	}
+	return tokenNames[t.kind]
+}
+
+// operators maps operator spellings to tokens, longest first.
+var operators = []struct {
+	text string
+	kind tokenKind
+}{
+	{"&&", tokenAnd}, {"||", tokenOr}, {"==", tokenEq}, {"!=", tokenNe}, {"<>", tokenNe},
+	{"<=", tokenLe}, {">=", tokenGe}, {"=", tokenEq}, {"<", tokenLt}, {">", tokenGt}, {"!", tokenNot},
+	{"+", tokenPlus}, {"-", tokenMinus}, {"*", tokenStar}, {"/", tokenSlash}, {"%", tokenPercent},
+	{"(", tokenLParen}, {")", tokenRParen}, {",", tokenComma}, {".", tokenDot},
+}
+
+// lex splits an expression into tokens, ending with a tokenEOF token.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	pos := 0
+	for pos < len(src) {
+		r, size := utf8.DecodeRuneInString(src[pos:])
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+		case r == '_' || unicode.IsLetter(r):
+			end := pos
+			for end < len(src) {
+				r, size := utf8.DecodeRuneInString(src[end:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				end += size
+			}
+			text := src[pos:end]
+			kind, ok := keywords[strings.ToLower(text)]
+			if !ok {
+				kind = tokenIdent
+			}
+			tokens = append(tokens, token{kind: kind, pos: pos, text: text})
+			pos = end
+		case r >= '0' && r <= '9':
+			tok, err := lexNumber(src, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			pos += len(tok.text)
+		case r == '"' || r == '\'':
+			tok, end, err := lexString(src, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			pos = end
+		default:
+			tok, ok := lexOperator(src, pos)
+			if !ok {
+				if r == '&' || r == '|' {
+					return nil, newError(src, pos, "unexpected %q (did you mean %q?)", r, string([]rune{r, r}))
+				}
+				return nil, newError(src, pos, "unexpected character %q", r)
+			}
+			tokens = append(tokens, tok)
+			pos += len(tok.text)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+// lexOperator reads an operator or punctuation token at pos.
+func lexOperator(src string, pos int) (token, bool) {
+	for _, op := range operators {
+		if strings.HasPrefix(src[pos:], op.text) {
+			return token{kind: op.kind, pos: pos, text: op.text}, true
+		}
+	}
+	return token{}, false
+}
+
+// lexNumber reads an integer or a decimal number with an optional exponent at pos.
+func lexNumber(src string, pos int) (token, error) {
+	end := pos
+	digits := func() {
+		for end < len(src) && src[end] >= '0' && src[end] <= '9' {
+			end++
+		}
+	}
+
+	kind := tokenInt
+	digits()
+	if end+1 < len(src) && src[end] == '.' && src[end+1] >= '0' && src[end+1] <= '9' {
+		kind = tokenFloat
+		end++
+		digits()
+	}
+	if end < len(src) && (src[end] == 'e' || src[end] == 'E') {
+		kind = tokenFloat
+		end++
+		if end < len(src) && (src[end] == '+' || src[end] == '-') {
+			end++
+		}
+		start := end
+		digits()
+		if end == start {
+			return token{}, newError(src, pos, "invalid number %q", src[pos:end])
+		}
+	}
+	if end < len(src) {
+		if r, _ := utf8.DecodeRuneInString(src[end:]); r == '_' || unicode.IsLetter(r) {
+			return token{}, newError(src, end, "unexpected character %q after number", r)
+		}
+	}
+	return token{kind: kind, pos: pos, text: src[pos:end]}, nil
+}
+
+// lexString reads a quoted string at pos and returns the token and the offset after it.
+// Double-quoted strings use Go escapes; in single-quoted strings, as in SQL, a quote is written twice.
+func lexString(src string, pos int) (token, int, error) {
+	quote := src[pos]
+	var b strings.Builder
+	for i := pos + 1; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == quote && quote == '\'' && i+1 < len(src) && src[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case c == quote:
+			return token{kind: tokenString, pos: pos, text: b.String()}, i + 1, nil
+		case c == '\\' && quote == '"':
+			value, multibyte, tail, err := strconv.UnquoteChar(src[i:], quote)
+			if err != nil {
+				return token{}, 0, newError(src, i, "invalid escape in string")
+			}
+			if value < utf8.RuneSelf || multibyte {
+				b.WriteRune(value)
+			} else {
+				b.WriteByte(byte(value))
+			}
+			i = len(src) - len(tail) - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return token{}, 0, newError(src, pos, "unterminated string")
+}
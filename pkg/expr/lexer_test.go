@@ -0,0 +1,43 @@
+package expr
+
+import "testing"
+
+func TestLex(t *testing.T) {
+	tokens, err := lex(`a.b>=1.5e3 AND not "x\"y" <> 'it''s' || 7%2`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []token{
+		{tokenIdent, 0, "a"}, {tokenDot, 1, "."}, {tokenIdent, 2, "b"}, {tokenGe, 3, ">="},
+		{tokenFloat, 5, "1.5e3"}, {tokenAnd, 11, "AND"}, {tokenNot, 15, "not"}, {tokenString, 19, `x"y`},
+		{tokenNe, 26, "<>"}, {tokenString, 29, "it's"}, {tokenOr, 37, "||"}, {tokenInt, 40, "7"},
+		{tokenPercent, 41, "%"}, {tokenInt, 42, "2"}, {tokenEOF, 43, ""},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(expected), len(tokens), tokens)
+	}
+	for i := range expected {
+		if tokens[i] != expected[i] {
+			t.Errorf("Token %d: expected %+v, got %+v", i, expected[i], tokens[i])
+		}
+	}
+}
+
+func TestLex_Errors(t *testing.T) {
+	cases := map[string]int{
+		"a | b":     3,
+		"a @ b":     3,
+		"1e":        1,
+		`"\q"`:      2,
+		"'unclosed": 1,
+		"größe ~ 1": 7,
+	}
+	for src, column := range cases {
+		_, err := lex(src)
+		exprErr, ok := err.(*Error)
+		if !ok || exprErr.Column != column {
+			t.Errorf("%q: expected an error at column %d, got %v", src, column, err)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// node is a node of the syntax tree. Nodes record their position for error messages and
+// receive their type from the checker.
+type node interface {
+	position() int
+}
+
+// literal is a boolean, number or string constant.
+type literal struct {
+	pos   int
+	value any // bool, int64, float64 or string
+}
+
+// fieldRef refers to a field of the element, such as amount or customer.country.
+// Offsets holds the position of each name of the path.
+type fieldRef struct {
+	pos     int
+	path    []string
+	offsets []int
+	field   *field
+}
+
+// unaryExpr applies ! or - to an operand.
+type unaryExpr struct {
+	pos int
+	op  tokenKind
+	x   node
+	typ Type
+}
+
+// binaryExpr applies an operator to two operands. Pos is the position of the operator.
+type binaryExpr struct {
+	pos    int
+	op     tokenKind
+	x, y   node
+	typ    Type
+	opType Type
+}
+
+// callExpr calls a built-in function.
+type callExpr struct {
+	pos  int
+	name string
+	args []node
+	fn   *function
+	typ  Type
+	// constant holds a value the function computed from literal arguments when it was checked,
+	// such as a compiled regular expression.
+	constant any
+}
+
+func (n *literal) position() int    { return n.pos }
+func (n *fieldRef) position() int   { return n.pos }
+func (n *unaryExpr) position() int  { return n.pos }
+func (n *binaryExpr) position() int { return n.pos }
+func (n *callExpr) position() int   { return n.pos }
+
+// precedences holds the binding power of binary operators; higher binds tighter.
+var precedences = map[tokenKind]int{
+	tokenOr:  1,
+	tokenAnd: 2,
+	tokenEq:  3, tokenNe: 3, tokenLt: 3, tokenLe: 3, tokenGt: 3, tokenGe: 3,
+	tokenPlus: 4, tokenMinus: 4,
+	tokenStar: 5, tokenSlash: 5, tokenPercent: 5,
+}
+
+// comparisonPrecedence is the precedence of the comparison operators, which do not chain.
+const comparisonPrecedence = 3
+
+// parser builds a syntax tree from tokens by precedence climbing.
+type parser struct {
+	src    string
+	tokens []token
+	pos    int
+}
+
+// parse parses a complete expression.
+func parse(src string) (node, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	n, err := p.parseExpr(1)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectEnd(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// newParser lexes src and returns a parser positioned at its first token.
+func newParser(src string) (*parser, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{src: src, tokens: tokens}, nil
+}
+
+// peek returns the current token.
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next returns the current token and advances past it.
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// expect consumes a token of the given kind or reports what was found instead.
+func (p *parser) expect(kind tokenKind) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, newError(p.src, tok.pos, "expected %s, found %s", tokenNames[kind], tok)
+	}
+	return tok, nil
+}
+
+// expectEnd reports an error unless all tokens have been consumed.
+func (p *parser) expectEnd() error {
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return newError(p.src, tok.pos, "unexpected %s", tok)
+	}
+	return nil
+}
+
+// parseExpr parses a binary expression whose operators bind at least as tightly as minPrecedence.
+func (p *parser) parseExpr(minPrecedence int) (node, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		precedence, ok := precedences[op.kind]
+		if !ok || precedence < minPrecedence {
+			return x, nil
+		}
+		p.next()
+		y, err := p.parseExpr(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		if precedence == comparisonPrecedence {
+			if next := p.peek(); precedences[next.kind] == comparisonPrecedence {
+				return nil, newError(p.src, next.pos, "comparisons cannot be chained; use && to combine them")
+			}
+		}
+		x = &binaryExpr{pos: op.pos, op: op.kind, x: x, y: y}
+	}
+}
+
+// parseUnary parses a unary expression or a primary expression.
+func (p *parser) parseUnary() (node, error) {
+	if tok := p.peek(); tok.kind == tokenNot || tok.kind == tokenMinus {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{pos: tok.pos, op: tok.kind, x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a literal, a field reference, a function call or a parenthesized expression.
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenTrue, tokenFalse:
+		return &literal{pos: tok.pos, value: tok.kind == tokenTrue}, nil
+	case tokenString:
+		return &literal{pos: tok.pos, value: tok.text}, nil
+	case tokenInt:
+		n, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, newError(p.src, tok.pos, "integer %s out of range", tok.text)
+		}
+		return &literal{pos: tok.pos, value: n}, nil
+	case tokenFloat:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, newError(p.src, tok.pos, "number %s out of range", tok.text)
+		}
+		return &literal{pos: tok.pos, value: f}, nil
+	case tokenLParen:
+		x, err := p.parseExpr(1)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen); err != nil {
+			return nil, err
+		}
+		return x, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(tok)
+		}
+		ref := &fieldRef{pos: tok.pos, path: []string{tok.text}, offsets: []int{tok.pos}}
+		for p.peek().kind == tokenDot {
+			p.next()
+			name, err := p.expect(tokenIdent)
+			if err != nil {
+				return nil, err
+			}
+			ref.path = append(ref.path, name.text)
+			ref.offsets = append(ref.offsets, name.pos)
+		}
+		return ref, nil
+	}
+	return nil, newError(p.src, tok.pos, "unexpected %s", tok)
+}
+
+// parseCall parses the argument list of a function call.
+func (p *parser) parseCall(name token) (node, error) {
+	p.next()
+	call := &callExpr{pos: name.pos, name: name.text}
+	if p.peek().kind == tokenRParen {
+		p.next()
+		return call, nil
+	}
+	for {
+		arg, err := p.parseExpr(1)
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		if tok := p.next(); tok.kind != tokenComma {
+			if tok.kind != tokenRParen {
+				return nil, newError(p.src, tok.pos, "expected ',' or ')', found %s", tok)
+			}
+			return call, nil
+		}
+	}
+}
+
+// orderKey is one key of an ordering such as "region, amount desc".
+type orderKey struct {
+	x          node
+	descending bool
+}
+
+// parseOrder parses a comma-separated list of expressions, each optionally followed by asc or desc.
+func parseOrder(src string) ([]orderKey, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []orderKey
+	for {
+		x, err := p.parseExpr(1)
+		if err != nil {
+			return nil, err
+		}
+		key := orderKey{x: x}
+		if tok := p.peek(); tok.kind == tokenIdent {
+			switch strings.ToLower(tok.text) {
+			case "asc":
+			case "desc":
+				key.descending = true
+			default:
+				return nil, newError(src, tok.pos, "expected asc or desc, found %s", tok)
+			}
+			p.next()
+		}
+		keys = append(keys, key)
+
+		if p.peek().kind != tokenComma {
+			return keys, p.expectEnd()
+		}
+		p.next()
+	}
+}
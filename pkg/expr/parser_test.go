@@ -0,0 +1,69 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// format renders a syntax tree with explicit parentheses.
+func format(n node) string {
+	switch n := n.(type) {
+	case *literal:
+		return fmt.Sprint(n.value)
+	case *fieldRef:
+		return strings.Join(n.path, ".")
+	case *unaryExpr:
+		return fmt.Sprintf("(%s %s)", tokenNames[n.op], format(n.x))
+	case *binaryExpr:
+		return fmt.Sprintf("(%s %s %s)", format(n.x), tokenNames[n.op], format(n.y))
+	case *callExpr:
+		args := make([]string, len(n.args))
+		for i, arg := range n.args {
+			args[i] = format(arg)
+		}
+		return fmt.Sprintf("%s(%s)", n.name, strings.Join(args, ", "))
+	}
+	return "?"
+}
+
+func TestParse_Precedence(t *testing.T) {
+	cases := map[string]string{
+		"a || b && c":          "(a '||' (b '&&' c))",
+		"a + b * c - d":        "((a '+' (b '*' c)) '-' d)",
+		"-a.b < 2 == false":    "",
+		"!(a == 1) && f(x, 2)": "(('!' (a '==' 1)) '&&' f(x, 2))",
+		"a - b - c":            "((a '-' b) '-' c)",
+		"now()":                "now()",
+		"x.y.z >= 1.5":         "(x.y.z '>=' 1.5)",
+	}
+	for src, expected := range cases {
+		n, err := parse(src)
+		if expected == "" {
+			if err == nil {
+				t.Errorf("%q: expected error", src)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", src, err)
+			continue
+		}
+		if got := format(n); got != expected {
+			t.Errorf("%q: expected %s, got %s", src, expected, got)
+		}
+	}
+}
+
+func TestParseOrder(t *testing.T) {
+	keys, err := parseOrder("region, amount * 2 DESC, name asc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(keys) != 3 || keys[0].descending || !keys[1].descending || keys[2].descending {
+		t.Errorf("Unexpected keys %+v", keys)
+	}
+	if got := format(keys[1].x); got != "(amount '*' 2)" {
+		t.Errorf("Unexpected key expression %s", got)
+	}
+}
@@ -0,0 +1,200 @@
+// Package fieldpath resolves dot-separated paths such as "Customer.Country" to fields of a struct
+// type. It is the field lookup shared by the field operations of algo, the declarative specs of
+// spec and the expressions of expr, so that a name means the same field everywhere.
+//
+// Each segment of a path matches an exported field by Go name or `algo` tag, ignoring case.
+// Pointers to structs along the path are followed, and a nil pointer reads as the zero value.
+// Resolved paths are cached per type.
+//
+//	f, err := fieldpath.Resolve(reflect.TypeOf(Order{}), "customer.country")
+//	country := f.Value(reflect.ValueOf(order)).String()
+package fieldpath
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownField is wrapped by the errors of paths that do not name a field.
+var ErrUnknownField = errors.New("unknown field")
+
+// Error reports a path that does not resolve.
+type Error struct {
+	// Path is the path that was resolved.
+	Path string
+	// Segment is the index of the first dot-separated segment of Path that does not resolve.
+	Segment int
+	// Type is the type in which the segment was looked up.
+	Type reflect.Type
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	name := strings.Split(e.Path, ".")[e.Segment]
+	if e.Type.Kind() != reflect.Struct {
+		return fmt.Sprintf("%s has no field %q", e.Type, name)
+	}
+	return fmt.Sprintf("unknown field %q in %s", name, e.Type)
+}
+
+// Unwrap returns ErrUnknownField.
+func (e *Error) Unwrap() error {
+	return ErrUnknownField
+}
+
+// Field is a resolved path to a field, possibly nested.
+type Field struct {
+	// Name is the Go path of the field, such as "Customer.Country".
+	Name string
+	// Type is the type of the field.
+	Type reflect.Type
+
+	index [][]int
+	// pointer is set when the path goes through a pointer, including an embedded one.
+	pointer bool
+}
+
+// cacheKey identifies a path of a type in cache.
+type cacheKey struct {
+	t    reflect.Type
+	path string
+}
+
+// cache holds resolved fields keyed by cacheKey.
+var cache sync.Map
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Resolve looks up a path in t, which may be a struct or a pointer to one.
+// It returns an *Error if a segment does not name a field.
+func Resolve(t reflect.Type, path string) (*Field, error) {
+	key := cacheKey{t: t, path: path}
+	if cached, ok := cache.Load(key); ok {
+		return cached.(*Field), nil
+	}
+
+	f := &Field{}
+	segments := strings.Split(path, ".")
+	names := make([]string, 0, len(segments))
+	current := t
+	for i, segment := range segments {
+		if current.Kind() == reflect.Pointer {
+			f.pointer = true
+			current = current.Elem()
+		}
+		if current.Kind() != reflect.Struct {
+			return nil, &Error{Path: path, Segment: i, Type: current}
+		}
+		sf, ok := lookup(current, segment)
+		if !ok {
+			return nil, &Error{Path: path, Segment: i, Type: current}
+		}
+		if throughPointer(current, sf.Index) {
+			f.pointer = true
+		}
+		names = append(names, sf.Name)
+		f.index = append(f.index, sf.Index)
+		current = sf.Type
+	}
+	f.Name = strings.Join(names, ".")
+	f.Type = current
+
+	cached, _ := cache.LoadOrStore(key, f)
+	return cached.(*Field), nil
+}
+
+// lookup finds an exported field by Go name or `algo` tag, ignoring case.
+func lookup(t reflect.Type, name string) (reflect.StructField, bool) {
+	if name == "" {
+		return reflect.StructField{}, false
+	}
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		tag, _, _ := strings.Cut(sf.Tag.Get("algo"), ",")
+		if strings.EqualFold(sf.Name, name) || (tag != "" && tag != "-" && strings.EqualFold(tag, name)) {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// throughPointer reports whether a promoted field is reached through an embedded pointer.
+func throughPointer(t reflect.Type, index []int) bool {
+	for i := 1; i < len(index); i++ {
+		if t.FieldByIndex(index[:i]).Type.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns the field of v, a value of the type the field was resolved in.
+// A nil pointer along the path yields the zero value of the field.
+func (f *Field) Value(v reflect.Value) reflect.Value {
+	for _, index := range f.index {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Zero(f.Type)
+			}
+			v = v.Elem()
+		}
+		next, err := v.FieldByIndexErr(index)
+		if err != nil {
+			return reflect.Zero(f.Type)
+		}
+		v = next
+	}
+	return v
+}
+
+// ThroughPointer reports whether the path goes through a pointer, in which case setting the
+// field on a copy of the element would modify the original.
+func (f *Field) ThroughPointer() bool {
+	return f.pointer
+}
+
+// Ordered reports whether values of the field can be ordered with Compare: booleans, numbers,
+// strings and times.
+func (f *Field) Ordered() bool {
+	if f.Type == timeType {
+		return true
+	}
+	switch f.Type.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// Compare orders two values of the field, with false before true. The field must be Ordered.
+func (f *Field) Compare(a, b reflect.Value) int {
+	if f.Type == timeType {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+	}
+	switch f.Type.Kind() {
+	case reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case b.Bool():
+			return -1
+		}
+		return 1
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	}
+	return strings.Compare(a.String(), b.String())
+}
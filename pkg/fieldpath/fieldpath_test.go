@@ -0,0 +1,138 @@
+package fieldpath
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type Address struct {
+	City string `algo:"town"`
+}
+
+type Audit struct {
+	Updated time.Time
+}
+
+type Customer struct {
+	Name    string
+	Address *Address
+	*Audit
+}
+
+type Order struct {
+	ID       int
+	Customer Customer
+	Tags     []string
+	secret   string
+}
+
+func TestResolve_NestedAndTags(t *testing.T) {
+	for _, path := range []string{"Customer.Address.City", "customer.address.town", "CUSTOMER.ADDRESS.CITY"} {
+		f, err := Resolve(reflect.TypeOf(Order{}), path)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", path, err)
+		}
+		if f.Name != "Customer.Address.City" || f.Type.Kind() != reflect.String || !f.ThroughPointer() {
+			t.Errorf("%q: expected Customer.Address.City of type string through a pointer, got %+v", path, f)
+		}
+	}
+
+	f, _ := Resolve(reflect.TypeOf(Order{}), "Customer.Address.City")
+	if again, _ := Resolve(reflect.TypeOf(Order{}), "Customer.Address.City"); again != f {
+		t.Error("Expected the field to be cached")
+	}
+	order := Order{Customer: Customer{Address: &Address{City: "Lyon"}}}
+	if got := f.Value(reflect.ValueOf(order)).String(); got != "Lyon" {
+		t.Errorf("Expected Lyon, got %q", got)
+	}
+	if got := f.Value(reflect.ValueOf(&order)).String(); got != "Lyon" {
+		t.Errorf("Expected Lyon through a pointer to the element, got %q", got)
+	}
+	if got := f.Value(reflect.ValueOf(Order{})).String(); got != "" {
+		t.Errorf("Expected the zero value through a nil pointer, got %q", got)
+	}
+
+	name, _ := Resolve(reflect.TypeOf(Order{}), "customer.name")
+	if name.ThroughPointer() {
+		t.Error("Expected Customer.Name not to go through a pointer")
+	}
+}
+
+func TestResolve_EmbeddedPointer(t *testing.T) {
+	f, err := Resolve(reflect.TypeOf(Order{}), "Customer.Updated")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !f.ThroughPointer() || f.Type != timeType {
+		t.Errorf("Expected a time reached through the embedded pointer, got %+v", f)
+	}
+	if got := f.Value(reflect.ValueOf(Order{})); !got.Interface().(time.Time).IsZero() {
+		t.Errorf("Expected the zero time through a nil embedded pointer, got %v", got)
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	cases := []struct {
+		path     string
+		segment  int
+		expected string
+	}{
+		{"Missing", 0, `unknown field "Missing" in fieldpath.Order`},
+		{"Customer.Missing", 1, `unknown field "Missing" in fieldpath.Customer`},
+		{"ID.Value", 1, `int has no field "Value"`},
+		{"secret", 0, `unknown field "secret" in fieldpath.Order`},
+		{"", 0, `unknown field "" in fieldpath.Order`},
+		{"Customer.", 1, `unknown field "" in fieldpath.Customer`},
+	}
+	for _, c := range cases {
+		_, err := Resolve(reflect.TypeOf(Order{}), c.path)
+		var fieldErr *Error
+		if !errors.As(err, &fieldErr) || !errors.Is(err, ErrUnknownField) {
+			t.Fatalf("%q: expected an *Error wrapping ErrUnknownField, got %v", c.path, err)
+		}
+		if fieldErr.Segment != c.segment || err.Error() != c.expected {
+			t.Errorf("%q: expected segment %d %q, got %d %q", c.path, c.segment, c.expected, fieldErr.Segment, err)
+		}
+	}
+}
+
+func TestField_Compare(t *testing.T) {
+	type Values struct {
+		Int   int
+		Uint  uint8
+		Float float64
+		Text  string
+		Flag  bool
+		Time  time.Time
+		Tags  []string
+	}
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	cases := []struct {
+		field    string
+		a, b     any
+		expected int
+	}{
+		{"Int", 1, 2, -1},
+		{"Uint", uint8(3), uint8(3), 0},
+		{"Float", 2.5, 1.5, 1},
+		{"Text", "a", "b", -1},
+		{"Flag", false, true, -1},
+		{"Flag", true, true, 0},
+		{"Time", day(2), day(1).In(time.FixedZone("X", 3600)), 1},
+	}
+	for _, c := range cases {
+		f, _ := Resolve(reflect.TypeOf(Values{}), c.field)
+		if !f.Ordered() {
+			t.Errorf("%s: expected an ordered field", c.field)
+		}
+		if got := f.Compare(reflect.ValueOf(c.a), reflect.ValueOf(c.b)); got != c.expected {
+			t.Errorf("%s: compare(%v, %v): expected %d, got %d", c.field, c.a, c.b, c.expected, got)
+		}
+	}
+
+	if f, _ := Resolve(reflect.TypeOf(Values{}), "Tags"); f.Ordered() {
+		t.Error("Expected a slice field not to be ordered")
+	}
+}
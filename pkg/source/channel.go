@@ -0,0 +1,83 @@
+package source
+
+import (
+	"context"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// FromChannel returns a source that yields the elements received from ch until it is closed.
+// Elements are processed as they arrive, one at a time, so a producer writing to ch is held back
+// by the pipeline once the channel's buffer is full.
+// The stream fails with ctx.Err() if ctx is done before ch is closed.
+//
+// Example:
+//
+//	err := algo.NewPipeline[Order]().
+//	    Filter(isValid).
+//	    Stream(FromChannel(ctx, incoming), ToChannel(ctx, accepted))
+func FromChannel[T any](ctx context.Context, ch <-chan T) algo.Source[T] {
+	return func(yield func(T) bool) error {
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case item, ok := <-ch:
+				if !ok {
+					return nil
+				}
+				if !yield(item) {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// ToChannel returns a sink that sends every element to ch.
+// Sending blocks while ch is full, which in turn stops the pipeline from reading its source.
+// The sink fails with ctx.Err() if ctx is done while it is blocked. It never closes ch.
+//
+// Example:
+//
+//	err := pipeline.Stream(FromChannel(ctx, in), ToChannel(ctx, out))
+//	close(out)
+func ToChannel[T any](ctx context.Context, ch chan<- T) algo.Sink[T] {
+	return func(item T) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ch <- item:
+			return nil
+		}
+	}
+}
+
+// Pipe runs the pipeline in a new goroutine over the elements received from in and returns a channel
+// of its results buffered to hold bufferSize elements. The output channel is closed once in is closed
+// and all results have been sent, or when the pipeline fails or ctx is done.
+// The error channel then receives the pipeline's error, or nil, and is closed.
+// The pipeline may stop reading in early, for example after a Take, so producers should also watch ctx.
+//
+// Example:
+//
+//	out, errc := Pipe(ctx, algo.NewPipeline[Order]().Filter(isValid), in, 64)
+//	for order := range out {
+//	    ship(order)
+//	}
+//	if err := <-errc; err != nil {
+//	    return err
+//	}
+func Pipe[T comparable](
+	ctx context.Context, p *algo.Pipeline[T], in <-chan T, bufferSize int,
+) (<-chan T, <-chan error) {
+	out := make(chan T, max(bufferSize, 0))
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		err := p.Stream(FromChannel(ctx, in), ToChannel(ctx, out))
+		close(out)
+		errc <- err
+	}()
+	return out, errc
+}
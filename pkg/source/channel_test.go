@@ -0,0 +1,150 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+func TestFromChannel_ToChannel(t *testing.T) {
+	in := make(chan int)
+	out := make(chan int, 100)
+	go func() {
+		defer close(in)
+		for i := 0; i < 10; i++ {
+			in <- i
+		}
+	}()
+
+	ctx := context.Background()
+	err := algo.NewPipeline[int]().
+		Filter(func(x int) bool { return x%2 == 0 }).
+		Map(func(x int) int { return x * x }).
+		Stream(FromChannel(ctx, in), ToChannel(ctx, out))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	close(out)
+
+	var result []int
+	for x := range out {
+		result = append(result, x)
+	}
+	if !reflect.DeepEqual(result, []int{0, 4, 16, 36, 64}) {
+		t.Errorf("Expected [0 4 16 36 64], got %v", result)
+	}
+}
+
+func TestFromChannel_ProcessesAsElementsArrive(t *testing.T) {
+	in := make(chan int)
+	out := make(chan int)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- algo.NewPipeline[int]().
+			Map(func(x int) int { return x + 1 }).
+			Stream(FromChannel(ctx, in), ToChannel(ctx, out))
+	}()
+
+	// Each result is available before the next element is sent, so nothing is collected up front.
+	for i := 0; i < 3; i++ {
+		in <- i
+		if got := <-out; got != i+1 {
+			t.Errorf("Expected %d, got %d", i+1, got)
+		}
+	}
+	close(in)
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestFromChannel_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	go func() {
+		in <- 1
+		cancel()
+	}()
+
+	result, err := algo.NewPipeline[int]().ExecuteSource(FromChannel(ctx, in))
+	if !errors.Is(err, context.Canceled) || result != nil {
+		t.Errorf("Expected context.Canceled, got %v (%v)", err, result)
+	}
+}
+
+func TestToChannel_Backpressure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	produced := 0
+	source := func(yield func(int) bool) error {
+		for i := 0; i < 1000; i++ {
+			produced++
+			if !yield(i) {
+				return nil
+			}
+		}
+		return nil
+	}
+
+	// Nobody reads out, so the pipeline stalls once its buffer of 2 is full.
+	out := make(chan int, 2)
+	err := algo.NewPipeline[int]().Stream(source, ToChannel(ctx, out))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if produced != 3 {
+		t.Errorf("Expected the source to stall after 3 elements, produced %d", produced)
+	}
+}
+
+func TestPipe(t *testing.T) {
+	ctx := context.Background()
+	in := make(chan int, 4)
+	go func() {
+		defer close(in)
+		for i := 1; i <= 20; i++ {
+			in <- i
+		}
+	}()
+
+	out, errc := Pipe(ctx, algo.NewPipeline[int]().Filter(func(x int) bool { return x%5 == 0 }), in, 2)
+	if cap(out) != 2 {
+		t.Errorf("Expected an output buffer of 2, got %d", cap(out))
+	}
+
+	var result []int
+	for x := range out {
+		result = append(result, x)
+	}
+	if !reflect.DeepEqual(result, []int{5, 10, 15, 20}) {
+		t.Errorf("Expected [5 10 15 20], got %v", result)
+	}
+	if err := <-errc; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestPipe_Error(t *testing.T) {
+	in := make(chan int, 2)
+	in <- 1
+	close(in)
+
+	failing := algo.NewPipeline[int]().
+		Filter(func(int) bool { return false }).
+		Reduce(func(a, b int) int { return a + b })
+	out, errc := Pipe(context.Background(), failing, in, 0)
+	for range out {
+		t.Error("Expected no output")
+	}
+	if err := <-errc; err == nil {
+		t.Error("Expected error from the pipeline")
+	}
+}
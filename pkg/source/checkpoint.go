@@ -0,0 +1,715 @@
+package source
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// checkpointMagic starts every checkpoint file; the byte after it is the format version.
+const (
+	checkpointMagic   = "GACP"
+	checkpointVersion = 1
+)
+
+// cellKind is the encoding of a checkpoint column.
+type cellKind byte
+
+const (
+	kindBool cellKind = iota + 1
+	kindInt
+	kindUint
+	kindFloat
+	kindString
+	kindTime
+)
+
+// checkpointColumn is a struct field stored as a checkpoint column.
+type checkpointColumn struct {
+	column
+	kind cellKind
+}
+
+// checkpointColumns returns the columns of T with their encodings.
+// Returns an error if a field has a type that cannot be stored.
+func checkpointColumns(t reflect.Type) ([]checkpointColumn, error) {
+	columns, err := structColumns(t)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]checkpointColumn, len(columns))
+	for i, col := range columns {
+		field := t.FieldByIndex(col.index).Type
+		var kind cellKind
+		switch {
+		case field == timeType:
+			kind = kindTime
+		case field.Kind() == reflect.Bool:
+			kind = kindBool
+		case field.Kind() == reflect.String:
+			kind = kindString
+		case field.Kind() >= reflect.Int && field.Kind() <= reflect.Int64:
+			kind = kindInt
+		case field.Kind() >= reflect.Uint && field.Kind() <= reflect.Uint64:
+			kind = kindUint
+		case field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64:
+			kind = kindFloat
+		default:
+			return nil, fmt.Errorf("column %q: unsupported field type %v", col.name, field)
+		}
+		result[i] = checkpointColumn{column: col, kind: kind}
+	}
+	return result, nil
+}
+
+// A cell is a single column value in its stored form: bool, int64, uint64, float64, string or time.Time.
+type cell any
+
+// cellOf returns the stored form of a field value.
+func cellOf(v reflect.Value, kind cellKind) cell {
+	switch kind {
+	case kindBool:
+		return v.Bool()
+	case kindInt:
+		return v.Int()
+	case kindUint:
+		return v.Uint()
+	case kindFloat:
+		return v.Float()
+	case kindString:
+		return v.String()
+	default:
+		return v.Interface().(time.Time)
+	}
+}
+
+// setCell stores a cell in a field, failing if the value does not fit the field's type.
+func setCell(v reflect.Value, c cell) error {
+	switch c := c.(type) {
+	case bool:
+		v.SetBool(c)
+	case int64:
+		if v.OverflowInt(c) {
+			return fmt.Errorf("value %d overflows %v", c, v.Type())
+		}
+		v.SetInt(c)
+	case uint64:
+		if v.OverflowUint(c) {
+			return fmt.Errorf("value %d overflows %v", c, v.Type())
+		}
+		v.SetUint(c)
+	case float64:
+		v.SetFloat(c)
+	case string:
+		v.SetString(c)
+	case time.Time:
+		v.Set(reflect.ValueOf(c))
+	}
+	return nil
+}
+
+// compareCells orders two cells of the same kind; false sorts before true and NaN before
+// every other float, so block statistics and row comparisons agree.
+func compareCells(a, b cell) int {
+	switch a := a.(type) {
+	case bool:
+		return cmp.Compare(boolToInt(a), boolToInt(b.(bool)))
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case uint64:
+		return cmp.Compare(a, b.(uint64))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	case string:
+		return strings.Compare(a, b.(string))
+	default:
+		return a.(time.Time).Compare(b.(time.Time))
+	}
+}
+
+// boolToInt returns 1 for true and 0 for false.
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// appendCell encodes a cell: varints for integers and booleans, fixed 8 bytes for floats,
+// and length-prefixed bytes for strings and times.
+func appendCell(data []byte, c cell) []byte {
+	switch c := c.(type) {
+	case bool:
+		return append(data, byte(boolToInt(c)))
+	case int64:
+		return binary.AppendVarint(data, c)
+	case uint64:
+		return binary.AppendUvarint(data, c)
+	case float64:
+		return binary.LittleEndian.AppendUint64(data, math.Float64bits(c))
+	case string:
+		data = binary.AppendUvarint(data, uint64(len(c)))
+		return append(data, c...)
+	default:
+		encoded, _ := c.(time.Time).MarshalBinary()
+		data = binary.AppendUvarint(data, uint64(len(encoded)))
+		return append(data, encoded...)
+	}
+}
+
+// cellDecoder reads cells from an encoded block, remembering the first error.
+type cellDecoder struct {
+	data []byte
+	err  error
+}
+
+// next decodes the next cell of the given kind.
+func (d *cellDecoder) next(kind cellKind) cell {
+	if d.err != nil {
+		return nil
+	}
+	switch kind {
+	case kindBool:
+		if len(d.data) < 1 {
+			break
+		}
+		b := d.data[0] != 0
+		d.data = d.data[1:]
+		return b
+	case kindInt:
+		v, n := binary.Varint(d.data)
+		if n <= 0 {
+			break
+		}
+		d.data = d.data[n:]
+		return v
+	case kindUint:
+		v, n := binary.Uvarint(d.data)
+		if n <= 0 {
+			break
+		}
+		d.data = d.data[n:]
+		return v
+	case kindFloat:
+		if len(d.data) < 8 {
+			break
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.data))
+		d.data = d.data[8:]
+		return v
+	case kindString, kindTime:
+		length, n := binary.Uvarint(d.data)
+		if n <= 0 || length > uint64(len(d.data)-n) {
+			break
+		}
+		raw := d.data[n : n+int(length)]
+		d.data = d.data[n+int(length):]
+		if kind == kindString {
+			return string(raw)
+		}
+		var t time.Time
+		if err := t.UnmarshalBinary(raw); err != nil {
+			d.err = err
+			return nil
+		}
+		return t
+	}
+	d.err = errors.New("truncated block")
+	return nil
+}
+
+// CheckpointWriter writes records of type T in a compact binary columnar format.
+// Records are grouped into blocks; each block stores every column contiguously together with the
+// column's minimum and maximum, which lets CheckpointReader skip blocks that cannot match a query.
+// Supported field types are bools, integers, floats, strings and time.Time.
+type CheckpointWriter[T any] struct {
+	writer    *bufio.Writer
+	blockSize int
+	columns   []checkpointColumn
+	pending   []T
+	err       error
+	started   bool
+}
+
+// NewCheckpointWriter creates a writer that writes to w in blocks of blockSize records.
+// A blockSize below 1 defaults to 4096. Close must be called after the last record.
+//
+// Example:
+//
+//	writer := NewCheckpointWriter[Order](file, 10000)
+//	err := pipeline.Stream(source, writer.Sink())
+//	err = writer.Close()
+func NewCheckpointWriter[T any](w io.Writer, blockSize int) *CheckpointWriter[T] {
+	if blockSize < 1 {
+		blockSize = 4096
+	}
+	return &CheckpointWriter[T]{writer: bufio.NewWriter(w), blockSize: blockSize}
+}
+
+// Write queues a record and writes the block once it is full.
+func (c *CheckpointWriter[T]) Write(record T) error {
+	if !c.started {
+		c.start()
+	}
+	if c.err == nil {
+		c.pending = append(c.pending, record)
+		if len(c.pending) >= c.blockSize {
+			c.writeBlock()
+		}
+	}
+	if c.err != nil {
+		return fmt.Errorf("CheckpointWriter: %w", c.err)
+	}
+	return nil
+}
+
+// Sink returns a sink that writes every element with Write.
+func (c *CheckpointWriter[T]) Sink() algo.Sink[T] {
+	return c.Write
+}
+
+// Close writes the last block and the end marker and flushes the output.
+// It does not close the underlying writer.
+func (c *CheckpointWriter[T]) Close() error {
+	if !c.started {
+		c.start()
+	}
+	if c.err == nil && len(c.pending) > 0 {
+		c.writeBlock()
+	}
+	if c.err == nil {
+		c.err = c.writer.WriteByte(0)
+	}
+	if c.err == nil {
+		c.err = c.writer.Flush()
+	}
+	if c.err != nil {
+		return fmt.Errorf("CheckpointWriter: %w", c.err)
+	}
+	return nil
+}
+
+// start writes the file header describing the columns of T.
+func (c *CheckpointWriter[T]) start() {
+	c.started = true
+	c.columns, c.err = checkpointColumns(reflect.TypeOf((*T)(nil)).Elem())
+	if c.err != nil {
+		return
+	}
+
+	header := append([]byte(checkpointMagic), checkpointVersion)
+	header = binary.AppendUvarint(header, uint64(len(c.columns)))
+	for _, col := range c.columns {
+		header = appendCell(header, col.name)
+		header = append(header, byte(col.kind))
+	}
+	_, c.err = c.writer.Write(header)
+}
+
+// writeBlock writes the pending records as one block: the row count, the length-prefixed minimum
+// and maximum of every column, and the length-prefixed column data.
+func (c *CheckpointWriter[T]) writeBlock() {
+	var stats, data []byte
+	for _, col := range c.columns {
+		var low, high cell
+		for _, record := range c.pending {
+			value := cellOf(reflect.ValueOf(record).FieldByIndex(col.index), col.kind)
+			data = appendCell(data, value)
+			if low == nil || compareCells(value, low) < 0 {
+				low = value
+			}
+			if high == nil || compareCells(value, high) > 0 {
+				high = value
+			}
+		}
+		stats = appendCell(stats, low)
+		stats = appendCell(stats, high)
+	}
+	header := binary.AppendUvarint(nil, uint64(len(c.pending)))
+	header = binary.AppendUvarint(header, uint64(len(stats)))
+	header = append(header, stats...)
+	header = binary.AppendUvarint(header, uint64(len(data)))
+
+	c.pending = c.pending[:0]
+	if _, c.err = c.writer.Write(header); c.err == nil {
+		_, c.err = c.writer.Write(data)
+	}
+}
+
+// SaveCheckpoint executes the pipeline and writes its result to w in the checkpoint format,
+// so that it can later be read back with FromCheckpoint instead of recomputing it.
+//
+// Example:
+//
+//	err := SaveCheckpoint(expensivePipeline, file)
+func SaveCheckpoint[T comparable](p *algo.Pipeline[T], w io.Writer) error {
+	records, err := p.Execute()
+	if err != nil {
+		return err
+	}
+
+	writer := NewCheckpointWriter[T](w, 0)
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// checkpointCondition is a comparison pushed down into a CheckpointReader.
+type checkpointCondition struct {
+	column   string
+	op       string
+	value    any
+	position int
+	cell     cell
+}
+
+// matches reports whether a value satisfies the condition.
+func (c *checkpointCondition) matches(value cell) bool {
+	order := compareCells(value, c.cell)
+	switch c.op {
+	case "=":
+		return order == 0
+	case "!=":
+		return order != 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	default:
+		return order >= 0
+	}
+}
+
+// mayMatch reports whether any value between low and high can satisfy the condition.
+func (c *checkpointCondition) mayMatch(low, high cell) bool {
+	switch c.op {
+	case "=":
+		return compareCells(low, c.cell) <= 0 && compareCells(high, c.cell) >= 0
+	case "!=":
+		return compareCells(low, c.cell) != 0 || compareCells(high, c.cell) != 0
+	case "<", "<=":
+		return c.matches(low)
+	default:
+		return c.matches(high)
+	}
+}
+
+// CheckpointReader reads records written by CheckpointWriter.
+// Conditions added with Where are checked against every block's statistics first, and blocks that
+// cannot contain a match are skipped without being decoded.
+type CheckpointReader[T any] struct {
+	reader     *bufio.Reader
+	conditions []*checkpointCondition
+	columns    []checkpointColumn
+	fileKinds  []cellKind
+	targets    [][]int
+	queue      []T
+	started    bool
+	done       bool
+	skipped    int
+	read       int
+}
+
+// NewCheckpointReader creates a reader that reads a checkpoint from r.
+//
+// Example:
+//
+//	reader := NewCheckpointReader[Order](file).
+//	    Where("status", "=", "completed").
+//	    Where("amount", ">", 100)
+//	result, err := pipeline.ExecuteSource(reader.Source())
+func NewCheckpointReader[T any](r io.Reader) *CheckpointReader[T] {
+	return &CheckpointReader[T]{reader: bufio.NewReader(r)}
+}
+
+// Where adds a condition that every returned record satisfies.
+// The operator is one of =, !=, <, <=, > and >=; the value must be comparable with the column,
+// so numbers for numeric columns, strings for string columns, bools and time.Time values.
+// Invalid conditions are reported by the first Read.
+func (c *CheckpointReader[T]) Where(column, op string, value any) *CheckpointReader[T] {
+	c.conditions = append(c.conditions, &checkpointCondition{column: column, op: op, value: value})
+	return c
+}
+
+// BlocksSkipped returns the number of blocks skipped so far because of their statistics.
+func (c *CheckpointReader[T]) BlocksSkipped() int {
+	return c.skipped
+}
+
+// BlocksRead returns the number of blocks decoded so far.
+func (c *CheckpointReader[T]) BlocksRead() int {
+	return c.read
+}
+
+// Read returns the next record that satisfies every condition, or io.EOF after the last one.
+func (c *CheckpointReader[T]) Read() (T, error) {
+	var record T
+	if !c.started {
+		c.started = true
+		if err := c.readHeader(); err != nil {
+			c.done = true
+			return record, fmt.Errorf("CheckpointReader: %w", err)
+		}
+	}
+
+	for len(c.queue) == 0 {
+		if c.done {
+			return record, io.EOF
+		}
+		if err := c.readBlock(); err != nil {
+			c.done = true
+			return record, fmt.Errorf("CheckpointReader: %w", err)
+		}
+	}
+	record = c.queue[0]
+	c.queue = c.queue[1:]
+	return record, nil
+}
+
+// ReadAll reads all remaining records that satisfy every condition.
+func (c *CheckpointReader[T]) ReadAll() ([]T, error) {
+	var records []T
+	for {
+		record, err := c.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+// Source returns a source that reads records one at a time, for use with Pipeline.Stream.
+func (c *CheckpointReader[T]) Source() algo.Source[T] {
+	return readerSource[T](c.Read)
+}
+
+// readHeader reads the file's columns, maps them to the fields of T and resolves the conditions.
+func (c *CheckpointReader[T]) readHeader() error {
+	columns, err := checkpointColumns(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+
+	magic := make([]byte, len(checkpointMagic)+1)
+	if _, err := io.ReadFull(c.reader, magic); err != nil || string(magic[:4]) != checkpointMagic {
+		return errors.New("not a checkpoint file")
+	}
+	if magic[4] != checkpointVersion {
+		return fmt.Errorf("unsupported checkpoint version %d", magic[4])
+	}
+
+	count, err := binary.ReadUvarint(c.reader)
+	if err != nil || count > 1<<16 {
+		return errors.New("corrupt header")
+	}
+	positions := make(map[string]int, count)
+	c.fileKinds = make([]cellKind, count)
+	for i := range c.fileKinds {
+		length, err := binary.ReadUvarint(c.reader)
+		if err != nil || length > 1<<16 {
+			return errors.New("corrupt header")
+		}
+		name := make([]byte, length+1)
+		if _, err := io.ReadFull(c.reader, name); err != nil {
+			return errors.New("corrupt header")
+		}
+		positions[string(name[:length])] = i
+		c.fileKinds[i] = cellKind(name[length])
+	}
+
+	c.columns = columns
+	c.targets = make([][]int, count)
+	var missing []string
+	for _, col := range columns {
+		position, ok := positions[col.name]
+		switch {
+		case !ok && !col.optional:
+			missing = append(missing, col.name)
+		case ok && c.fileKinds[position] != col.kind:
+			return fmt.Errorf("column %q has a different type in the file", col.name)
+		case ok:
+			c.targets[position] = col.index
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("file is missing columns %s", strings.Join(missing, ", "))
+	}
+
+	for _, cond := range c.conditions {
+		position, ok := positions[cond.column]
+		if !ok {
+			return fmt.Errorf("unknown column %q in condition", cond.column)
+		}
+		switch cond.op {
+		case "=", "!=", "<", "<=", ">", ">=":
+		default:
+			return fmt.Errorf("unknown operator %q in condition on %q", cond.op, cond.column)
+		}
+		value, err := conditionCell(cond.value, c.fileKinds[position])
+		if err != nil {
+			return fmt.Errorf("condition on %q: %w", cond.column, err)
+		}
+		cond.position, cond.cell = position, value
+	}
+	return nil
+}
+
+// conditionCell converts a condition value to a cell of the column's kind.
+func conditionCell(value any, kind cellKind) (cell, error) {
+	v := reflect.ValueOf(value)
+	switch {
+	case kind == kindTime && v.Type() == timeType:
+		return value.(time.Time), nil
+	case kind == kindBool && v.Kind() == reflect.Bool:
+		return v.Bool(), nil
+	case kind == kindString && v.Kind() == reflect.String:
+		return v.String(), nil
+	case kind == kindInt && v.CanInt():
+		return v.Int(), nil
+	case kind == kindInt && v.CanUint() && v.Uint() <= math.MaxInt64:
+		return int64(v.Uint()), nil
+	case kind == kindUint && v.CanUint():
+		return v.Uint(), nil
+	case kind == kindUint && v.CanInt() && v.Int() >= 0:
+		return uint64(v.Int()), nil
+	case kind == kindFloat && v.CanFloat():
+		return v.Float(), nil
+	case kind == kindFloat && v.CanInt():
+		return float64(v.Int()), nil
+	case kind == kindFloat && v.CanUint():
+		return float64(v.Uint()), nil
+	}
+	return nil, fmt.Errorf("cannot compare with %T", value)
+}
+
+// readBlock reads the next block, skipping it if its statistics rule out every condition,
+// and queues the records that satisfy the conditions.
+func (c *CheckpointReader[T]) readBlock() error {
+	rows, err := binary.ReadUvarint(c.reader)
+	if err != nil {
+		return errors.New("truncated file")
+	}
+	if rows == 0 {
+		c.done = true
+		return nil
+	}
+
+	stats, err := c.readSection()
+	if err != nil {
+		return err
+	}
+	lows := make([]cell, len(c.fileKinds))
+	highs := make([]cell, len(c.fileKinds))
+	decoder := &cellDecoder{data: stats}
+	for i, kind := range c.fileKinds {
+		lows[i], highs[i] = decoder.next(kind), decoder.next(kind)
+	}
+	if decoder.err != nil {
+		return fmt.Errorf("corrupt block statistics: %w", decoder.err)
+	}
+	length, err := binary.ReadUvarint(c.reader)
+	if err != nil || length > math.MaxInt32 {
+		return errors.New("truncated file")
+	}
+
+	for _, cond := range c.conditions {
+		if !cond.mayMatch(lows[cond.position], highs[cond.position]) {
+			c.skipped++
+			if _, err := c.reader.Discard(int(length)); err != nil {
+				return errors.New("truncated file")
+			}
+			return nil
+		}
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, data); err != nil {
+		return errors.New("truncated file")
+	}
+	c.read++
+	return c.decodeBlock(data, int(rows))
+}
+
+// readSection reads a length-prefixed section of a block.
+func (c *CheckpointReader[T]) readSection() ([]byte, error) {
+	length, err := binary.ReadUvarint(c.reader)
+	if err != nil || length > math.MaxInt32 {
+		return nil, errors.New("truncated file")
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, data); err != nil {
+		return nil, errors.New("truncated file")
+	}
+	return data, nil
+}
+
+// decodeBlock decodes the column data of a block into records and queues those that satisfy
+// every condition.
+func (c *CheckpointReader[T]) decodeBlock(data []byte, rows int) error {
+	if len(c.fileKinds) > 0 && rows > len(data) {
+		return errors.New("corrupt block")
+	}
+	decoder := &cellDecoder{data: data}
+	values := make([][]cell, len(c.fileKinds))
+	for i, kind := range c.fileKinds {
+		values[i] = make([]cell, rows)
+		for row := range values[i] {
+			values[i][row] = decoder.next(kind)
+		}
+	}
+	if decoder.err != nil {
+		return decoder.err
+	}
+
+rows:
+	for row := 0; row < rows; row++ {
+		for _, cond := range c.conditions {
+			if !cond.matches(values[cond.position][row]) {
+				continue rows
+			}
+		}
+
+		var record T
+		v := reflect.ValueOf(&record).Elem()
+		for i, index := range c.targets {
+			if index == nil {
+				continue
+			}
+			if err := setCell(v.FieldByIndex(index), values[i][row]); err != nil {
+				return err
+			}
+		}
+		c.queue = append(c.queue, record)
+	}
+	return nil
+}
+
+// FromCheckpoint returns a source that reads every record of a checkpoint from r.
+// Use NewCheckpointReader with Where to skip blocks that cannot match.
+//
+// Example:
+//
+//	result, err := algo.NewPipeline[Order]().
+//	    Filter(isLarge).
+//	    ExecuteSource(FromCheckpoint[Order](file))
+func FromCheckpoint[T any](r io.Reader) algo.Source[T] {
+	return NewCheckpointReader[T](r).Source()
+}
@@ -0,0 +1,266 @@
+package source
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+type Reading struct {
+	Sensor  string    `algo:"sensor"`
+	Seq     int64     `algo:"seq"`
+	Value   float64   `algo:"value"`
+	Count   uint8     `algo:"count"`
+	Valid   bool      `algo:"valid"`
+	TakenAt time.Time `algo:"taken_at"`
+}
+
+func generateReadings(n int) []Reading {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	readings := make([]Reading, n)
+	for i := range readings {
+		readings[i] = Reading{
+			Sensor:  []string{"alpha", "beta", "gamma"}[i%3],
+			Seq:     int64(i),
+			Value:   float64(i%100) / 4,
+			Count:   uint8(i % 200),
+			Valid:   i%7 != 0,
+			TakenAt: start.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	return readings
+}
+
+func writeCheckpoint(t *testing.T, readings []Reading, blockSize int) []byte {
+	var buf bytes.Buffer
+	writer := NewCheckpointWriter[Reading](&buf, blockSize)
+	for _, r := range readings {
+		if err := writer.Write(r); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestCheckpoint_RoundTrip(t *testing.T) {
+	readings := generateReadings(2500)
+	data := writeCheckpoint(t, readings, 1000)
+
+	decoded, err := NewCheckpointReader[Reading](bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, readings) {
+		t.Error("Decoded readings differ from the originals")
+	}
+
+	empty := writeCheckpoint(t, nil, 10)
+	decoded, err = NewCheckpointReader[Reading](bytes.NewReader(empty)).ReadAll()
+	if err != nil || len(decoded) != 0 {
+		t.Errorf("Expected no readings, got %v (%v)", decoded, err)
+	}
+}
+
+func TestCheckpoint_IsCompact(t *testing.T) {
+	readings := generateReadings(10000)
+	data := writeCheckpoint(t, readings, 0)
+
+	var csvBuf bytes.Buffer
+	if err := NewCSVWriter[Reading](&csvBuf).WriteAll(readings); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(data) >= csvBuf.Len() {
+		t.Errorf("Expected the checkpoint (%d bytes) to be smaller than CSV (%d bytes)", len(data), csvBuf.Len())
+	}
+}
+
+func TestCheckpointReader_PredicatePushdown(t *testing.T) {
+	readings := generateReadings(10000)
+	data := writeCheckpoint(t, readings, 500)
+
+	reader := NewCheckpointReader[Reading](bytes.NewReader(data)).
+		Where("seq", ">=", 2000).
+		Where("seq", "<", 3000).
+		Where("sensor", "=", "beta")
+	result, err := algo.NewPipeline[Reading]().
+		Filter(func(r Reading) bool { return r.Valid }).
+		ExecuteSource(reader.Source())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var expected []Reading
+	for _, r := range readings {
+		if r.Seq >= 2000 && r.Seq < 3000 && r.Sensor == "beta" && r.Valid {
+			expected = append(expected, r)
+		}
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %d readings, got %d", len(expected), len(result))
+	}
+	if reader.BlocksRead() != 2 || reader.BlocksSkipped() != 18 {
+		t.Errorf("Expected 2 blocks read and 18 skipped, got %d and %d", reader.BlocksRead(), reader.BlocksSkipped())
+	}
+}
+
+func TestCheckpointReader_ConditionKinds(t *testing.T) {
+	readings := generateReadings(300)
+	data := writeCheckpoint(t, readings, 50)
+	cutoff := time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		column string
+		op     string
+		value  any
+		match  func(r Reading) bool
+	}{
+		{"value", "<=", 1, func(r Reading) bool { return r.Value <= 1 }},
+		{"value", ">", 24.5, func(r Reading) bool { return r.Value > 24.5 }},
+		{"count", "!=", uint(3), func(r Reading) bool { return r.Count != 3 }},
+		{"count", ">=", 150, func(r Reading) bool { return r.Count >= 150 }},
+		{"valid", "=", false, func(r Reading) bool { return !r.Valid }},
+		{"taken_at", "<", cutoff, func(r Reading) bool { return r.TakenAt.Before(cutoff) }},
+		{"sensor", ">", "alpha", func(r Reading) bool { return r.Sensor > "alpha" }},
+	}
+	for _, tc := range cases {
+		result, err := NewCheckpointReader[Reading](bytes.NewReader(data)).Where(tc.column, tc.op, tc.value).ReadAll()
+		if err != nil {
+			t.Fatalf("%s %s %v: unexpected error: %v", tc.column, tc.op, tc.value, err)
+		}
+		count := 0
+		for _, r := range readings {
+			if tc.match(r) {
+				count++
+			}
+		}
+		if len(result) != count {
+			t.Errorf("%s %s %v: expected %d readings, got %d", tc.column, tc.op, tc.value, count, len(result))
+		}
+	}
+}
+
+func TestCheckpointReader_NaN(t *testing.T) {
+	readings := []Reading{{Seq: 1, Value: math.NaN()}, {Seq: 2, Value: 5}}
+	data := writeCheckpoint(t, readings, 10)
+
+	result, err := NewCheckpointReader[Reading](bytes.NewReader(data)).Where("value", ">", 1).ReadAll()
+	if err != nil || len(result) != 1 || result[0].Seq != 2 {
+		t.Errorf("Expected reading 2, got %v (%v)", result, err)
+	}
+}
+
+func TestCheckpointReader_InvalidConditions(t *testing.T) {
+	data := writeCheckpoint(t, generateReadings(10), 10)
+	cases := []struct {
+		column, op string
+		value      any
+	}{
+		{"missing", "=", 1},
+		{"seq", "~", 1},
+		{"seq", "=", "one"},
+		{"count", "=", -1},
+		{"valid", "<", 1},
+	}
+	for _, tc := range cases {
+		if _, err := NewCheckpointReader[Reading](bytes.NewReader(data)).Where(tc.column, tc.op, tc.value).ReadAll(); err == nil {
+			t.Errorf("%s %s %v: expected error", tc.column, tc.op, tc.value)
+		}
+	}
+}
+
+func TestCheckpointReader_SchemaValidation(t *testing.T) {
+	data := writeCheckpoint(t, generateReadings(10), 10)
+
+	type subset struct {
+		Sensor string `algo:"sensor"`
+		Seq    int64  `algo:"seq"`
+		Note   string `algo:"note,optional"`
+	}
+	result, err := NewCheckpointReader[subset](bytes.NewReader(data)).ReadAll()
+	if err != nil || len(result) != 10 || result[4] != (subset{Sensor: "beta", Seq: 4}) {
+		t.Errorf("Expected the selected columns to be read, got %v (%v)", result, err)
+	}
+
+	type wrongType struct {
+		Seq string `algo:"seq"`
+	}
+	if _, err := NewCheckpointReader[wrongType](bytes.NewReader(data)).ReadAll(); err == nil {
+		t.Error("Expected error for a column of a different type")
+	}
+
+	type missing struct {
+		Other int `algo:"other"`
+	}
+	if _, err := NewCheckpointReader[missing](bytes.NewReader(data)).ReadAll(); err == nil {
+		t.Error("Expected error for a missing column")
+	}
+
+	type narrow struct {
+		Seq int8 `algo:"seq"`
+	}
+	large := writeCheckpoint(t, generateReadings(300), 100)
+	if _, err := NewCheckpointReader[narrow](bytes.NewReader(large)).ReadAll(); err == nil {
+		t.Error("Expected overflow error")
+	}
+}
+
+func TestCheckpointReader_CorruptInput(t *testing.T) {
+	data := writeCheckpoint(t, generateReadings(100), 30)
+	inputs := map[string][]byte{
+		"empty":     nil,
+		"bad magic": append([]byte("NOPE"), data[4:]...),
+		"version":   append(append([]byte(checkpointMagic), 9), data[5:]...),
+		"truncated": data[:len(data)-10],
+		"no end":    data[:len(data)-1],
+	}
+	for name, input := range inputs {
+		if _, err := NewCheckpointReader[Reading](bytes.NewReader(input)).ReadAll(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestCheckpointWriter_UnsupportedType(t *testing.T) {
+	type withSlice struct {
+		Tags []string
+	}
+	writer := NewCheckpointWriter[withSlice](io.Discard, 10)
+	if err := writer.Write(withSlice{}); err == nil {
+		t.Error("Expected error for unsupported field type")
+	}
+	if err := writer.Close(); err == nil {
+		t.Error("Expected Close to report the error")
+	}
+}
+
+func TestSaveCheckpoint_FromCheckpoint(t *testing.T) {
+	readings := generateReadings(100)
+	expensive := algo.NewPipelineWithData(readings).
+		Filter(func(r Reading) bool { return r.Sensor == "gamma" })
+
+	var buf bytes.Buffer
+	if err := SaveCheckpoint(expensive, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := algo.NewPipeline[Reading]().Take(3).ExecuteSource(FromCheckpoint[Reading](&buf))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 3 || result[0].Seq != 2 || result[2].Seq != 8 {
+		t.Errorf("Expected readings 2, 5 and 8, got %v", result)
+	}
+
+	failing := algo.NewPipeline[Reading]().Reduce(func(a, b Reading) Reading { return a })
+	if err := SaveCheckpoint(failing, &buf); err == nil {
+		t.Error("Expected error from failing pipeline")
+	}
+}
@@ -0,0 +1,310 @@
+package source
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// CSVReader reads CSV records into structs of type T.
+// The first record must be a header naming the columns; columns are matched to fields by name,
+// unknown columns are ignored and missing columns are an error unless the field is optional.
+type CSVReader[T any] struct {
+	// Comma is the field delimiter. It defaults to ','.
+	Comma rune
+	// TimeLayout is the layout used to parse time.Time fields. It defaults to time.RFC3339.
+	TimeLayout string
+	// OnError, if set, receives every row that cannot be converted and reading continues with the next row
+	// unless it returns an error. If OnError is nil, reading stops at the first invalid row.
+	// See RejectTo and RejectToChannel.
+	OnError func(err *RowError) error
+
+	input   io.Reader
+	reader  *csv.Reader
+	columns []column
+	indices []int
+}
+
+// NewCSVReader creates a reader that reads CSV from r.
+//
+// Example:
+//
+//	reader := NewCSVReader[Order](file)
+//	reader.Comma = ';'
+//	reader.OnError = RejectTo(rejects)
+//	orders, err := reader.ReadAll()
+func NewCSVReader[T any](r io.Reader) *CSVReader[T] {
+	return &CSVReader[T]{Comma: ',', TimeLayout: time.RFC3339, input: r}
+}
+
+// Read returns the next record.
+// It reads and validates the header on the first call and returns io.EOF after the last record.
+func (c *CSVReader[T]) Read() (T, error) {
+	var record T
+	if c.reader == nil {
+		if err := c.readHeader(); err != nil {
+			return record, err
+		}
+	}
+
+	for {
+		fields, err := c.reader.Read()
+		if err == io.EOF {
+			return record, io.EOF
+		}
+
+		var rowErr *RowError
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount):
+			rowErr = &RowError{Line: parseErr.StartLine, Err: csv.ErrFieldCount}
+		case err != nil:
+			return record, fmt.Errorf("CSVReader: %w", err)
+		default:
+			record, rowErr = c.decode(fields)
+		}
+
+		if rowErr == nil {
+			return record, nil
+		}
+		if err := c.handle(rowErr); err != nil {
+			return record, err
+		}
+	}
+}
+
+// handle passes an invalid row to OnError, or returns it as an error if OnError is nil.
+func (c *CSVReader[T]) handle(rowErr *RowError) error {
+	if c.OnError == nil {
+		return fmt.Errorf("CSVReader: %w", rowErr)
+	}
+	if err := c.OnError(rowErr); err != nil {
+		return fmt.Errorf("CSVReader: %w", err)
+	}
+	return nil
+}
+
+// Source returns a source that reads records one at a time, for use with Pipeline.Stream.
+//
+// Example:
+//
+//	result, err := algo.NewPipeline[Order]().
+//	    Filter(isLarge).
+//	    Take(10).
+//	    ExecuteSource(NewCSVReader[Order](file).Source())
+func (c *CSVReader[T]) Source() algo.Source[T] {
+	return readerSource[T](c.Read)
+}
+
+// ReadAll reads all remaining records.
+//
+// Example:
+//
+//	orders, err := NewCSVReader[Order](file).ReadAll()
+func (c *CSVReader[T]) ReadAll() ([]T, error) {
+	var records []T
+	for {
+		record, err := c.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+// readHeader reads the header record and maps every column of T to its position in the header.
+func (c *CSVReader[T]) readHeader() error {
+	columns, err := structColumns(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return fmt.Errorf("CSVReader: %w", err)
+	}
+
+	c.reader = csv.NewReader(c.input)
+	c.reader.Comma = c.Comma
+	c.reader.ReuseRecord = true
+	header, err := c.reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return errors.New("CSVReader: missing header")
+		}
+		return fmt.Errorf("CSVReader: %w", err)
+	}
+
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, ok := positions[name]; ok {
+			return fmt.Errorf("CSVReader: duplicate column %q in header", name)
+		}
+		positions[name] = i
+	}
+
+	var missing []string
+	c.columns = columns
+	c.indices = make([]int, len(columns))
+	for i, col := range columns {
+		position, ok := positions[col.name]
+		if !ok {
+			position = -1
+			if !col.optional {
+				missing = append(missing, col.name)
+			}
+		}
+		c.indices[i] = position
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("CSVReader: header is missing columns %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// decode converts the fields of one record into a T.
+func (c *CSVReader[T]) decode(fields []string) (T, *RowError) {
+	var record T
+	v := reflect.ValueOf(&record).Elem()
+	for i, col := range c.columns {
+		position := c.indices[i]
+		if position < 0 {
+			continue
+		}
+		if err := decodeValue(v.FieldByIndex(col.index), fields[position], c.TimeLayout); err != nil {
+			line, _ := c.reader.FieldPos(position)
+			return record, &RowError{Line: line, Column: col.name, Err: err}
+		}
+	}
+	return record, nil
+}
+
+// FromCSV reads CSV with a header from r and returns a pipeline over the records.
+// Returns an error if the header does not match T or any row cannot be converted.
+//
+// Example:
+//
+//	pipeline, err := FromCSV[Order](file)
+//	result, err := pipeline.
+//	    Filter(func(o Order) bool { return o.Amount > 100 }).
+//	    Execute()
+func FromCSV[T comparable](r io.Reader) (*algo.Pipeline[T], error) {
+	records, err := NewCSVReader[T](r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return algo.NewPipelineWithData(records), nil
+}
+
+// CSVWriter writes structs of type T as CSV records, preceded by a header with their column names.
+type CSVWriter[T any] struct {
+	// Comma is the field delimiter. It defaults to ','.
+	Comma rune
+	// TimeLayout is the layout used to format time.Time fields. It defaults to time.RFC3339.
+	TimeLayout string
+
+	output  io.Writer
+	writer  *csv.Writer
+	columns []column
+	fields  []string
+}
+
+// NewCSVWriter creates a writer that writes CSV to w.
+//
+// Example:
+//
+//	writer := NewCSVWriter[Order](os.Stdout)
+//	err := writer.WriteAll(orders)
+func NewCSVWriter[T any](w io.Writer) *CSVWriter[T] {
+	return &CSVWriter[T]{Comma: ',', TimeLayout: time.RFC3339, output: w}
+}
+
+// Write writes a single record, writing the header first if it has not been written yet.
+// Records are buffered; call Flush to write them to the underlying writer.
+func (c *CSVWriter[T]) Write(record T) error {
+	if c.writer == nil {
+		if err := c.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	v := reflect.ValueOf(record)
+	for i, col := range c.columns {
+		field, err := encodeValue(v.FieldByIndex(col.index), c.TimeLayout)
+		if err != nil {
+			return fmt.Errorf("CSVWriter: column %q: %w", col.name, err)
+		}
+		c.fields[i] = field
+	}
+	if err := c.writer.Write(c.fields); err != nil {
+		return fmt.Errorf("CSVWriter: %w", err)
+	}
+	return nil
+}
+
+// WriteAll writes the header and all records and flushes the output.
+// The header is written even if records is empty.
+func (c *CSVWriter[T]) WriteAll(records []T) error {
+	if c.writer == nil {
+		if err := c.writeHeader(); err != nil {
+			return err
+		}
+	}
+	for _, record := range records {
+		if err := c.Write(record); err != nil {
+			return err
+		}
+	}
+	return c.Flush()
+}
+
+// Flush writes any buffered records to the underlying writer.
+func (c *CSVWriter[T]) Flush() error {
+	if c.writer == nil {
+		return nil
+	}
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		return fmt.Errorf("CSVWriter: %w", err)
+	}
+	return nil
+}
+
+// writeHeader writes the column names of T.
+func (c *CSVWriter[T]) writeHeader() error {
+	columns, err := structColumns(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return fmt.Errorf("CSVWriter: %w", err)
+	}
+
+	c.writer = csv.NewWriter(c.output)
+	c.writer.Comma = c.Comma
+	c.columns = columns
+	c.fields = make([]string, len(columns))
+	for i, col := range columns {
+		c.fields[i] = col.name
+	}
+	if err := c.writer.Write(c.fields); err != nil {
+		return fmt.Errorf("CSVWriter: %w", err)
+	}
+	return nil
+}
+
+// ToCSV executes the pipeline and writes its result to w as CSV with a header.
+// Returns an error if any operation fails or the output cannot be written.
+//
+// Example:
+//
+//	err := ToCSV(algo.NewPipelineWithData(orders).Take(10), os.Stdout)
+func ToCSV[T comparable](p *algo.Pipeline[T], w io.Writer) error {
+	records, err := p.Execute()
+	if err != nil {
+		return err
+	}
+	return NewCSVWriter[T](w).WriteAll(records)
+}
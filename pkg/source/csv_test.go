@@ -0,0 +1,220 @@
+package source
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+type Order struct {
+	ID       int       `algo:"id"`
+	Customer string    `algo:"customer"`
+	Amount   float64   `algo:"amount"`
+	Paid     bool      `algo:"paid"`
+	Placed   time.Time `algo:"placed_at"`
+	Note     string    `algo:"note,optional"`
+}
+
+const ordersCSV = `id,customer,amount,paid,placed_at,ignored
+1,alice,120.5,true,2024-01-02T00:00:00Z,x
+2,bob,80,false,2024-01-03T00:00:00Z,y
+3,carol,300,true,2024-01-04T00:00:00Z,z
+`
+
+func TestFromCSV(t *testing.T) {
+	pipeline, err := FromCSV[Order](strings.NewReader(ordersCSV))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := pipeline.
+		Filter(func(o Order) bool { return o.Amount > 100 }).
+		Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []Order{
+		{ID: 1, Customer: "alice", Amount: 120.5, Paid: true, Placed: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, Customer: "carol", Amount: 300, Paid: true, Placed: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCSVReader_HeaderValidation(t *testing.T) {
+	cases := map[string]string{
+		"missing column":   "id,customer,amount,paid\n1,a,1,true\n",
+		"duplicate column": "id,id,customer,amount,paid,placed_at\n",
+		"empty input":      "",
+	}
+	for name, input := range cases {
+		if _, err := NewCSVReader[Order](strings.NewReader(input)).ReadAll(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	_, err := NewCSVReader[Order](strings.NewReader("id,customer\n")).ReadAll()
+	if err == nil || !strings.Contains(err.Error(), "amount, paid, placed_at") {
+		t.Errorf("Expected error naming the missing columns, got %v", err)
+	}
+
+	if _, err := NewCSVReader[int](strings.NewReader("a\n")).ReadAll(); err == nil {
+		t.Error("Expected error for non-struct type")
+	}
+}
+
+func TestCSVReader_HeaderWithBOMAndSpaces(t *testing.T) {
+	input := "\ufeffid, customer ,amount,paid,placed_at\n7,dave,1,false,\n"
+	records, err := NewCSVReader[Order](strings.NewReader(input)).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].ID != 7 || records[0].Customer != "dave" || !records[0].Placed.IsZero() {
+		t.Errorf("Unexpected records: %v", records)
+	}
+}
+
+func TestCSVReader_StopsAtFirstInvalidRow(t *testing.T) {
+	input := "id,customer,amount,paid,placed_at\n1,a,1,true,\n2,b,abc,true,\n3,c,3,true,\n"
+	_, err := NewCSVReader[Order](strings.NewReader(input)).ReadAll()
+
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) {
+		t.Fatalf("Expected RowError, got %v", err)
+	}
+	if rowErr.Line != 3 || rowErr.Column != "amount" {
+		t.Errorf("Expected line 3, column amount, got %v", rowErr)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected the conversion error to be wrapped, got %v", err)
+	}
+}
+
+func TestCSVReader_OnErrorSkipsInvalidRows(t *testing.T) {
+	input := "id,customer,amount,paid,placed_at\n" +
+		"1,a,1,true,\n" +
+		"2,b,2,perhaps,\n" +
+		"3,c\n" +
+		"4,d,4,false,2024-13-01T00:00:00Z\n" +
+		"5,e,5,false,\n"
+
+	var rejected []*RowError
+	reader := NewCSVReader[Order](strings.NewReader(input))
+	reader.OnError = func(err *RowError) error {
+		rejected = append(rejected, err)
+		return nil
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(records) != 2 || records[0].ID != 1 || records[1].ID != 5 {
+		t.Errorf("Expected records 1 and 5, got %v", records)
+	}
+	expected := []string{
+		`line 3, column "paid"`,
+		`line 4: wrong number of fields`,
+		`line 5, column "placed_at"`,
+	}
+	if len(rejected) != len(expected) {
+		t.Fatalf("Expected %d rejected rows, got %v", len(expected), rejected)
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(rejected[i].Error(), prefix) {
+			t.Errorf("Expected error starting with %q, got %q", prefix, rejected[i].Error())
+		}
+	}
+}
+
+func TestCSVReader_ReadAndOptions(t *testing.T) {
+	input := "id;customer;amount;paid;placed_at;note\n1;a;1;1;02/01/2024;hi\n"
+	reader := NewCSVReader[Order](strings.NewReader(input))
+	reader.Comma = ';'
+	reader.TimeLayout = "02/01/2006"
+
+	record, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record.Note != "hi" || !record.Paid || !record.Placed.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+	if _, err := reader.Read(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+}
+
+func TestCSVReader_Source(t *testing.T) {
+	result, err := algo.NewPipeline[Order]().
+		Take(1).
+		ExecuteSource(NewCSVReader[Order](strings.NewReader(ordersCSV)).Source())
+	if err != nil || len(result) != 1 || result[0].ID != 1 {
+		t.Errorf("Expected the first order, got %v (%v)", result, err)
+	}
+}
+
+func TestCSVWriter_RoundTrip(t *testing.T) {
+	orders := []Order{
+		{ID: 1, Customer: "alice, jr.", Amount: 120.5, Paid: true, Placed: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Customer: "bob", Amount: 80, Note: `say "hi"`},
+	}
+
+	var buf bytes.Buffer
+	if err := NewCSVWriter[Order](&buf).WriteAll(orders); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "id,customer,amount,paid,placed_at,note\n" +
+		"1,\"alice, jr.\",120.5,true,2024-01-02T00:00:00Z,\n" +
+		"2,bob,80,false,0001-01-01T00:00:00Z,\"say \"\"hi\"\"\"\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	decoded, err := NewCSVReader[Order](&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, orders) {
+		t.Errorf("Expected %v, got %v", orders, decoded)
+	}
+}
+
+func TestCSVWriter_EmptyWritesHeader(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewCSVWriter[Order](&buf)
+	writer.Comma = '\t'
+	if err := writer.WriteAll(nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.String() != "id\tcustomer\tamount\tpaid\tplaced_at\tnote\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
+
+func TestToCSV(t *testing.T) {
+	orders := []Order{{ID: 1, Amount: 5}, {ID: 2, Amount: 50}, {ID: 3, Amount: 500}}
+
+	var buf bytes.Buffer
+	err := ToCSV(algo.NewPipelineWithData(orders).Filter(func(o Order) bool { return o.Amount > 10 }).Take(1), &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[1], "2,") {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	failing := algo.NewPipelineWithData([]Order{}).Reduce(func(a, b Order) Order { return a })
+	if err := ToCSV(failing, &buf); err == nil {
+		t.Error("Expected error from failing pipeline")
+	}
+}
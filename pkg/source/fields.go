@@ -0,0 +1,174 @@
+package source
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// column is a struct field mapped to a named column.
+type column struct {
+	name     string
+	index    []int
+	optional bool
+}
+
+// columnCache holds the columns of every struct type seen so far, keyed by reflect.Type.
+var columnCache sync.Map
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// structColumns returns the columns of struct type t in field order.
+// Fields of embedded structs are promoted as in encoding/json; unexported fields and fields
+// promoted through embedded pointers are ignored.
+// Returns an error if t is not a struct or two fields map to the same column.
+func structColumns(t reflect.Type) ([]column, error) {
+	if cached, ok := columnCache.Load(t); ok {
+		return cached.([]column), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%v is not a struct type", t)
+	}
+
+	var columns []column
+	seen := make(map[string]bool)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous || throughPointer(t, f.Index) {
+			continue
+		}
+
+		name, options, _ := strings.Cut(f.Tag.Get("algo"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%v: duplicate column %q", t, name)
+		}
+		seen[name] = true
+		columns = append(columns, column{name: name, index: f.Index, optional: options == "optional"})
+	}
+
+	columnCache.Store(t, columns)
+	return columns, nil
+}
+
+// throughPointer reports whether the field at index is promoted through an embedded pointer,
+// which may be nil and cannot be addressed without allocating.
+func throughPointer(t reflect.Type, index []int) bool {
+	for i := 1; i < len(index); i++ {
+		if t.FieldByIndex(index[:i]).Type.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeValue parses s into v according to the type of v.
+// An empty string leaves v at its zero value.
+// Times are parsed with layout; durations with time.ParseDuration.
+func decodeValue(v reflect.Value, s, layout string) error {
+	if s == "" {
+		v.SetZero()
+		return nil
+	}
+
+	switch {
+	case v.Type() == timeType:
+		parsed, err := time.Parse(layout, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(parsed))
+		return nil
+	case v.Type() == durationType:
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(parsed))
+		return nil
+	case v.Kind() == reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		return decodeValue(v.Elem(), s, layout)
+	case v.Addr().Type().Implements(textUnmarshalerType):
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %v", v.Type())
+	}
+	return nil
+}
+
+// encodeValue formats v as a string; it is the inverse of decodeValue.
+// A nil pointer is formatted as an empty string.
+func encodeValue(v reflect.Value, layout string) (string, error) {
+	switch {
+	case v.Type() == timeType:
+		return v.Interface().(time.Time).Format(layout), nil
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String(), nil
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return "", nil
+		}
+		return encodeValue(v.Elem(), layout)
+	case v.Type().Implements(textMarshalerType):
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %v", v.Type())
+	}
+}
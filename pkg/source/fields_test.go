@@ -0,0 +1,138 @@
+package source
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type base struct {
+	ID int `algo:"id"`
+}
+
+type Embedded struct {
+	Region string
+}
+
+type fieldsRecord struct {
+	base
+	*Embedded
+	Name     string `algo:"name"`
+	Note     string `algo:"note,optional"`
+	Skipped  string `algo:"-"`
+	Plain    int
+	internal int
+}
+
+func TestStructColumns(t *testing.T) {
+	columns, err := structColumns(reflect.TypeOf(fieldsRecord{}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, col := range columns {
+		names = append(names, col.name)
+	}
+	expected := []string{"id", "name", "note", "Plain"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+	if columns[1].optional || !columns[2].optional {
+		t.Error("Expected only note to be optional")
+	}
+}
+
+func TestStructColumns_Errors(t *testing.T) {
+	if _, err := structColumns(reflect.TypeOf(0)); err == nil {
+		t.Error("Expected error for non-struct type")
+	}
+
+	type duplicate struct {
+		A string `algo:"x"`
+		B string `algo:"x"`
+	}
+	if _, err := structColumns(reflect.TypeOf(duplicate{})); err == nil {
+		t.Error("Expected error for duplicate column")
+	}
+}
+
+type valuesRecord struct {
+	S   string
+	B   bool
+	I   int
+	I8  int8
+	U   uint16
+	F   float64
+	T   time.Time
+	D   time.Duration
+	P   *int
+	IP  netip.Addr
+	Bad complex64
+}
+
+func TestDecodeEncodeValue(t *testing.T) {
+	var record valuesRecord
+	v := reflect.ValueOf(&record).Elem()
+	inputs := map[string]string{
+		"S": "hello", "B": "true", "I": "-42", "I8": "12", "U": "65535", "F": "2.5",
+		"T": "2024-03-01T10:00:00Z", "D": "1m30s", "P": "7", "IP": "10.0.0.1",
+	}
+	for name, input := range inputs {
+		if err := decodeValue(v.FieldByName(name), input, time.RFC3339); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+	}
+
+	if record.S != "hello" || !record.B || record.I != -42 || record.I8 != 12 || record.U != 65535 || record.F != 2.5 {
+		t.Errorf("Unexpected scalar values: %+v", record)
+	}
+	if !record.T.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)) || record.D != 90*time.Second {
+		t.Errorf("Unexpected time values: %v %v", record.T, record.D)
+	}
+	if record.P == nil || *record.P != 7 || record.IP != netip.MustParseAddr("10.0.0.1") {
+		t.Errorf("Unexpected pointer or text values: %v %v", record.P, record.IP)
+	}
+
+	for name, input := range inputs {
+		output, err := encodeValue(v.FieldByName(name), time.RFC3339)
+		if err != nil || output != input {
+			t.Errorf("%s: expected %q, got %q (%v)", name, input, output, err)
+		}
+	}
+}
+
+func TestDecodeValue_Errors(t *testing.T) {
+	var record valuesRecord
+	v := reflect.ValueOf(&record).Elem()
+	cases := map[string]string{"B": "maybe", "I": "1.5", "I8": "300", "U": "-1", "F": "abc", "T": "yesterday", "D": "5", "IP": "x"}
+	for name, input := range cases {
+		if err := decodeValue(v.FieldByName(name), input, time.RFC3339); err == nil {
+			t.Errorf("%s: expected error for %q", name, input)
+		}
+	}
+	if err := decodeValue(v.FieldByName("Bad"), "1", time.RFC3339); err == nil {
+		t.Error("Expected error for unsupported type")
+	}
+	if _, err := encodeValue(v.FieldByName("Bad"), time.RFC3339); err == nil {
+		t.Error("Expected error for unsupported type")
+	}
+}
+
+func TestDecodeValue_EmptyIsZero(t *testing.T) {
+	n := 3
+	record := valuesRecord{I: 5, P: &n}
+	v := reflect.ValueOf(&record).Elem()
+	for _, name := range []string{"I", "P"} {
+		if err := decodeValue(v.FieldByName(name), "", time.RFC3339); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if record.I != 0 || record.P != nil {
+		t.Errorf("Expected zero values, got %d and %v", record.I, record.P)
+	}
+	if output, _ := encodeValue(v.FieldByName("P"), time.RFC3339); output != "" {
+		t.Errorf("Expected empty string for nil pointer, got %q", output)
+	}
+}
@@ -0,0 +1,167 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// JSONLinesReader reads newline-delimited JSON, decoding every non-blank line into a T.
+// Lines may be of any length.
+type JSONLinesReader[T any] struct {
+	// OnError, if set, receives every malformed line and reading continues with the next line
+	// unless it returns an error. If OnError is nil, reading stops at the first malformed line.
+	// See RejectTo and RejectToChannel.
+	OnError func(err *RowError) error
+
+	reader *bufio.Reader
+	line   int
+	done   bool
+}
+
+// NewJSONLinesReader creates a reader that reads JSON Lines from r.
+//
+// Example:
+//
+//	reader := NewJSONLinesReader[Event](file)
+//	reader.OnError = RejectTo(rejectFile)
+//	err := pipeline.Stream(reader.Source(), ToJSONLines[Event](os.Stdout))
+func NewJSONLinesReader[T any](r io.Reader) *JSONLinesReader[T] {
+	return &JSONLinesReader[T]{reader: bufio.NewReader(r)}
+}
+
+// Read returns the next record, or io.EOF after the last one.
+func (j *JSONLinesReader[T]) Read() (T, error) {
+	var record T
+	for !j.done {
+		line, err := j.reader.ReadBytes('\n')
+		if err == io.EOF {
+			j.done = true
+		} else if err != nil {
+			return record, fmt.Errorf("JSONLinesReader: %w", err)
+		}
+
+		j.line++
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var decoded T
+		if err := json.Unmarshal(line, &decoded); err != nil {
+			rowErr := &RowError{Line: j.line, Record: string(line), Err: err}
+			if j.OnError == nil {
+				return record, fmt.Errorf("JSONLinesReader: %w", rowErr)
+			}
+			if err := j.OnError(rowErr); err != nil {
+				return record, fmt.Errorf("JSONLinesReader: %w", err)
+			}
+			continue
+		}
+		return decoded, nil
+	}
+	return record, io.EOF
+}
+
+// ReadAll reads all remaining records.
+func (j *JSONLinesReader[T]) ReadAll() ([]T, error) {
+	var records []T
+	for {
+		record, err := j.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+// Source returns a source that reads records one at a time, for use with Pipeline.Stream.
+func (j *JSONLinesReader[T]) Source() algo.Source[T] {
+	return readerSource[T](j.Read)
+}
+
+// FromJSONLines returns a source that decodes JSON Lines from r one record at a time.
+// Only as much input is read as the pipeline needs, so a Take stops reading early.
+// The stream fails at the first malformed line; use NewJSONLinesReader with OnError to skip such lines.
+//
+// Example:
+//
+//	errors, err := algo.NewPipeline[Event]().
+//	    Filter(func(e Event) bool { return e.Level == "error" }).
+//	    Take(100).
+//	    ExecuteSource(FromJSONLines[Event](file))
+func FromJSONLines[T any](r io.Reader) algo.Source[T] {
+	return NewJSONLinesReader[T](r).Source()
+}
+
+// JSONLinesWriter writes records as newline-delimited JSON.
+type JSONLinesWriter[T any] struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewJSONLinesWriter creates a writer that writes JSON Lines to w.
+// Records are buffered; call Flush after the last one.
+//
+// Example:
+//
+//	writer := NewJSONLinesWriter[Event](file)
+//	err := writer.WriteAll(events)
+func NewJSONLinesWriter[T any](w io.Writer) *JSONLinesWriter[T] {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	return &JSONLinesWriter[T]{writer: writer, encoder: encoder}
+}
+
+// Write writes a single record as one line.
+func (j *JSONLinesWriter[T]) Write(record T) error {
+	if err := j.encoder.Encode(record); err != nil {
+		return fmt.Errorf("JSONLinesWriter: %w", err)
+	}
+	return nil
+}
+
+// WriteAll writes all records and flushes the output.
+func (j *JSONLinesWriter[T]) WriteAll(records []T) error {
+	for _, record := range records {
+		if err := j.Write(record); err != nil {
+			return err
+		}
+	}
+	return j.Flush()
+}
+
+// Flush writes any buffered records to the underlying writer.
+func (j *JSONLinesWriter[T]) Flush() error {
+	if err := j.writer.Flush(); err != nil {
+		return fmt.Errorf("JSONLinesWriter: %w", err)
+	}
+	return nil
+}
+
+// ToJSONLines returns a sink that writes every record to w as one JSON line.
+// The sink does not buffer, so wrap w in a bufio.Writer for large outputs and flush it afterwards.
+//
+// Example:
+//
+//	err := algo.NewPipeline[Event]().
+//	    Filter(isError).
+//	    Stream(FromJSONLines[Event](os.Stdin), ToJSONLines[Event](os.Stdout))
+func ToJSONLines[T any](w io.Writer) algo.Sink[T] {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return func(record T) error {
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("ToJSONLines: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,207 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+type Event struct {
+	ID    int    `json:"id"`
+	Level string `json:"level"`
+	Text  string `json:"text"`
+}
+
+// countingReader counts how many bytes have been read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func eventLines(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		level := "info"
+		if i%10 == 0 {
+			level = "error"
+		}
+		line, _ := json.Marshal(Event{ID: i, Level: level, Text: strings.Repeat("x", 100)})
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func TestFromJSONLines_StopsReadingEarly(t *testing.T) {
+	input := eventLines(100000)
+	counter := &countingReader{r: strings.NewReader(input)}
+
+	result, err := algo.NewPipeline[Event]().
+		Filter(func(e Event) bool { return e.Level == "error" }).
+		Take(3).
+		ExecuteSource(FromJSONLines[Event](counter))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result) != 3 || result[0].ID != 0 || result[1].ID != 10 || result[2].ID != 20 {
+		t.Errorf("Expected events 0, 10 and 20, got %v", result)
+	}
+	if counter.n > 16*1024 {
+		t.Errorf("Expected to read only the first few lines, read %d of %d bytes", counter.n, len(input))
+	}
+}
+
+func TestJSONLinesReader_ReadAll(t *testing.T) {
+	input := "{\"id\":1,\"level\":\"info\"}\n\n  {\"id\":2,\"level\":\"error\",\"text\":\"a\\nb\"}  \r\n{\"id\":3}"
+	records, err := NewJSONLinesReader[Event](strings.NewReader(input)).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []Event{{ID: 1, Level: "info"}, {ID: 2, Level: "error", Text: "a\nb"}, {ID: 3}}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("Expected %v, got %v", expected, records)
+	}
+}
+
+func TestJSONLinesReader_LongLines(t *testing.T) {
+	long := Event{ID: 1, Text: strings.Repeat("y", 200000)}
+	line, _ := json.Marshal(long)
+	records, err := NewJSONLinesReader[Event](bytes.NewReader(line)).ReadAll()
+	if err != nil || len(records) != 1 || records[0] != long {
+		t.Errorf("Expected the long line to be decoded, got %d records (%v)", len(records), err)
+	}
+}
+
+const malformedLines = `{"id":1}
+{"id":2
+{"id":"three"}
+{"id":4}
+`
+
+func TestJSONLinesReader_StopsAtMalformedLine(t *testing.T) {
+	_, err := NewJSONLinesReader[Event](strings.NewReader(malformedLines)).ReadAll()
+
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) {
+		t.Fatalf("Expected RowError, got %v", err)
+	}
+	if rowErr.Line != 2 || rowErr.Record != `{"id":2` {
+		t.Errorf("Expected line 2 with its raw record, got %+v", rowErr)
+	}
+}
+
+func TestJSONLinesReader_RejectTo(t *testing.T) {
+	var rejects bytes.Buffer
+	reader := NewJSONLinesReader[Event](strings.NewReader(malformedLines))
+	reader.OnError = RejectTo(&rejects)
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(records) != 2 || records[0].ID != 1 || records[1].ID != 4 {
+		t.Errorf("Expected records 1 and 4, got %v", records)
+	}
+
+	var lines []int
+	scanner := bufio.NewScanner(&rejects)
+	for scanner.Scan() {
+		var reject struct {
+			Line   int    `json:"line"`
+			Error  string `json:"error"`
+			Record string `json:"record"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &reject); err != nil {
+			t.Fatalf("Malformed reject line %q: %v", scanner.Text(), err)
+		}
+		if reject.Error == "" || reject.Record == "" {
+			t.Errorf("Expected error and record in %q", scanner.Text())
+		}
+		lines = append(lines, reject.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{2, 3}) {
+		t.Errorf("Expected rejected lines [2 3], got %v", lines)
+	}
+}
+
+func TestJSONLinesReader_RejectToChannel(t *testing.T) {
+	rejects := make(chan *RowError, 10)
+	reader := NewJSONLinesReader[Event](strings.NewReader(malformedLines))
+	reader.OnError = RejectToChannel(rejects)
+
+	result, err := algo.NewPipeline[Event]().ExecuteSource(reader.Source())
+	if err != nil || len(result) != 2 {
+		t.Fatalf("Expected 2 records, got %v (%v)", result, err)
+	}
+	close(rejects)
+
+	var lines []int
+	for reject := range rejects {
+		lines = append(lines, reject.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{2, 3}) {
+		t.Errorf("Expected rejected lines [2 3], got %v", lines)
+	}
+}
+
+func TestJSONLinesReader_OnErrorCanAbort(t *testing.T) {
+	stop := errors.New("too many rejects")
+	reader := NewJSONLinesReader[Event](strings.NewReader(malformedLines))
+	reader.OnError = func(err *RowError) error { return stop }
+
+	if _, err := reader.ReadAll(); !errors.Is(err, stop) {
+		t.Errorf("Expected abort error, got %v", err)
+	}
+}
+
+func TestJSONLinesWriter_RoundTrip(t *testing.T) {
+	events := []Event{{ID: 1, Level: "info", Text: "<b>&</b>"}, {ID: 2, Level: "error"}}
+
+	var buf bytes.Buffer
+	if err := NewJSONLinesWriter[Event](&buf).WriteAll(events); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `{"id":1,"level":"info","text":"<b>&</b>"}` + "\n" + `{"id":2,"level":"error","text":""}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	decoded, err := NewJSONLinesReader[Event](&buf).ReadAll()
+	if err != nil || !reflect.DeepEqual(decoded, events) {
+		t.Errorf("Expected %v, got %v (%v)", events, decoded, err)
+	}
+}
+
+func TestToJSONLines_Stream(t *testing.T) {
+	input := eventLines(50)
+
+	var buf bytes.Buffer
+	err := algo.NewPipeline[Event]().
+		Filter(func(e Event) bool { return e.Level == "error" }).
+		MergeSort(func(a, b Event) bool { return a.ID > b.ID }).
+		Take(2).
+		Stream(FromJSONLines[Event](strings.NewReader(input)), ToJSONLines[Event](&buf))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	decoded, _ := NewJSONLinesReader[Event](&buf).ReadAll()
+	if len(decoded) != 2 || decoded[0].ID != 40 || decoded[1].ID != 30 {
+		t.Errorf("Expected events 40 and 30, got %v", decoded)
+	}
+}
@@ -0,0 +1,103 @@
+// Package source connects pipelines to external data: it reads records into slices of structs
+// and writes pipeline results back out.
+//
+// Struct fields are mapped to columns by name. The column name defaults to the field name and
+// can be changed with an `algo` struct tag; a tag of "-" skips the field and the "optional"
+// option allows the column to be absent:
+//
+//	type Order struct {
+//	    ID       int       `algo:"id"`
+//	    Amount   float64   `algo:"amount"`
+//	    Placed   time.Time `algo:"placed_at"`
+//	    Note     string    `algo:"note,optional"`
+//	    Internal string    `algo:"-"`
+//	}
+//
+//	orders, err := source.FromCSV[Order](file)
+//	result, err := orders.Filter(func(o Order) bool { return o.Amount > 100 }).Execute()
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// RowError describes a record that could not be read.
+// Line is the 1-based line number in the input; Column names the offending column, if any;
+// Record holds the raw input of the record when the format keeps it, such as a JSON line.
+type RowError struct {
+	Line   int
+	Column string
+	Record string
+	Err    error
+}
+
+// Error returns the error message including the line and column.
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d, column %q: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// RejectTo returns an error handler that writes every rejected record to w as a JSON line holding
+// its line number, error message and raw input, so that the rejects can be inspected or replayed.
+// Reading stops if w returns an error.
+//
+// Example:
+//
+//	reader := NewJSONLinesReader[Event](input)
+//	reader.OnError = RejectTo(rejectFile)
+func RejectTo(w io.Writer) func(err *RowError) error {
+	encoder := json.NewEncoder(w)
+	return func(err *RowError) error {
+		return encoder.Encode(struct {
+			Line   int    `json:"line"`
+			Column string `json:"column,omitempty"`
+			Error  string `json:"error"`
+			Record string `json:"record,omitempty"`
+		}{err.Line, err.Column, err.Err.Error(), err.Record})
+	}
+}
+
+// RejectToChannel returns an error handler that sends every rejected record to ch.
+// Reading blocks while ch is full.
+//
+// Example:
+//
+//	rejects := make(chan *RowError, 100)
+//	go logRejects(rejects)
+//	reader.OnError = RejectToChannel(rejects)
+func RejectToChannel(ch chan<- *RowError) func(err *RowError) error {
+	return func(err *RowError) error {
+		ch <- err
+		return nil
+	}
+}
+
+// readerSource adapts a function returning one record per call, and io.EOF at the end,
+// to a pipeline source.
+func readerSource[T any](read func() (T, error)) algo.Source[T] {
+	return func(yield func(T) bool) error {
+		for {
+			record, err := read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !yield(record) {
+				return nil
+			}
+		}
+	}
+}
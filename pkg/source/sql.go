@@ -0,0 +1,305 @@
+package source
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// FromRows returns a source that scans every row of rows into a T.
+// Result columns are matched to fields by name, ignoring case; extra result columns are ignored and
+// a field without a matching column is an error unless it is optional. Nullable columns need pointer
+// or sql.Null* fields. The rows are closed when the stream ends, including when it stops early.
+//
+// Example:
+//
+//	rows, err := db.QueryContext(ctx, "SELECT id, amount, status FROM orders")
+//	result, err := algo.NewPipeline[Order]().
+//	    Filter(isLarge).
+//	    ExecuteSource(FromRows[Order](rows))
+func FromRows[T any](rows *sql.Rows) algo.Source[T] {
+	return func(yield func(T) bool) (err error) {
+		defer func() {
+			if closeErr := rows.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("FromRows: %w", closeErr)
+			}
+		}()
+
+		scan, err := rowScanner[T](rows)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			record, err := scan()
+			if err != nil {
+				return err
+			}
+			if !yield(record) {
+				return nil
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("FromRows: %w", err)
+		}
+		return nil
+	}
+}
+
+// FromQuery returns a source that runs query against db when the stream starts and scans the rows
+// into T as described for FromRows.
+//
+// Example:
+//
+//	orders := FromQuery[Order](ctx, db, "SELECT * FROM orders WHERE placed_at >= ?", since)
+//	err := pipeline.Stream(orders, ToJSONLines[Order](os.Stdout))
+func FromQuery[T any](ctx context.Context, db *sql.DB, query string, args ...any) algo.Source[T] {
+	return func(yield func(T) bool) error {
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("FromQuery: %w", err)
+		}
+		return FromRows[T](rows)(yield)
+	}
+}
+
+// ScanRows scans all rows into a slice of T and closes them.
+//
+// Example:
+//
+//	rows, err := db.QueryContext(ctx, "SELECT * FROM orders")
+//	orders, err := ScanRows[Order](rows)
+//	pipeline := algo.NewPipelineWithData(orders)
+func ScanRows[T any](rows *sql.Rows) ([]T, error) {
+	var records []T
+	err := FromRows[T](rows)(func(record T) bool {
+		records = append(records, record)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// rowScanner maps the result columns of rows to the fields of T and returns a function that scans
+// the current row.
+func rowScanner[T any](rows *sql.Rows) (func() (T, error), error) {
+	columns, err := structColumns(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, fmt.Errorf("FromRows: %w", err)
+	}
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("FromRows: %w", err)
+	}
+
+	// targets[i] is the field index scanned from result column i, or nil if the column is ignored.
+	targets := make([][]int, len(names))
+	var missing []string
+	for _, col := range columns {
+		found := false
+		for i, name := range names {
+			if targets[i] == nil && strings.EqualFold(name, col.name) {
+				targets[i] = col.index
+				found = true
+				break
+			}
+		}
+		if !found && !col.optional {
+			missing = append(missing, col.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("FromRows: result is missing columns %s", strings.Join(missing, ", "))
+	}
+
+	dest := make([]any, len(names))
+	return func() (T, error) {
+		var record T
+		v := reflect.ValueOf(&record).Elem()
+		for i, index := range targets {
+			if index == nil {
+				dest[i] = new(any)
+			} else {
+				dest[i] = v.FieldByIndex(index).Addr().Interface()
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return record, fmt.Errorf("FromRows: %w", err)
+		}
+		return record, nil
+	}, nil
+}
+
+// SQLInserter inserts records of type T into a table in batches.
+// Every batch is written in its own transaction through a single prepared statement, and the column
+// list is taken from the fields of T as for the readers in this package.
+type SQLInserter[T any] struct {
+	// Placeholder returns the bind parameter for the n-th value, starting at 1. It defaults to "?";
+	// use a function returning "$n" for PostgreSQL drivers.
+	Placeholder func(n int) string
+	// QuoteIdentifier returns the quoted form of the table and column names. When it is nil, every
+	// name must be a plain identifier of letters, digits and underscores, optionally qualified with
+	// dots, and is used as it is; other names are rejected so that they cannot alter the statement.
+	// Set it to ANSIQuote, or to a function using backticks for MySQL, to allow any name.
+	QuoteIdentifier func(name string) string
+
+	db        *sql.DB
+	table     string
+	batchSize int
+	pending   []T
+	query     string
+	columns   []column
+}
+
+// NewSQLInserter creates an inserter that writes to table in batches of batchSize records.
+// A batchSize below 1 is treated as 1. Table and column names are checked as described for QuoteIdentifier.
+//
+// Example:
+//
+//	inserter := NewSQLInserter[Summary](db, "daily_summaries", 500)
+//	err := pipeline.Stream(FromQuery[Order](ctx, db, query), inserter.Sink(ctx))
+//	err = inserter.Flush(ctx)
+func NewSQLInserter[T any](db *sql.DB, table string, batchSize int) *SQLInserter[T] {
+	return &SQLInserter[T]{
+		Placeholder: func(int) string { return "?" },
+		db:          db,
+		table:       table,
+		batchSize:   max(batchSize, 1),
+	}
+}
+
+// Write queues a record and inserts the queued batch once it reaches the batch size.
+func (s *SQLInserter[T]) Write(ctx context.Context, record T) error {
+	s.pending = append(s.pending, record)
+	if len(s.pending) < s.batchSize {
+		return nil
+	}
+	return s.Flush(ctx)
+}
+
+// WriteAll inserts all records and flushes the last, possibly partial, batch.
+func (s *SQLInserter[T]) WriteAll(ctx context.Context, records []T) error {
+	for _, record := range records {
+		if err := s.Write(ctx, record); err != nil {
+			return err
+		}
+	}
+	return s.Flush(ctx)
+}
+
+// Sink returns a sink that writes every element with Write.
+// Call Flush after the stream ends to insert the last batch.
+func (s *SQLInserter[T]) Sink(ctx context.Context) algo.Sink[T] {
+	return func(record T) error {
+		return s.Write(ctx, record)
+	}
+}
+
+// Flush inserts the queued records in a single transaction.
+// On failure the transaction is rolled back and the records stay queued.
+func (s *SQLInserter[T]) Flush(ctx context.Context) (err error) {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	if s.query == "" {
+		if err := s.prepareQuery(); err != nil {
+			return err
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("SQLInserter: %w", err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = errors.Join(err, fmt.Errorf("SQLInserter: %w", rollbackErr))
+		}
+	}()
+
+	// Statements prepared within a transaction are closed when it ends.
+	stmt, err := tx.PrepareContext(ctx, s.query)
+	if err != nil {
+		return fmt.Errorf("SQLInserter: %w", err)
+	}
+
+	args := make([]any, len(s.columns))
+	for _, record := range s.pending {
+		v := reflect.ValueOf(record)
+		for i, col := range s.columns {
+			args[i] = v.FieldByIndex(col.index).Interface()
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return fmt.Errorf("SQLInserter: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("SQLInserter: %w", err)
+	}
+	s.pending = s.pending[:0]
+	return nil
+}
+
+// prepareQuery builds the INSERT statement for the columns of T.
+func (s *SQLInserter[T]) prepareQuery() error {
+	columns, err := structColumns(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return fmt.Errorf("SQLInserter: %w", err)
+	}
+
+	table, err := s.identifier(s.table)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	for i, col := range columns {
+		if names[i], err = s.identifier(col.name); err != nil {
+			return err
+		}
+		placeholders[i] = s.Placeholder(i + 1)
+	}
+	s.columns = columns
+	s.query = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		table, strings.Join(names, ", "), strings.Join(placeholders, ", "))
+	return nil
+}
+
+// identifier returns a table or column name as it is written into the statement.
+func (s *SQLInserter[T]) identifier(name string) (string, error) {
+	if s.QuoteIdentifier != nil {
+		return s.QuoteIdentifier(name), nil
+	}
+	if !plainIdentifier.MatchString(name) {
+		return "", fmt.Errorf("SQLInserter: %q is not a plain identifier; set QuoteIdentifier to quote it", name)
+	}
+	return name, nil
+}
+
+// plainIdentifier matches names that need no quoting, such as "daily_summaries" or "sales.orders".
+var plainIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// ANSIQuote quotes a name with double quotes as in standard SQL, which PostgreSQL, SQLite and
+// SQL Server accept. Each dot-separated part is quoted separately and embedded quotes are doubled.
+//
+// Example:
+//
+//	inserter := NewSQLInserter[Summary](db, "reporting.Daily Summaries", 500)
+//	inserter.QuoteIdentifier = ANSIQuote // INSERT INTO "reporting"."Daily Summaries" ...
+func ANSIQuote(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+	return strings.Join(parts, ".")
+}
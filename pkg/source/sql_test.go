@@ -0,0 +1,329 @@
+package source
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// fakeStore is the shared state of one fake database: canned query results and a log of executions.
+type fakeStore struct {
+	mu       sync.Mutex
+	results  map[string]fakeResult
+	execs    []fakeExec
+	prepares []string
+	commits  int
+	failExec int // the n-th Exec fails when positive
+	rowsRead int
+}
+
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+var (
+	fakeStoresMu sync.Mutex
+	fakeStores   = map[string]*fakeStore{}
+)
+
+func init() {
+	sql.Register("fakedb", fakeDriver{})
+}
+
+// openFakeDB opens a database backed by a new fake store.
+func openFakeDB(t *testing.T) (*sql.DB, *fakeStore) {
+	store := &fakeStore{results: map[string]fakeResult{}}
+	fakeStoresMu.Lock()
+	fakeStores[t.Name()] = store
+	fakeStoresMu.Unlock()
+
+	db, err := sql.Open("fakedb", t.Name())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, store
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeStoresMu.Lock()
+	defer fakeStoresMu.Unlock()
+	store, ok := fakeStores[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown fake database %q", name)
+	}
+	return &fakeConn{store: store}, nil
+}
+
+type fakeConn struct {
+	store *fakeStore
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	c.store.prepares = append(c.store.prepares, query)
+	return &fakeStmt{store: c.store, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return &fakeTx{store: c.store}, nil }
+
+type fakeTx struct {
+	store *fakeStore
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.store.mu.Lock()
+	defer tx.store.mu.Unlock()
+	tx.store.commits++
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	store *fakeStore
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	if s.store.failExec > 0 && len(s.store.execs)+1 == s.store.failExec {
+		return nil, errors.New("constraint violation")
+	}
+	s.store.execs = append(s.store.execs, fakeExec{query: s.query, args: args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	result, ok := s.store.results[s.query]
+	if !ok {
+		return nil, fmt.Errorf("no such table in %q", s.query)
+	}
+	return &fakeRows{store: s.store, result: result}, nil
+}
+
+type fakeRows struct {
+	store  *fakeStore
+	result fakeResult
+	pos    int
+}
+
+func (r *fakeRows) Columns() []string { return r.result.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.pos])
+	r.pos++
+	r.store.mu.Lock()
+	r.store.rowsRead++
+	r.store.mu.Unlock()
+	return nil
+}
+
+type Payment struct {
+	ID       int64      `algo:"id"`
+	Customer string     `algo:"customer"`
+	Amount   float64    `algo:"amount"`
+	Refunded *time.Time `algo:"refunded_at"`
+	Memo     string     `algo:"memo,optional"`
+}
+
+const paymentsQuery = "SELECT * FROM payments"
+
+func seedPayments(store *fakeStore, n int) {
+	refunded := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	result := fakeResult{columns: []string{"ID", "customer", "amount", "refunded_at", "extra"}}
+	for i := 1; i <= n; i++ {
+		var refundedAt driver.Value
+		if i%4 == 0 {
+			refundedAt = refunded
+		}
+		result.rows = append(result.rows, []driver.Value{int64(i), "c" + strconv.Itoa(i), float64(i) * 10, refundedAt, "x"})
+	}
+	store.results[paymentsQuery] = result
+}
+
+func TestFromQuery(t *testing.T) {
+	db, store := openFakeDB(t)
+	seedPayments(store, 8)
+
+	result, err := algo.NewPipeline[Payment]().
+		Filter(func(p Payment) bool { return p.Amount >= 30 }).
+		ExecuteSource(FromQuery[Payment](context.Background(), db, paymentsQuery))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result) != 6 || result[0].ID != 3 || result[0].Customer != "c3" || result[0].Refunded != nil {
+		t.Fatalf("Unexpected result: %+v", result)
+	}
+	if result[1].Refunded == nil || !result[1].Refunded.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected payment 4 to be refunded, got %v", result[1].Refunded)
+	}
+}
+
+func TestFromRows_StopsEarly(t *testing.T) {
+	db, store := openFakeDB(t)
+	seedPayments(store, 1000)
+
+	rows, err := db.Query(paymentsQuery)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result, err := algo.NewPipeline[Payment]().Take(5).ExecuteSource(FromRows[Payment](rows))
+	if err != nil || len(result) != 5 {
+		t.Fatalf("Expected 5 payments, got %d (%v)", len(result), err)
+	}
+	if store.rowsRead != 5 {
+		t.Errorf("Expected only 5 rows to be read, read %d", store.rowsRead)
+	}
+	if rows.Next() {
+		t.Error("Expected rows to be closed")
+	}
+}
+
+func TestScanRows_Errors(t *testing.T) {
+	db, store := openFakeDB(t)
+	store.results["SELECT partial"] = fakeResult{columns: []string{"id", "customer"}, rows: [][]driver.Value{{int64(1), "a"}}}
+	store.results["SELECT bad"] = fakeResult{
+		columns: []string{"id", "customer", "amount", "refunded_at"},
+		rows:    [][]driver.Value{{"not a number", "a", 1.0, nil}},
+	}
+
+	rows, _ := db.Query("SELECT partial")
+	if _, err := ScanRows[Payment](rows); err == nil {
+		t.Error("Expected error for missing columns")
+	}
+
+	rows, _ = db.Query("SELECT bad")
+	if _, err := ScanRows[Payment](rows); err == nil {
+		t.Error("Expected scan error")
+	}
+
+	if _, err := algo.NewPipeline[Payment]().ExecuteSource(FromQuery[Payment](context.Background(), db, "SELECT nothing")); err == nil {
+		t.Error("Expected query error")
+	}
+}
+
+func TestSQLInserter_Batches(t *testing.T) {
+	db, store := openFakeDB(t)
+	seedPayments(store, 10)
+
+	inserter := NewSQLInserter[Payment](db, "large_payments", 3)
+	err := algo.NewPipeline[Payment]().
+		Filter(func(p Payment) bool { return p.Amount > 20 }).
+		Stream(FromQuery[Payment](context.Background(), db, paymentsQuery), inserter.Sink(context.Background()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := inserter.Flush(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	const insert = "INSERT INTO large_payments (id, customer, amount, refunded_at, memo) VALUES (?, ?, ?, ?, ?)"
+	if len(store.execs) != 8 || store.commits != 3 {
+		t.Fatalf("Expected 8 inserts in 3 transactions, got %d in %d", len(store.execs), store.commits)
+	}
+	prepared := 0
+	for _, query := range store.prepares {
+		if query == insert {
+			prepared++
+		}
+	}
+	if prepared != 3 {
+		t.Errorf("Expected the insert to be prepared once per batch, got %d", prepared)
+	}
+
+	first := store.execs[0]
+	if first.query != insert || !reflect.DeepEqual(first.args, []driver.Value{int64(3), "c3", 30.0, nil, ""}) {
+		t.Errorf("Unexpected first insert: %q %v", first.query, first.args)
+	}
+}
+
+func TestSQLInserter_PlaceholderAndFailure(t *testing.T) {
+	db, store := openFakeDB(t)
+	store.failExec = 2
+
+	inserter := NewSQLInserter[Payment](db, "payments", 10)
+	inserter.Placeholder = func(n int) string { return "$" + strconv.Itoa(n) }
+	payments := []Payment{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	if err := inserter.WriteAll(context.Background(), payments); err == nil {
+		t.Fatal("Expected insert error")
+	}
+	if store.commits != 0 {
+		t.Errorf("Expected the failed batch not to be committed, got %d commits", store.commits)
+	}
+	if store.prepares[0] != "INSERT INTO payments (id, customer, amount, refunded_at, memo) VALUES ($1, $2, $3, $4, $5)" {
+		t.Errorf("Unexpected query: %q", store.prepares[0])
+	}
+
+	// The batch stays queued and can be retried.
+	store.failExec = 0
+	if err := inserter.Flush(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if store.commits != 1 || len(store.execs) != 4 {
+		t.Errorf("Expected 1 commit and 4 executions, got %d and %d", store.commits, len(store.execs))
+	}
+}
+
+func TestSQLInserter_Identifiers(t *testing.T) {
+	db, store := openFakeDB(t)
+
+	inserter := NewSQLInserter[Payment](db, "payments; DROP TABLE payments", 10)
+	err := inserter.WriteAll(context.Background(), []Payment{{ID: 1}})
+	if err == nil || !strings.Contains(err.Error(), "not a plain identifier") {
+		t.Fatalf("Expected the table name to be rejected, got %v", err)
+	}
+	if len(store.prepares) != 0 {
+		t.Errorf("Expected no statement to be prepared, got %q", store.prepares)
+	}
+
+	inserter = NewSQLInserter[Payment](db, `billing.Large "Payments"`, 10)
+	inserter.QuoteIdentifier = ANSIQuote
+	if err := inserter.WriteAll(context.Background(), []Payment{{ID: 1}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `INSERT INTO "billing"."Large ""Payments""" ("id", "customer", "amount", "refunded_at", "memo") ` +
+		`VALUES (?, ?, ?, ?, ?)`
+	if store.prepares[0] != expected {
+		t.Errorf("Unexpected query: %q", store.prepares[0])
+	}
+
+	inserter = NewSQLInserter[Payment](db, "billing.payments", 10)
+	if err := inserter.WriteAll(context.Background(), []Payment{{ID: 1}}); err != nil {
+		t.Errorf("Expected a qualified plain name to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package spec
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Field is a validated reference to a field of T, possibly nested, such as "Customer.Country".
+type Field[T any] struct {
+	// Name is the Go path of the field, such as "Customer.Country".
+	Name string
+	// Type is the type of the field.
+	Type reflect.Type
+
+	field *algo.Field
+}
+
+// ResolveField looks up a field of T by path, as algo.ResolveField does.
+// Each segment of the path matches a field by Go name or `algo` tag, ignoring case;
+// pointers to structs along the path are followed.
+//
+// Example:
+//
+//	field, err := spec.ResolveField[Order]("customer.country")
+//	country := field.Get(order).String()
+func ResolveField[T any](path string) (Field[T], error) {
+	field, err := algo.ResolveField[T](path)
+	if err != nil {
+		return Field[T]{}, err
+	}
+	return Field[T]{Name: field.Name, Type: field.Type, field: field}, nil
+}
+
+// Get returns the value of the field in item.
+// A nil pointer along the path yields the zero value of the field.
+func (f Field[T]) Get(item T) reflect.Value {
+	return f.field.Value(reflect.ValueOf(&item).Elem())
+}
+
+// Comparable reports whether values of the field can be ordered: booleans, numbers, strings,
+// durations and times.
+func (f Field[T]) Comparable() bool {
+	return f.field.Ordered()
+}
+
+// Compare orders the field values of two items.
+// The field must be Comparable.
+func (f Field[T]) Compare(a, b T) int {
+	return f.field.Compare(f.Get(a), f.Get(b))
+}
+
+// compareTo orders the field value of an item against a value converted with Convert.
+func (f Field[T]) compareTo(item T, value reflect.Value) int {
+	return f.field.Compare(f.Get(item), value)
+}
+
+// Convert converts a spec value, such as a string or int64 from a parsed spec, to the type of the field.
+// Strings are parsed for numeric, boolean, duration and time fields; times use RFC 3339 or 2006-01-02.
+func (f Field[T]) Convert(value any) (reflect.Value, error) {
+	v, err := convertValue(value, f.Type)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("field %s: %w", f.Name, err)
+	}
+	return v, nil
+}
+
+// convertValue converts a parsed spec value to t.
+func convertValue(value any, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if value == nil {
+		return v, nil
+	}
+
+	text, isText := value.(string)
+	switch {
+	case t == timeType:
+		if !isText {
+			return reflect.Value{}, fmt.Errorf("expected a time, got %v", value)
+		}
+		parsed, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			if parsed, err = time.Parse(time.DateOnly, text); err != nil {
+				return reflect.Value{}, fmt.Errorf("invalid time %q", text)
+			}
+		}
+		v.Set(reflect.ValueOf(parsed))
+		return v, nil
+	case t == durationType:
+		if !isText {
+			return reflect.Value{}, fmt.Errorf("expected a duration such as \"1h30m\", got %v", value)
+		}
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid duration %q", text)
+		}
+		v.SetInt(int64(d))
+		return v, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		switch value.(type) {
+		case string, int64, float64, bool:
+			v.SetString(fmt.Sprint(value))
+			return v, nil
+		}
+	case reflect.Bool:
+		switch b := value.(type) {
+		case bool:
+			v.SetBool(b)
+			return v, nil
+		case string:
+			parsed, err := strconv.ParseBool(b)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("invalid bool %q", b)
+			}
+			v.SetBool(parsed)
+			return v, nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if v.OverflowInt(n) {
+			return reflect.Value{}, fmt.Errorf("%d overflows %s", n, t)
+		}
+		v.SetInt(n)
+		return v, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := toInt(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if n < 0 || v.OverflowUint(uint64(n)) {
+			return reflect.Value{}, fmt.Errorf("%d overflows %s", n, t)
+		}
+		v.SetUint(uint64(n))
+		return v, nil
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if v.OverflowFloat(f) {
+			return reflect.Value{}, fmt.Errorf("%g overflows %s", f, t)
+		}
+		v.SetFloat(f)
+		return v, nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %v as %s", value, t)
+}
+
+// toInt converts a parsed spec value to an integer.
+func toInt(value any) (int64, error) {
+	switch n := value.(type) {
+	case int64:
+		return n, nil
+	case float64:
+		if n != math.Trunc(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+			return 0, fmt.Errorf("expected an integer, got %v", n)
+		}
+		return int64(n), nil
+	case string:
+		parsed, err := strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("expected an integer, got %q", n)
+		}
+		return parsed, nil
+	}
+	return 0, fmt.Errorf("expected an integer, got %v", value)
+}
+
+// toFloat converts a parsed spec value to a float.
+func toFloat(value any) (float64, error) {
+	switch n := value.(type) {
+	case int64:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case string:
+		parsed, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, fmt.Errorf("expected a number, got %q", n)
+		}
+		return parsed, nil
+	}
+	return 0, fmt.Errorf("expected a number, got %v", value)
+}
@@ -0,0 +1,80 @@
+package spec
+
+import (
+	"testing"
+	"time"
+)
+
+type Shipment struct {
+	*Customer
+	Weight   uint8
+	Duration time.Duration
+	Order    Order
+}
+
+func TestResolveField(t *testing.T) {
+	field, err := ResolveField[Order]("customer.COUNTRY")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if field.Name != "Customer.Country" || field.Type.Kind().String() != "string" {
+		t.Errorf("Unexpected field %s of type %v", field.Name, field.Type)
+	}
+	if got := field.Get(sampleOrders()[2]).String(); got != "FR" {
+		t.Errorf("Expected FR, got %s", got)
+	}
+
+	byTag, err := ResolveField[*Order]("customer.country")
+	if err != nil || byTag.Get(nil).String() != "" {
+		t.Errorf("Expected the zero value through a nil pointer, got %v", err)
+	}
+
+	promoted, err := ResolveField[Shipment]("VIP")
+	if err != nil || !promoted.field.ThroughPointer() || promoted.Get(Shipment{}).Bool() {
+		t.Errorf("Expected a promoted field through a nil pointer, got %+v (%v)", promoted, err)
+	}
+
+	for _, path := range []string{"", "Missing", "Customer.", "Amount.Value", "Order.Referrer.Missing"} {
+		if _, err := ResolveField[Shipment](path); err == nil {
+			t.Errorf("%q: expected error", path)
+		}
+	}
+}
+
+func TestField_Convert(t *testing.T) {
+	weight, _ := ResolveField[Shipment]("Weight")
+	duration, _ := ResolveField[Shipment]("Duration")
+	amount, _ := ResolveField[Shipment]("Order.Amount")
+	name, _ := ResolveField[Shipment]("Name")
+
+	valid := []struct {
+		field Field[Shipment]
+		value any
+	}{
+		{weight, int64(255)}, {weight, "12"}, {weight, 3.0}, {duration, "1h30m"},
+		{amount, int64(3)}, {amount, "2.5"}, {name, int64(42)}, {name, nil},
+	}
+	for _, tc := range valid {
+		if _, err := tc.field.Convert(tc.value); err != nil {
+			t.Errorf("%s %v: unexpected error: %v", tc.field.Name, tc.value, err)
+		}
+	}
+
+	invalid := []struct {
+		field Field[Shipment]
+		value any
+	}{
+		{weight, int64(256)}, {weight, int64(-1)}, {weight, 1.5}, {duration, int64(5)},
+		{duration, "soon"}, {amount, true}, {name, []any{"a"}},
+	}
+	for _, tc := range invalid {
+		if _, err := tc.field.Convert(tc.value); err == nil {
+			t.Errorf("%s %v: expected error", tc.field.Name, tc.value)
+		}
+	}
+
+	v, _ := duration.Convert("90s")
+	if time.Duration(v.Int()) != 90*time.Second {
+		t.Errorf("Expected 90s, got %v", v)
+	}
+}
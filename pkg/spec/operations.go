@@ -0,0 +1,252 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// comparisons maps the comparison parameters of a filter step to the comparison results they accept.
+var comparisons = []struct {
+	key    string
+	accept func(c int) bool
+}{
+	{"eq", func(c int) bool { return c == 0 }},
+	{"ne", func(c int) bool { return c != 0 }},
+	{"gt", func(c int) bool { return c > 0 }},
+	{"gte", func(c int) bool { return c >= 0 }},
+	{"lt", func(c int) bool { return c < 0 }},
+	{"lte", func(c int) bool { return c <= 0 }},
+}
+
+// buildFilter builds a filter step. It either names a registered predicate with "func", or
+// compares a "field" with any of eq, ne, gt, gte, lt, lte and in, which must all hold.
+func (r *Registry[T]) buildFilter(params *Params[T]) (algo.Operation[T], error) {
+	if params.Has("func") {
+		name := params.String("func")
+		predicate, ok := r.predicates[name]
+		if !ok && name != "" {
+			return nil, fmt.Errorf("unknown predicate %q", name)
+		}
+		return &algo.FilterOperation[T]{Predicate: predicate}, nil
+	}
+
+	field := params.Field("field")
+	if field.Type == nil {
+		return nil, nil
+	}
+	if !field.Comparable() {
+		return nil, fmt.Errorf("field %s of type %s cannot be compared", field.Name, field.Type)
+	}
+
+	var conditions []func(T) bool
+	for _, comparison := range comparisons {
+		if !params.Has(comparison.key) {
+			continue
+		}
+		value, err := field.Convert(params.Value(comparison.key))
+		if err != nil {
+			params.Fail(err)
+			continue
+		}
+		accept := comparison.accept
+		conditions = append(conditions, func(item T) bool {
+			return accept(field.compareTo(item, value))
+		})
+	}
+	if params.Has("in") {
+		list, ok := params.Value("in").([]any)
+		if !ok {
+			return nil, errors.New("parameter \"in\": expected a list")
+		}
+		values := make([]reflect.Value, 0, len(list))
+		for _, item := range list {
+			value, err := field.Convert(item)
+			if err != nil {
+				params.Fail(err)
+				continue
+			}
+			values = append(values, value)
+		}
+		conditions = append(conditions, func(item T) bool {
+			for _, value := range values {
+				if field.compareTo(item, value) == 0 {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	if len(conditions) == 0 && params.Err() == nil {
+		return nil, errors.New("expected a comparison: eq, ne, gt, gte, lt, lte or in")
+	}
+
+	return &algo.FilterOperation[T]{Predicate: func(item T) bool {
+		for _, condition := range conditions {
+			if !condition(item) {
+				return false
+			}
+		}
+		return true
+	}}, nil
+}
+
+// buildMap builds a map step. It either names a registered mapper with "func", or sets a
+// "field" to the constant given by "set".
+func (r *Registry[T]) buildMap(params *Params[T]) (algo.Operation[T], error) {
+	if params.Has("func") {
+		name := params.String("func")
+		mapper, ok := r.mappers[name]
+		if !ok && name != "" {
+			return nil, fmt.Errorf("unknown mapper %q", name)
+		}
+		return &algo.MapOperation[T]{Mapper: mapper}, nil
+	}
+
+	field := params.Field("field")
+	if field.Type == nil {
+		return nil, nil
+	}
+	if field.field.ThroughPointer() {
+		return nil, fmt.Errorf("field %s is reached through a pointer and cannot be set", field.Name)
+	}
+	value, err := field.Convert(params.Value("set"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &algo.MapOperation[T]{Mapper: func(item T) T {
+		// Without pointers on the path, the field of the addressable copy can be set.
+		field.field.Value(reflect.ValueOf(&item).Elem()).Set(value)
+		return item
+	}}, nil
+}
+
+// buildSort builds a sort step from "by", a list of field names each optionally followed by
+// "asc" or "desc". Items with equal keys keep their order.
+func buildSort[T comparable](params *Params[T]) (algo.Operation[T], error) {
+	type sortKey struct {
+		field      Field[T]
+		descending bool
+	}
+
+	var keys []sortKey
+	for _, spec := range params.Strings("by") {
+		parts := strings.Fields(spec)
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, fmt.Errorf("invalid sort key %q", spec)
+		}
+		key := sortKey{}
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				key.descending = true
+			default:
+				return nil, fmt.Errorf("invalid sort order %q in %q", parts[1], spec)
+			}
+		}
+
+		field, err := ResolveField[T](parts[0])
+		if err != nil {
+			params.Fail(err)
+			continue
+		}
+		if !field.Comparable() {
+			params.Fail(fmt.Errorf("field %s of type %s cannot be compared", field.Name, field.Type))
+			continue
+		}
+		key.field = field
+		keys = append(keys, key)
+	}
+
+	// Reporting ties as "in order" keeps the merge sort stable.
+	return &algo.MergeSortOperation[T]{Comparator: func(a, b T) bool {
+		for _, key := range keys {
+			c := key.field.Compare(a, b)
+			if key.descending {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return true
+	}}, nil
+}
+
+// buildTake builds a take step from "count".
+func buildTake[T comparable](params *Params[T]) (algo.Operation[T], error) {
+	return &algo.TakeOperation[T]{Count: params.Int("count")}, nil
+}
+
+// buildSkip builds a skip step from "count".
+func buildSkip[T comparable](params *Params[T]) (algo.Operation[T], error) {
+	return &algo.SkipOperation[T]{Count: params.Int("count")}, nil
+}
+
+// buildDistinct builds a distinct step. Items are compared by the optional "fields",
+// or as whole values when no fields are given.
+func buildDistinct[T comparable](params *Params[T]) (algo.Operation[T], error) {
+	if !params.Has("fields") {
+		return &distinctItemsOperation[T]{}, nil
+	}
+	fields := params.Fields("fields")
+	if err := params.Err(); err != nil {
+		return nil, err
+	}
+	return validated(&algo.DistinctByFieldsOperation[T]{Fields: fieldNames(fields)})
+}
+
+// buildGroupBy builds a groupBy step from "fields" and an optional per-group "limit".
+func buildGroupBy[T comparable](params *Params[T]) (algo.Operation[T], error) {
+	fields := params.Fields("fields")
+	if err := params.Err(); err != nil {
+		return nil, err
+	}
+	op := &algo.GroupByFieldsOperation[T]{Fields: fieldNames(fields)}
+	if params.Has("limit") {
+		op.Limit = params.Int("limit")
+		if op.Limit == 0 {
+			return nil, errors.New("parameter \"limit\": expected a positive integer")
+		}
+	}
+	return validated(op)
+}
+
+// validated applies op to no data, which reports fields that cannot be keyed when the spec is
+// built rather than when it runs.
+func validated[T comparable](op algo.Operation[T]) (algo.Operation[T], error) {
+	if _, err := op.Apply(nil); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// fieldNames returns the paths of fields.
+func fieldNames[T any](fields []Field[T]) []string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return names
+}
+
+// distinctItemsOperation keeps the first of each equal item, comparing every item with all earlier ones.
+type distinctItemsOperation[T comparable] struct{}
+
+// Apply performs the distinct operation on the data.
+func (d *distinctItemsOperation[T]) Apply(data []T) ([]T, error) {
+	seen := make(map[T]struct{}, len(data))
+	distinctData := make([]T, 0, len(data))
+	for _, item := range data {
+		if _, ok := seen[item]; !ok {
+			seen[item] = struct{}{}
+			distinctData = append(distinctData, item)
+		}
+	}
+	return distinctData, nil
+}
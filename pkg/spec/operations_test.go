@@ -0,0 +1,111 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilter_Comparisons(t *testing.T) {
+	cases := map[string][]int{
+		"field: Amount\n    gt: 100\n    lte: 150":         {1, 4, 5},
+		"field: Amount\n    gte: 150":                      {3, 4},
+		"field: Status\n    ne: completed":                 {2},
+		"field: Placed\n    lt: 2024-03-03":                {1, 2},
+		"field: Placed\n    gte: \"2024-03-04T00:00:00Z\"": {4, 5},
+		"field: Customer.VIP\n    eq: true":                {2, 5},
+		"field: customer.country\n    in: [US]":            {2, 4},
+		"field: Referrer.Name\n    eq: \"\"":               {1, 2, 3, 4, 5},
+	}
+	for params, expected := range cases {
+		ids := buildAndRun(t, NewRegistry[Order](), "steps:\n  - op: filter\n    "+params+"\n")
+		if !equalIDs(ids, expected) {
+			t.Errorf("%q: expected %v, got %v", params, expected, ids)
+		}
+	}
+
+	s, _ := Parse([]byte("steps:\n  - op: filter\n    field: Amount\n"))
+	if _, err := NewRegistry[Order]().Build(s); err == nil || !strings.Contains(err.Error(), "expected a comparison") {
+		t.Errorf("Expected a missing comparison error, got %v", err)
+	}
+}
+
+func TestMap_Set(t *testing.T) {
+	s, _ := Parse([]byte("steps:\n  - op: map\n    field: Customer.Country\n    set: XX\n"))
+	pipeline, err := NewRegistry[Order]().Build(s)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	orders := sampleOrders()
+	result, _ := pipeline.WithData(orders).Execute()
+	if result[0].Customer.Country != "XX" || orders[0].Customer.Country != "DE" {
+		t.Errorf("Expected a modified copy, got %q and original %q", result[0].Customer.Country, orders[0].Customer.Country)
+	}
+
+	s, _ = Parse([]byte("steps:\n  - op: map\n    field: Referrer.Name\n    set: x\n"))
+	if _, err := NewRegistry[Order]().Build(s); err == nil || !strings.Contains(err.Error(), "through a pointer") {
+		t.Errorf("Expected a pointer error, got %v", err)
+	}
+}
+
+func TestSort_StableMultiKey(t *testing.T) {
+	ids := buildAndRun(t, NewRegistry[Order](), "steps:\n  - op: sort\n    by:\n      - region desc\n      - amount\n")
+	if expected := []int{2, 4, 1, 5, 3}; !equalIDs(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+
+	ids = buildAndRun(t, NewRegistry[Order](), "steps:\n  - op: sort\n    by: Status\n")
+	if expected := []int{1, 3, 4, 5, 2}; !equalIDs(ids, expected) {
+		t.Errorf("Expected equal keys to keep their order %v, got %v", expected, ids)
+	}
+}
+
+func TestDistinctAndGroupBy(t *testing.T) {
+	ids := buildAndRun(t, NewRegistry[Order](), "steps:\n  - op: distinct\n    fields: [Region, Status]\n")
+	if expected := []int{1, 2, 4}; !equalIDs(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+
+	ids = buildAndRun(t, NewRegistry[Order](), "steps:\n  - op: distinct\n")
+	if len(ids) != 5 {
+		t.Errorf("Expected all 5 orders, got %v", ids)
+	}
+
+	ids = buildAndRun(t, NewRegistry[Order](), "steps:\n  - op: groupBy\n    fields: Region\n")
+	if expected := []int{1, 3, 5, 2, 4}; !equalIDs(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+
+	ids = buildAndRun(t, NewRegistry[Order](), `
+steps:
+  - op: sort
+    by: Amount desc
+  - op: groupBy
+    fields: Region
+    limit: 1
+`)
+	if expected := []int{3, 4}; !equalIDs(ids, expected) {
+		t.Errorf("Expected the largest order per region %v, got %v", expected, ids)
+	}
+}
+
+func TestDistinct_TimeInstants(t *testing.T) {
+	orders := sampleOrders()
+	// The same instant in another location is a duplicate.
+	orders[1].Placed = orders[0].Placed.In(time.FixedZone("JST", 9*60*60))
+	s, err := Parse([]byte("steps:\n  - op: distinct\n    fields: Placed\n"))
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	pipeline, err := NewRegistry[Order]().Build(s)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result, err := pipeline.WithData(orders).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 4 || result[1].ID != 3 {
+		t.Errorf("Expected order 2 to be dropped, got %v", result)
+	}
+}
@@ -0,0 +1,277 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+// Factory builds an operation from the parameters of a step.
+// Parameter accessors record their errors in params, so a factory can read all of its
+// parameters and return the operation; the registry reports the recorded errors.
+type Factory[T comparable] func(params *Params[T]) (algo.Operation[T], error)
+
+// Registry maps operation names to factories for pipelines over T.
+// A new registry holds the built-in operations: filter, map, sort, take, skip, distinct and groupBy.
+type Registry[T comparable] struct {
+	factories  map[string]Factory[T]
+	mappers    map[string]func(T) T
+	predicates map[string]func(T) bool
+}
+
+// NewRegistry creates a registry with the built-in operations.
+//
+// Example:
+//
+//	registry := spec.NewRegistry[Order]().
+//	    RegisterPredicate("isVIP", func(o Order) bool { return o.Customer.VIP }).
+//	    RegisterMapper("anonymize", anonymize)
+func NewRegistry[T comparable]() *Registry[T] {
+	r := &Registry[T]{
+		factories:  make(map[string]Factory[T]),
+		mappers:    make(map[string]func(T) T),
+		predicates: make(map[string]func(T) bool),
+	}
+	r.Register("filter", r.buildFilter)
+	r.Register("map", r.buildMap)
+	r.Register("sort", buildSort[T])
+	r.Register("take", buildTake[T])
+	r.Register("skip", buildSkip[T])
+	r.Register("distinct", buildDistinct[T])
+	r.Register("groupBy", buildGroupBy[T])
+	return r
+}
+
+// Register adds an operation under a name, replacing any operation registered under the same name.
+//
+// Example:
+//
+//	registry.Register("topPerRegion", func(params *spec.Params[Order]) (algo.Operation[Order], error) {
+//	    n := params.Int("n")
+//	    return &TopPerRegionOperation{N: n}, nil
+//	})
+func (r *Registry[T]) Register(name string, factory Factory[T]) *Registry[T] {
+	r.factories[name] = factory
+	return r
+}
+
+// RegisterMapper names a function that "map" steps can refer to with their "func" parameter.
+//
+// Example:
+//
+//	registry.RegisterMapper("roundAmount", func(o Order) Order {
+//	    o.Amount = math.Round(o.Amount)
+//	    return o
+//	})
+func (r *Registry[T]) RegisterMapper(name string, mapper func(T) T) *Registry[T] {
+	r.mappers[name] = mapper
+	return r
+}
+
+// RegisterPredicate names a function that "filter" steps can refer to with their "func" parameter.
+//
+// Example:
+//
+//	registry.RegisterPredicate("isVIP", func(o Order) bool { return o.Customer.VIP })
+func (r *Registry[T]) RegisterPredicate(name string, predicate func(T) bool) *Registry[T] {
+	r.predicates[name] = predicate
+	return r
+}
+
+// Operations returns the registered operation names in sorted order.
+func (r *Registry[T]) Operations() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build validates every step of the spec against T and returns a pipeline with the
+// corresponding operations. All invalid steps are reported, each as a *StepError.
+//
+// Example:
+//
+//	pipeline, err := registry.Build(s)
+//	if err != nil {
+//	    log.Fatal(err) // step 2 (sort): field "Amout" not found in main.Order
+//	}
+//	result, err := pipeline.WithData(orders).Execute()
+func (r *Registry[T]) Build(s *Spec) (*algo.Pipeline[T], error) {
+	pipeline := algo.NewPipeline[T]()
+	var errs []error
+	for i, step := range s.Steps {
+		op, err := r.buildStep(step)
+		if err != nil {
+			errs = append(errs, &StepError{Index: i + 1, Op: step.Op, Err: err})
+			continue
+		}
+		pipeline.AddOperation(op)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return pipeline, nil
+}
+
+// LoadFile reads a spec from a file with LoadFile and builds it.
+//
+// Example:
+//
+//	pipeline, err := spec.NewRegistry[Order]().LoadFile("reports/weekly.yaml")
+func (r *Registry[T]) LoadFile(path string) (*algo.Pipeline[T], error) {
+	s, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return r.Build(s)
+}
+
+// buildStep builds the operation of a single step and checks that all parameters were used.
+func (r *Registry[T]) buildStep(step Step) (algo.Operation[T], error) {
+	factory, ok := r.factories[step.Op]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %q", step.Op)
+	}
+
+	params := &Params[T]{values: step.Params, used: make(map[string]bool)}
+	op, err := factory(params)
+	if err == nil {
+		err = params.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for key := range step.Params {
+		if !params.used[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown parameter %s", strings.Join(unknown, ", "))
+	}
+	return op, nil
+}
+
+// Params gives a factory typed access to the parameters of a step.
+// Accessors of missing or invalid parameters return zero values and record an error,
+// which Err returns.
+type Params[T comparable] struct {
+	values map[string]any
+	used   map[string]bool
+	errs   []error
+}
+
+// Has reports whether the step sets a parameter.
+func (p *Params[T]) Has(key string) bool {
+	_, ok := p.values[key]
+	return ok
+}
+
+// Value returns a required parameter as parsed from the spec.
+func (p *Params[T]) Value(key string) any {
+	value, ok := p.values[key]
+	p.used[key] = true
+	if !ok {
+		p.errs = append(p.errs, fmt.Errorf("missing parameter %q", key))
+	}
+	return value
+}
+
+// String returns a required string parameter.
+func (p *Params[T]) String(key string) string {
+	value := p.Value(key)
+	if value == nil {
+		return ""
+	}
+	s, ok := value.(string)
+	if !ok {
+		p.errs = append(p.errs, fmt.Errorf("parameter %q: expected a string, got %v", key, value))
+	}
+	return s
+}
+
+// Int returns a required non-negative integer parameter.
+func (p *Params[T]) Int(key string) int {
+	value := p.Value(key)
+	if value == nil {
+		return 0
+	}
+	n, ok := value.(int64)
+	if !ok || n < 0 || int64(int(n)) != n {
+		p.errs = append(p.errs, fmt.Errorf("parameter %q: expected a non-negative integer, got %v", key, value))
+		return 0
+	}
+	return int(n)
+}
+
+// Strings returns a required list of strings. A single string is treated as a list of one.
+func (p *Params[T]) Strings(key string) []string {
+	value := p.Value(key)
+	if value == nil {
+		return nil
+	}
+	if s, ok := value.(string); ok {
+		return []string{s}
+	}
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		p.errs = append(p.errs, fmt.Errorf("parameter %q: expected a list of strings, got %v", key, value))
+		return nil
+	}
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			p.errs = append(p.errs, fmt.Errorf("parameter %q: expected a list of strings, got %v", key, value))
+			return nil
+		}
+		strs = append(strs, s)
+	}
+	return strs
+}
+
+// Field resolves a required parameter naming a field of T.
+func (p *Params[T]) Field(key string) Field[T] {
+	name := p.String(key)
+	if name == "" {
+		return Field[T]{}
+	}
+	field, err := ResolveField[T](name)
+	if err != nil {
+		p.errs = append(p.errs, err)
+	}
+	return field
+}
+
+// Fields resolves a required parameter holding a field name or a list of field names.
+func (p *Params[T]) Fields(key string) []Field[T] {
+	names := p.Strings(key)
+	fields := make([]Field[T], 0, len(names))
+	for _, name := range names {
+		field, err := ResolveField[T](name)
+		if err != nil {
+			p.errs = append(p.errs, err)
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// Fail records an error found by a factory.
+func (p *Params[T]) Fail(err error) {
+	p.errs = append(p.errs, err)
+}
+
+// Err returns the errors recorded so far, joined.
+func (p *Params[T]) Err() error {
+	return errors.Join(p.errs...)
+}
@@ -0,0 +1,253 @@
+package spec
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NaokiOouchi/GoAlgoChain/pkg/algo"
+)
+
+type Customer struct {
+	Name    string
+	Country string `algo:"country"`
+	VIP     bool
+}
+
+type Order struct {
+	ID       int
+	Region   string
+	Status   string
+	Amount   float64
+	Placed   time.Time
+	Customer Customer
+	Referrer *Customer
+}
+
+func sampleOrders() []Order {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	return []Order{
+		{ID: 1, Region: "EU", Status: "completed", Amount: 120, Placed: day(1), Customer: Customer{Country: "DE"}},
+		{ID: 2, Region: "US", Status: "pending", Amount: 80, Placed: day(2), Customer: Customer{Country: "US", VIP: true}},
+		{ID: 3, Region: "EU", Status: "completed", Amount: 300, Placed: day(3), Customer: Customer{Country: "FR"}},
+		{ID: 4, Region: "US", Status: "completed", Amount: 150, Placed: day(4), Customer: Customer{Country: "US"}},
+		{ID: 5, Region: "EU", Status: "completed", Amount: 120, Placed: day(5), Customer: Customer{Country: "DE", VIP: true}},
+	}
+}
+
+func orderIDs(orders []Order) []int {
+	ids := make([]int, len(orders))
+	for i, o := range orders {
+		ids[i] = o.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func buildAndRun(t *testing.T, registry *Registry[Order], document string) []int {
+	t.Helper()
+	s, err := Parse([]byte(document))
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	pipeline, err := registry.Build(s)
+	if err != nil {
+		t.Fatalf("Unexpected build error: %v", err)
+	}
+	result, err := pipeline.WithData(sampleOrders()).Execute()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return orderIDs(result)
+}
+
+func TestRegistry_BuildYAML(t *testing.T) {
+	ids := buildAndRun(t, NewRegistry[Order](), `
+name: top completed orders
+steps:
+  - op: filter
+    field: status
+    eq: completed
+  - op: sort
+    by: [Amount desc, ID]
+  - op: skip
+    count: 1
+  - op: take
+    count: 2
+`)
+	if expected := []int{4, 1}; !equalIDs(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+}
+
+func TestRegistry_BuildJSON(t *testing.T) {
+	ids := buildAndRun(t, NewRegistry[Order](), `{
+		"steps": [
+			{"op": "filter", "field": "customer.country", "in": ["DE", "FR"]},
+			{"op": "distinct", "fields": "Amount"}
+		]
+	}`)
+	if expected := []int{1, 3}; !equalIDs(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+}
+
+func TestRegistry_RegisteredFunctions(t *testing.T) {
+	registry := NewRegistry[Order]().
+		RegisterPredicate("vip", func(o Order) bool { return o.Customer.VIP }).
+		RegisterMapper("double", func(o Order) Order {
+			o.ID *= 2
+			return o
+		})
+
+	ids := buildAndRun(t, registry, "steps:\n- op: filter\n  func: vip\n- op: map\n  func: double\n")
+	if expected := []int{4, 10}; !equalIDs(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+}
+
+// topOperation keeps the N orders with the highest amount.
+type topOperation struct {
+	n int
+}
+
+func (t *topOperation) Apply(data []Order) ([]Order, error) {
+	return algo.NewPipelineWithData(data).
+		MergeSort(func(a, b Order) bool { return a.Amount > b.Amount }).
+		Take(t.n).
+		Execute()
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := NewRegistry[Order]().Register("top", func(params *Params[Order]) (algo.Operation[Order], error) {
+		return &topOperation{n: params.Int("n")}, nil
+	})
+
+	ids := buildAndRun(t, registry, "steps:\n  - op: top\n    n: 2\n")
+	if expected := []int{3, 4}; !equalIDs(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+
+	operations := strings.Join(registry.Operations(), ",")
+	if operations != "distinct,filter,groupBy,map,skip,sort,take,top" {
+		t.Errorf("Unexpected operations: %s", operations)
+	}
+
+	s, _ := Parse([]byte("steps:\n  - op: top\n    n: -1\n    limit: 3\n"))
+	if _, err := registry.Build(s); err == nil || !strings.Contains(err.Error(), "step 1 (top)") {
+		t.Errorf("Expected a step error, got %v", err)
+	}
+}
+
+func TestRegistry_BuildErrors(t *testing.T) {
+	s, err := ParseYAML([]byte(`
+steps:
+  - op: filter
+    field: Stauts
+    eq: completed
+  - op: take
+    count: 10
+  - op: sort
+    by: Amount sideways
+  - op: limit
+    count: 3
+  - op: filter
+    field: Amount
+    gt: lots
+  - op: skip
+    count: 1
+    offset: 2
+  - op: filter
+    field: Referrer
+    eq: x
+`))
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+
+	_, err = NewRegistry[Order]().Build(s)
+	if err == nil {
+		t.Fatal("Expected build error")
+	}
+	message := err.Error()
+	for _, expected := range []string{
+		`step 1 (filter): unknown field "Stauts" in spec.Order`,
+		`step 3 (sort): invalid sort order "sideways"`,
+		`step 4 (limit): unknown operation "limit"`,
+		`step 5 (filter): field Amount: expected a number, got "lots"`,
+		`step 6 (skip): unknown parameter offset`,
+		`step 7 (filter): field Referrer of type *spec.Customer cannot be compared`,
+	} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("Expected error to contain %q, got:\n%s", expected, message)
+		}
+	}
+	if strings.Contains(message, "step 2") {
+		t.Errorf("Did not expect an error for step 2, got:\n%s", message)
+	}
+
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Index != 1 {
+		t.Errorf("Expected a *StepError for step 1, got %v", stepErr)
+	}
+}
+
+func TestRegistry_LoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := os.WriteFile(path, []byte(`{"steps": [{"op": "take", "count": 1}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline, err := NewRegistry[Order]().LoadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result, _ := pipeline.WithData(sampleOrders()).Execute()
+	if len(result) != 1 {
+		t.Errorf("Expected 1 order, got %d", len(result))
+	}
+
+	if _, err := NewRegistry[Order]().LoadFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
+
+func TestParams(t *testing.T) {
+	params := &Params[Order]{
+		values: map[string]any{"name": "x", "n": int64(3), "list": []any{"a", "b"}, "bad": 1.5},
+		used:   make(map[string]bool),
+	}
+	if params.String("name") != "x" || params.Int("n") != 3 || len(params.Strings("list")) != 2 {
+		t.Error("Unexpected parameter values")
+	}
+	if params.Err() != nil {
+		t.Fatalf("Unexpected error: %v", params.Err())
+	}
+
+	params.Int("bad")
+	params.String("missing")
+	params.Fields("list")
+	err := params.Err()
+	if err == nil {
+		t.Fatal("Expected errors")
+	}
+	for _, expected := range []string{`parameter "bad"`, `missing parameter "missing"`, `unknown field "a"`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain %q, got %v", expected, err)
+		}
+	}
+}
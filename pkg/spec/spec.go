@@ -0,0 +1,219 @@
+// Package spec builds pipelines from declarative specs, so that limits, sort keys and filters
+// can be changed in a config file instead of in code.
+//
+// A spec is a list of steps. Each step names an operation from a Registry and carries its
+// parameters; fields are referred to by Go field name or `algo` tag, with dots for nested
+// structs. Specs can be written in JSON or in a YAML subset:
+//
+//	name: top completed orders
+//	steps:
+//	  - op: filter
+//	    field: Status
+//	    eq: completed
+//	  - op: sort
+//	    by: [Region, Amount desc]
+//	  - op: take
+//	    count: 10
+//
+// Building the spec validates every step against the element type, so a misspelled field or
+// a value of the wrong type is reported before any data is processed:
+//
+//	s, err := spec.LoadFile("report.yaml")
+//	pipeline, err := spec.NewRegistry[Order]().Build(s)
+//	result, err := pipeline.WithData(orders).Execute()
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Spec is a declarative description of a pipeline.
+type Spec struct {
+	Name  string
+	Steps []Step
+}
+
+// Step is a single operation of a spec.
+// Params holds the remaining keys of the step; values are strings, int64, float64, bool,
+// nil or []any of those.
+type Step struct {
+	Op     string
+	Params map[string]any
+}
+
+// StepError describes a step that could not be built.
+// Index is the 1-based position of the step in the spec.
+type StepError struct {
+	Index int
+	Op    string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *StepError) Error() string {
+	if e.Op == "" {
+		return fmt.Sprintf("step %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("step %d (%s): %v", e.Index, e.Op, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Parse reads a spec in JSON or YAML.
+// Input starting with '{' is read as JSON, anything else as YAML.
+//
+// Example:
+//
+//	s, err := spec.Parse([]byte(`{"steps": [{"op": "take", "count": 10}]}`))
+func Parse(data []byte) (*Spec, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return ParseJSON(data)
+	}
+	return ParseYAML(data)
+}
+
+// ParseJSON reads a spec from a JSON object with an optional "name" and a "steps" array.
+// Each step is an object with an "op" key; its other keys are the parameters of the step.
+//
+// Example:
+//
+//	s, err := spec.ParseJSON([]byte(`{
+//	    "name": "top orders",
+//	    "steps": [
+//	        {"op": "sort", "by": "Amount desc"},
+//	        {"op": "take", "count": 10}
+//	    ]
+//	}`))
+func ParseJSON(data []byte) (*Spec, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("spec: %w", err)
+	}
+	if decoder.More() {
+		return nil, errors.New("spec: unexpected data after the JSON object")
+	}
+	return fromDocument(normalizeJSON(document))
+}
+
+// ParseYAML reads a spec written in a subset of YAML: block mappings and sequences, flow
+// sequences such as [a, b], plain and quoted scalars, and # comments.
+// The document has the same shape as the JSON form.
+//
+// Example:
+//
+//	s, err := spec.ParseYAML([]byte("steps:\n  - op: take\n    count: 10\n"))
+func ParseYAML(data []byte) (*Spec, error) {
+	document, err := parseYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("spec: %w", err)
+	}
+	return fromDocument(document)
+}
+
+// LoadFile reads a spec from a file.
+// Files ending in .json are read as JSON and files ending in .yaml or .yml as YAML;
+// other files are detected by their content.
+//
+// Example:
+//
+//	s, err := spec.LoadFile("reports/weekly.yaml")
+func LoadFile(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("spec: %w", err)
+	}
+
+	var s *Spec
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		s, err = ParseJSON(data)
+	case ".yaml", ".yml":
+		s, err = ParseYAML(data)
+	default:
+		s, err = Parse(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// fromDocument converts a decoded document into a Spec.
+func fromDocument(document any) (*Spec, error) {
+	root, ok := document.(map[string]any)
+	if !ok {
+		return nil, errors.New("spec: expected an object with a \"steps\" list")
+	}
+
+	s := &Spec{}
+	for key, value := range root {
+		switch key {
+		case "name":
+			name, ok := value.(string)
+			if !ok {
+				return nil, errors.New("spec: \"name\" must be a string")
+			}
+			s.Name = name
+		case "steps":
+		default:
+			return nil, fmt.Errorf("spec: unknown key %q", key)
+		}
+	}
+
+	steps, ok := root["steps"].([]any)
+	if !ok {
+		return nil, errors.New("spec: expected a \"steps\" list")
+	}
+	for i, value := range steps {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, &StepError{Index: i + 1, Err: errors.New("expected an object")}
+		}
+		op, ok := object["op"].(string)
+		if !ok || op == "" {
+			return nil, &StepError{Index: i + 1, Err: errors.New("missing \"op\"")}
+		}
+
+		params := make(map[string]any, len(object)-1)
+		for key, param := range object {
+			if key != "op" {
+				params[key] = param
+			}
+		}
+		s.Steps = append(s.Steps, Step{Op: op, Params: params})
+	}
+	return s, nil
+}
+
+// normalizeJSON converts json.Number values into int64 or float64, matching the YAML parser.
+func normalizeJSON(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i := range v {
+			v[i] = normalizeJSON(v[i])
+		}
+	case map[string]any:
+		for key := range v {
+			v[key] = normalizeJSON(v[key])
+		}
+	}
+	return value
+}
@@ -0,0 +1,95 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse_JSONAndYAMLAgree(t *testing.T) {
+	fromJSON, err := Parse([]byte(`{
+		"name": "report",
+		"steps": [
+			{"op": "filter", "field": "Amount", "gt": 100, "lte": 2.5e2},
+			{"op": "sort", "by": ["Region", "Amount desc"]},
+			{"op": "map", "field": "Status", "set": null}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fromYAML, err := Parse([]byte(`
+# weekly report
+name: report
+steps:
+- op: filter
+  field: Amount
+  gt: 100
+  lte: 250.0
+- op: sort
+  by:
+    - Region
+    - Amount desc
+- op: map
+  field: Status
+  set: ~
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(fromJSON, fromYAML) {
+		t.Errorf("Expected equal specs:\n%+v\n%+v", fromJSON, fromYAML)
+	}
+	if fromJSON.Name != "report" || len(fromJSON.Steps) != 3 || fromJSON.Steps[0].Params["gt"] != int64(100) {
+		t.Errorf("Unexpected spec %+v", fromJSON)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := map[string]string{
+		`{"steps": [{"op": "take"}]} {}`:    "unexpected data",
+		`{"steps": {"op": "take"}}`:         `expected a "steps" list`,
+		`{"steps": [{"count": 1}]}`:         `step 1: missing "op"`,
+		`{"steps": [3]}`:                    "step 1: expected an object",
+		`{"name": 1, "steps": []}`:          `"name" must be a string`,
+		"title: x\nsteps: []":               `unknown key "title"`,
+		"- op: take":                        `expected an object`,
+		"steps:\n  - op: take\n   count: 1": "line 3: unexpected indentation",
+		"":                                  "empty document",
+	}
+	for input, expected := range cases {
+		_, err := Parse([]byte(input))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%q: expected error containing %q, got %v", input, expected, err)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.json": `{"steps": [{"op": "take", "count": 1}]}`,
+		"b.yml":  "steps:\n  - op: take\n    count: 1\n",
+		"c.spec": "steps: [ ]\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadFile(path); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+
+	path := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(path, []byte("steps: []"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil || !strings.HasPrefix(err.Error(), path) {
+		t.Errorf("Expected an error naming the file, got %v", err)
+	}
+}
@@ -0,0 +1,285 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a non-blank line of a YAML document with its comment removed.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// yamlParser parses the block structure of a YAML document line by line.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML parses a YAML document into maps, slices and scalars.
+func parseYAML(data []byte) (any, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(string(data), "\n") {
+		raw = strings.TrimRight(raw, "\r")
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed in indentation", i+1)
+		}
+		text = strings.TrimSpace(stripComment(text))
+		if text == "" || text == "---" {
+			continue
+		}
+		p.lines = append(p.lines, yamlLine{number: i + 1, indent: len(raw) - len(strings.TrimLeft(raw, " ")), text: text})
+	}
+	if len(p.lines) == 0 {
+		return nil, errors.New("empty document")
+	}
+
+	value, err := p.parseBlock(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].number)
+	}
+	return value, nil
+}
+
+// parseBlock parses the mapping or sequence starting at the current line.
+func (p *yamlParser) parseBlock(indent int) (any, error) {
+	if isSequenceItem(p.lines[p.pos].text) {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+// parseMapping parses "key: value" lines at the given indentation.
+func (p *yamlParser) parseMapping(indent int) (map[string]any, error) {
+	mapping := make(map[string]any)
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && !isSequenceItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		key, rest, ok := splitKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", line.number)
+		}
+		if _, exists := mapping[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.number, key)
+		}
+		p.pos++
+
+		if rest != "" {
+			value, err := parseFlow(rest)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line.number, err)
+			}
+			mapping[key] = value
+			continue
+		}
+
+		// A key without a value holds the block below it: either indented further, or a
+		// sequence at the same indentation.
+		var value any
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			if next.indent > indent || (next.indent == indent && isSequenceItem(next.text)) {
+				var err error
+				if value, err = p.parseBlock(next.indent); err != nil {
+					return nil, err
+				}
+			}
+		}
+		mapping[key] = value
+	}
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].number)
+	}
+	return mapping, nil
+}
+
+// parseSequence parses "- item" lines at the given indentation.
+// An item of the form "- key: value" starts a mapping that continues on the following lines
+// aligned with its first key.
+func (p *yamlParser) parseSequence(indent int) ([]any, error) {
+	sequence := []any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isSequenceItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		rest := strings.TrimLeft(line.text[1:], " ")
+		if rest == "" {
+			p.pos++
+			if p.pos >= len(p.lines) || p.lines[p.pos].indent <= indent {
+				sequence = append(sequence, nil)
+				continue
+			}
+			value, err := p.parseBlock(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			sequence = append(sequence, value)
+			continue
+		}
+
+		if _, _, ok := splitKey(rest); ok || isSequenceItem(rest) {
+			// Re-read the rest of the line as the first line of a nested block.
+			itemIndent := indent + len(line.text) - len(rest)
+			p.lines[p.pos] = yamlLine{number: line.number, indent: itemIndent, text: rest}
+			value, err := p.parseBlock(itemIndent)
+			if err != nil {
+				return nil, err
+			}
+			sequence = append(sequence, value)
+			continue
+		}
+
+		value, err := parseFlow(rest)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		sequence = append(sequence, value)
+		p.pos++
+	}
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].number)
+	}
+	return sequence, nil
+}
+
+// isSequenceItem reports whether a line starts a sequence item.
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitKey splits "key: value" at the first colon outside quotes that is followed by a space
+// or ends the line.
+func splitKey(text string) (key, rest string, ok bool) {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			key = strings.TrimSpace(text[:i])
+			if unquoted, err := parseScalar(key); err == nil {
+				if s, isString := unquoted.(string); isString {
+					key = s
+				}
+			}
+			return key, strings.TrimSpace(text[i+1:]), key != ""
+		case c == '[' || c == '{':
+			return "", "", false
+		}
+	}
+	return "", "", false
+}
+
+// stripComment removes a # comment that starts the line or follows a space, outside quotes.
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' '):
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// parseFlow parses an inline value: a flow sequence such as [a, "b, c"] or a scalar.
+func parseFlow(text string) (any, error) {
+	if strings.HasPrefix(text, "{") {
+		return nil, errors.New("flow mappings are not supported")
+	}
+	if !strings.HasPrefix(text, "[") {
+		return parseScalar(text)
+	}
+	if !strings.HasSuffix(text, "]") {
+		return nil, fmt.Errorf("unterminated sequence %s", text)
+	}
+
+	inner := strings.TrimSpace(text[1 : len(text)-1])
+	items := []any{}
+	if inner == "" {
+		return items, nil
+	}
+
+	var quote byte
+	start := 0
+	for i := 0; i <= len(inner); i++ {
+		if i < len(inner) {
+			c := inner[i]
+			if quote != 0 {
+				if c == quote {
+					quote = 0
+				}
+				continue
+			}
+			if c == '"' || c == '\'' {
+				quote = c
+				continue
+			}
+			if c == '[' || c == ']' {
+				return nil, errors.New("nested sequences are not supported")
+			}
+			if c != ',' {
+				continue
+			}
+		}
+		item, err := parseScalar(strings.TrimSpace(inner[start:i]))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		start = i + 1
+	}
+	return items, nil
+}
+
+// parseScalar parses a plain or quoted scalar.
+// Plain scalars become int64, float64, bool or nil where they look like one, and strings otherwise.
+func parseScalar(text string) (any, error) {
+	switch {
+	case strings.HasPrefix(text, `"`):
+		s, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quoted string %s", text)
+		}
+		return s, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("invalid quoted string %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f, nil
+	}
+	return text, nil
+}
@@ -0,0 +1,64 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseYAML_Structure(t *testing.T) {
+	document, err := parseYAML([]byte(`
+a: plain text # comment
+b: "quoted # not a comment"
+'c': 'it''s'
+d: [1, -2.5, true, "x, y", null]
+e:
+  f: 1
+  g:
+    - one
+    -
+      - nested
+    - h: 2
+      i: 3
+j: []
+k:
+url: http://example.com/a#b
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]any{
+		"a": "plain text",
+		"b": "quoted # not a comment",
+		"c": "it's",
+		"d": []any{int64(1), -2.5, true, "x, y", nil},
+		"e": map[string]any{
+			"f": int64(1),
+			"g": []any{"one", []any{"nested"}, map[string]any{"h": int64(2), "i": int64(3)}},
+		},
+		"j":   []any{},
+		"k":   nil,
+		"url": "http://example.com/a#b",
+	}
+	if !reflect.DeepEqual(document, expected) {
+		t.Errorf("Expected:\n%#v\ngot:\n%#v", expected, document)
+	}
+}
+
+func TestParseYAML_Errors(t *testing.T) {
+	cases := []string{
+		"a: 1\na: 2",
+		"a: {b: 1}",
+		"a: [1, 2",
+		"a: [[1]]",
+		"a: \"open",
+		"\ta: 1",
+		"a: 1\n  b: 2",
+		"just text",
+	}
+	for _, input := range cases {
+		if _, err := parseYAML([]byte(input)); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+}